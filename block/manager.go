@@ -30,6 +30,7 @@ import (
 	"github.com/rollkit/go-sequencing/proxy/grpc"
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/evidence"
 	"github.com/rollkit/rollkit/mempool"
 	"github.com/rollkit/rollkit/state"
 	"github.com/rollkit/rollkit/store"
@@ -118,6 +119,9 @@ type Manager struct {
 
 	executor *state.BlockExecutor
 
+	// evpool collects evidence of sequencer misbehavior detected while syncing; it's optional
+	evpool *evidence.Pool
+
 	dalc *da.DAClient
 	// daHeight is the height of the latest processed DA block
 	daHeight uint64
@@ -198,6 +202,7 @@ func NewManager(
 	store store.Store,
 	mempool mempool.Mempool,
 	mempoolReaper *mempool.CListMempoolReaper,
+	evpool *evidence.Pool,
 	seqClient *grpc.Client,
 	proxyApp proxy.AppConnConsensus,
 	dalc *da.DAClient,
@@ -248,7 +253,12 @@ func NewManager(
 	// allow buffer for the block header and protocol encoding
 	maxBlobSize -= blockProtocolOverhead
 
-	exec := state.NewBlockExecutor(proposerAddress, genesis.ChainID, mempool, mempoolReaper, proxyApp, eventBus, maxBlobSize, logger, execMetrics)
+	// nil *evidence.Pool must not be wrapped in non-nil interface
+	var execEvpool state.EvidencePool
+	if evpool != nil {
+		execEvpool = evpool
+	}
+	exec := state.NewBlockExecutor(proposerAddress, genesis.ChainID, mempool, mempoolReaper, execEvpool, proxyApp, eventBus, maxBlobSize, logger, execMetrics)
 	if s.LastBlockHeight+1 == uint64(genesis.InitialHeight) { //nolint:gosec
 		res, err := exec.InitChain(genesis)
 		if err != nil {
@@ -286,6 +296,7 @@ func NewManager(
 		lastState:   s,
 		store:       store,
 		executor:    exec,
+		evpool:      evpool,
 		dalc:        dalc,
 		daHeight:    s.DAHeight,
 		// channels are buffered to avoid blocking on input/output operations, buffer sizes are arbitrary
@@ -314,6 +325,38 @@ func NewManager(
 	return agg, nil
 }
 
+// detectDoubleSign compares header with stored (or cached) header of the same height. If headers are different,
+// evidence of double signing is reported to evidence pool.
+func (m *Manager) detectDoubleSign(ctx context.Context, header *types.SignedHeader) {
+	if m.evpool == nil {
+		return
+	}
+	height := header.Height()
+	var other *types.SignedHeader
+	if height <= m.store.Height() {
+		stored, _, err := m.store.GetBlockData(ctx, height)
+		if err != nil {
+			m.logger.Debug("failed to load header for double sign detection", "height", height, "error", err)
+			return
+		}
+		other = stored
+	} else {
+		other = m.headerCache.getHeader(height)
+	}
+	if other == nil || bytes.Equal(other.Hash(), header.Hash()) {
+		return
+	}
+	ev, err := m.evpool.ReportConflictingHeaders(ctx, other, header)
+	switch {
+	case errors.Is(err, evidence.ErrEvidenceAlreadyPending), errors.Is(err, evidence.ErrEvidenceCommitted):
+		// already known
+	case err != nil:
+		m.logger.Error("failed to report conflicting headers", "height", height, "error", err)
+	default:
+		m.logger.Error("detected double signing by sequencer", "height", height, "evidence", ev)
+	}
+}
+
 func (m *Manager) init(ctx context.Context) {
 	// initialize da included height
 	if height, err := m.store.GetMetadata(ctx, DAIncludedHeightKey); err == nil && len(height) == 8 {
@@ -342,6 +385,26 @@ func (m *Manager) GetDAIncludedHeight() uint64 {
 	return m.daIncludedHeight.Load()
 }
 
+// GetDAHeight returns the height of the next DA block to be retrieved by the manager.
+func (m *Manager) GetDAHeight() uint64 {
+	return atomic.LoadUint64(&m.daHeight)
+}
+
+// GetPendingHeadersCount returns the number of headers waiting for submission to DA.
+func (m *Manager) GetPendingHeadersCount() uint64 {
+	return m.pendingHeaders.numPendingHeaders()
+}
+
+// GetLastSubmittedHeight returns the height of the last header successfully submitted to DA.
+func (m *Manager) GetLastSubmittedHeight() uint64 {
+	return m.pendingHeaders.lastSubmittedHeight.Load()
+}
+
+// IsProposer returns whether the manager is the proposer (sequencer) of the rollup.
+func (m *Manager) IsProposer() bool {
+	return m.isProposer
+}
+
 // SetDALC is used to set DataAvailabilityLayerClient used by Manager.
 func (m *Manager) SetDALC(dalc *da.DAClient) {
 	m.dalc = dalc
@@ -643,6 +706,7 @@ func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 				"daHeight", daHeight,
 				"hash", headerHash,
 			)
+			m.detectDoubleSign(ctx, header)
 			if headerHeight <= m.store.Height() || m.headerCache.isSeen(headerHash) {
 				m.logger.Debug("header already seen", "height", headerHeight, "block hash", headerHash)
 				continue
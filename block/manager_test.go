@@ -36,6 +36,7 @@ import (
 	seqGRPC "github.com/rollkit/go-sequencing/proxy/grpc"
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/evidence"
 	"github.com/rollkit/rollkit/mempool"
 	"github.com/rollkit/rollkit/state"
 	"github.com/rollkit/rollkit/store"
@@ -250,6 +251,65 @@ func TestIsDAIncluded(t *testing.T) {
 	require.True(m.IsDAIncluded(hash))
 }
 
+func TestDetectDoubleSign(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	chainID := "TestDetectDoubleSign"
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	st := store.New(kv)
+
+	header, privKey, err := types.GetRandomSignedHeader(chainID)
+	require.NoError(err)
+	header.BaseHeader.Height = 1
+	signature, err := types.GetSignature(header.Header, privKey)
+	require.NoError(err)
+	header.Signature = *signature
+	require.NoError(st.SaveBlockData(ctx, header, &types.Data{}, &header.Signature))
+	st.SetHeight(ctx, 1)
+	require.NoError(st.UpdateState(ctx, types.State{
+		ChainID:         chainID,
+		LastBlockHeight: 1,
+		LastBlockTime:   header.Time(),
+		Validators:      header.Validators,
+		NextValidators:  header.Validators,
+		LastValidators:  header.Validators,
+	}))
+	evpool := evidence.NewPool(ds.NewMapDatastore(), st, nil, test.NewLogger(t))
+
+	m := &Manager{
+		store:       st,
+		headerCache: NewHeaderCache(),
+		evpool:      evpool,
+		logger:      test.NewLogger(t),
+	}
+
+	// the same header is not a double sign
+	m.detectDoubleSign(ctx, header)
+	pending, _ := evpool.PendingEvidence(-1)
+	require.Empty(pending)
+
+	// conflicting with stored header
+	conflicting, err := types.GetConflictingSignedHeader(header, privKey)
+	require.NoError(err)
+	m.detectDoubleSign(ctx, conflicting)
+	pending, _ = evpool.PendingEvidence(-1)
+	require.Len(pending, 1)
+	require.Equal(int64(1), pending[0].Height())
+
+	// conflicting with cached header
+	next, err := types.GetRandomNextSignedHeader(header, privKey, chainID)
+	require.NoError(err)
+	m.headerCache.setHeader(next.Height(), next)
+	conflicting, err = types.GetConflictingSignedHeader(next, privKey)
+	require.NoError(err)
+	m.detectDoubleSign(ctx, conflicting)
+	m.detectDoubleSign(ctx, conflicting)
+	pending, _ = evpool.PendingEvidence(-1)
+	require.Len(pending, 2)
+}
+
 func TestSubmitBlocksToMockDA(t *testing.T) {
 	ctx := context.Background()
 
@@ -467,16 +527,14 @@ func Test_submitBlocksToDA_BlockMarshalErrorCase1(t *testing.T) {
 
 	m := getManager(t, goDATest.NewDummyDA())
 
-	header1, data1 := types.GetRandomBlock(uint64(1), 5, chainID)
-	header2, data2 := types.GetRandomBlock(uint64(2), 5, chainID)
-	header3, data3 := types.GetRandomBlock(uint64(3), 5, chainID)
+	header1, _ := types.GetRandomBlock(uint64(1), 5, chainID)
+	header2, _ := types.GetRandomBlock(uint64(2), 5, chainID)
+	header3, _ := types.GetRandomBlock(uint64(3), 5, chainID)
 
 	store := mocks.NewStore(t)
 	invalidateBlockHeader(header1)
 	store.On("GetMetadata", ctx, LastSubmittedHeightKey).Return(nil, ds.ErrNotFound)
-	store.On("GetBlockData", ctx, uint64(1)).Return(header1, data1, nil)
-	store.On("GetBlockData", ctx, uint64(2)).Return(header2, data2, nil)
-	store.On("GetBlockData", ctx, uint64(3)).Return(header3, data3, nil)
+	mockIterateHeaders(store, ctx, header1, header2, header3)
 	store.On("Height").Return(uint64(3))
 
 	m.store = store
@@ -502,9 +560,9 @@ func Test_submitBlocksToDA_BlockMarshalErrorCase2(t *testing.T) {
 
 	m := getManager(t, goDATest.NewDummyDA())
 
-	header1, data1 := types.GetRandomBlock(uint64(1), 5, chainID)
-	header2, data2 := types.GetRandomBlock(uint64(2), 5, chainID)
-	header3, data3 := types.GetRandomBlock(uint64(3), 5, chainID)
+	header1, _ := types.GetRandomBlock(uint64(1), 5, chainID)
+	header2, _ := types.GetRandomBlock(uint64(2), 5, chainID)
+	header3, _ := types.GetRandomBlock(uint64(3), 5, chainID)
 
 	store := mocks.NewStore(t)
 	invalidateBlockHeader(header3)
@@ -512,9 +570,7 @@ func Test_submitBlocksToDA_BlockMarshalErrorCase2(t *testing.T) {
 	store.On("SetMetadata", ctx, DAIncludedHeightKey, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02}).Return(nil)
 	store.On("SetMetadata", ctx, LastSubmittedHeightKey, []byte(strconv.FormatUint(2, 10))).Return(nil)
 	store.On("GetMetadata", ctx, LastSubmittedHeightKey).Return(nil, ds.ErrNotFound)
-	store.On("GetBlockData", ctx, uint64(1)).Return(header1, data1, nil)
-	store.On("GetBlockData", ctx, uint64(2)).Return(header2, data2, nil)
-	store.On("GetBlockData", ctx, uint64(3)).Return(header3, data3, nil)
+	mockIterateHeaders(store, ctx, header1, header2, header3)
 	store.On("Height").Return(uint64(3))
 
 	m.store = store
@@ -529,6 +585,20 @@ func Test_submitBlocksToDA_BlockMarshalErrorCase2(t *testing.T) {
 	assert.Equal(1, len(blocks))
 }
 
+// mockIterateHeaders sets up store mock to iterate over given headers in ascending order
+func mockIterateHeaders(mockStore *mocks.Store, ctx context.Context, headers ...*types.SignedHeader) {
+	mockStore.On("IterateHeaders", ctx, mock.Anything, mock.Anything, store.Ascending).Return(
+		func(_ context.Context, from, to uint64, _ store.Order) (store.Iterator, error) {
+			var inRange []*types.SignedHeader
+			for _, header := range headers {
+				if header.Height() >= from && header.Height() <= to {
+					inRange = append(inRange, header)
+				}
+			}
+			return store.NewSliceIterator(inRange, nil), nil
+		})
+}
+
 // invalidateBlockHeader results in a block header that produces a marshalling error
 func invalidateBlockHeader(header *types.SignedHeader) {
 	for i := range header.Validators.Validators {
@@ -697,7 +767,7 @@ func TestManager_publishBlock(t *testing.T) {
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	))
 	mpoolReaper := mempool.NewCListMempoolReaper(mpool, []byte(chainID), seqClient, logger)
-	executor := state.NewBlockExecutor(vKey.PubKey().Address(), chainID, mpool, mpoolReaper, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 100, logger, state.NopMetrics())
+	executor := state.NewBlockExecutor(vKey.PubKey().Address(), chainID, mpool, mpoolReaper, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 100, logger, state.NopMetrics())
 
 	signingKey, err := types.PrivKeyToSigningKey(vKey)
 	require.NoError(err)
@@ -63,15 +63,26 @@ func (pb *PendingHeaders) getPendingHeaders(ctx context.Context) ([]*types.Signe
 			lastSubmitted, height))
 	}
 
+	it, err := pb.store.IterateHeaders(ctx, lastSubmitted+1, height, store.Ascending)
+	if err != nil {
+		return nil, err
+	}
 	headers := make([]*types.SignedHeader, 0, height-lastSubmitted)
-	for i := lastSubmitted + 1; i <= height; i++ {
-		header, _, err := pb.store.GetBlockData(ctx, i)
-		if err != nil {
+	expected := lastSubmitted + 1
+	for ; it.Next(); expected++ {
+		header := it.Header()
+		if header.Height() != expected {
 			// return as much as possible + error information
-			return headers, err
+			return headers, fmt.Errorf("failed to load header at height %d: %w", expected, ds.ErrNotFound)
 		}
 		headers = append(headers, header)
 	}
+	if err := it.Err(); err != nil {
+		return headers, err
+	}
+	if expected <= height {
+		return headers, fmt.Errorf("failed to load header at height %d: %w", expected, ds.ErrNotFound)
+	}
 	return headers, nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/require"
 
 	"github.com/rollkit/rollkit/store"
@@ -77,10 +78,23 @@ func TestPendingBlocks(t *testing.T) {
 	}
 }
 
+func TestPendingBlocksMissingHeader(t *testing.T) {
+	ctx := context.Background()
+	pb := newPendingBlocks(t)
+	fillWithBlockData(ctx, t, pb, "TestPendingBlocksMissingHeader")
+	pb.store.SetHeight(ctx, numBlocks+2)
+
+	blocks, err := pb.getPendingHeaders(ctx)
+	require.ErrorIs(t, err, ds.ErrNotFound)
+	require.ErrorContains(t, err, "height 6")
+	require.Len(t, blocks, numBlocks)
+}
+
 func newPendingBlocks(t *testing.T) *PendingHeaders {
 	kv, err := store.NewDefaultInMemoryKVStore()
 	require.NoError(t, err)
-	pendingBlocks, err := NewPendingHeaders(store.New(kv), test.NewLogger(t))
+	s := store.New(kv)
+	pendingBlocks, err := NewPendingHeaders(s, test.NewLogger(t))
 	require.NoError(t, err)
 	return pendingBlocks
 }
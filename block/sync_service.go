@@ -207,7 +207,7 @@ func (syncService *SyncService[H]) setupP2P(ctx context.Context) ([]peer.ID, err
 	}
 	networkID := syncService.getNetworkID(network)
 
-	if syncService.p2pServer, err = newP2PServer(syncService.p2p.Host(), syncService.store, networkID); err != nil {
+	if syncService.p2pServer, err = newP2PServer(syncService.p2p.ExchangeHost(), syncService.store, networkID); err != nil {
 		return nil, fmt.Errorf("error while creating p2p server: %w", err)
 	}
 	if err := syncService.p2pServer.Start(ctx); err != nil {
@@ -360,12 +360,29 @@ func (syncService *SyncService[H]) getChainID() string {
 	return syncService.genesis.ChainID + "-" + string(syncService.syncType)
 }
 
+// getPeerIDs returns IDs of peers used as header/data exchange sources.
+// Trusted peers (e.g. sequencer) come first, so they are preferred over other peers.
 func (syncService *SyncService[H]) getPeerIDs() []peer.ID {
-	peerIDs := syncService.p2p.PeerIDs()
+	peerIDs := syncService.p2p.TrustedPeerIDs()
+	peerIDs = append(peerIDs, syncService.p2p.PeerIDs()...)
 	if !syncService.conf.Aggregator {
 		peerIDs = append(peerIDs, getSeedNodes(syncService.conf.P2P.Seeds, syncService.logger)...)
 	}
-	return peerIDs
+	return uniquePeerIDs(peerIDs)
+}
+
+// uniquePeerIDs removes duplicated peer IDs, preserving order of first occurrences.
+func uniquePeerIDs(peerIDs []peer.ID) []peer.ID {
+	seen := make(map[peer.ID]struct{}, len(peerIDs))
+	unique := make([]peer.ID, 0, len(peerIDs))
+	for _, id := range peerIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
 }
 
 func getSeedNodes(seeds string, logger log.Logger) []peer.ID {
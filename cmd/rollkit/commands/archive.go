@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	cometnode "github.com/cometbft/cometbft/node"
+	"github.com/spf13/cobra"
+
+	rollconf "github.com/rollkit/rollkit/config"
+	rollnode "github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/store"
+)
+
+const (
+	flagArchiveOutput    = "output"
+	flagArchiveInput     = "input"
+	flagArchiveFrom      = "from"
+	flagArchiveTo        = "to"
+	flagArchiveChunkSize = "chunk-size"
+)
+
+// NewExportCmd returns the command that exports block history from node store to an archive file.
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export block history to an archive file",
+		Long: `Export blocks, signatures, block responses and state from the node store to a versioned, chunked archive file.
+The node must be stopped while exporting.`,
+		Example: `  rollkit export --output chain.rkarchive --from 1 --to 1000`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parseConfig(cmd); err != nil {
+				return err
+			}
+			rollconf.GetNodeConfig(&nodeConfig, config)
+
+			output, err := cmd.Flags().GetString(flagArchiveOutput)
+			if err != nil {
+				return err
+			}
+			from, err := cmd.Flags().GetUint64(flagArchiveFrom)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetUint64(flagArchiveTo)
+			if err != nil {
+				return err
+			}
+			chunkSize, err := cmd.Flags().GetInt(flagArchiveChunkSize)
+			if err != nil {
+				return err
+			}
+
+			s, err := rollnode.OpenStore(cmd.Context(), nodeConfig, logger)
+			if err != nil {
+				return fmt.Errorf("failed to open store: %w", err)
+			}
+			defer s.Close() //nolint:errcheck
+
+			if to == 0 {
+				to = s.Height()
+			}
+
+			f, err := os.Create(output) //nolint:gosec
+			if err != nil {
+				return err
+			}
+			if err := store.Export(cmd.Context(), s, f, from, to, chunkSize); err != nil {
+				return errors.Join(fmt.Errorf("failed to export blocks: %w", err), f.Close())
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			logger.Info("Exported blocks", "from", from, "to", to, "output", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagArchiveOutput, "", "path of the archive file to create")
+	cmd.Flags().Uint64(flagArchiveFrom, 1, "first block height to export")
+	cmd.Flags().Uint64(flagArchiveTo, 0, "last block height to export (default: latest height)")
+	cmd.Flags().Int(flagArchiveChunkSize, store.DefaultArchiveChunkSize, "number of blocks stored in a single archive chunk")
+	_ = cmd.MarkFlagRequired(flagArchiveOutput)
+
+	return cmd
+}
+
+// NewImportCmd returns the command that imports block history from an archive file into node store.
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import block history from an archive file",
+		Long: `Import blocks, signatures, block responses and state from an archive file created with the export command.
+Every block is validated and verified against the previous header before it's saved.
+When importing into an empty store, the first block is verified against genesis.
+The node must be stopped while importing.
+
+Imported blocks are not executed. The ABCI app has to be restored separately, from a snapshot taken at
+the height of the last imported block; the node refuses to start if app height or app hash doesn't
+match the imported state.`,
+		Example: `  rollkit import --input chain.rkarchive`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parseConfig(cmd); err != nil {
+				return err
+			}
+			rollconf.GetNodeConfig(&nodeConfig, config)
+
+			input, err := cmd.Flags().GetString(flagArchiveInput)
+			if err != nil {
+				return err
+			}
+
+			genDoc, err := cometnode.DefaultGenesisDocProviderFunc(config)()
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(input) //nolint:gosec
+			if err != nil {
+				return err
+			}
+			defer f.Close() //nolint:errcheck
+
+			s, err := rollnode.OpenStore(cmd.Context(), nodeConfig, logger)
+			if err != nil {
+				return fmt.Errorf("failed to open store: %w", err)
+			}
+			defer s.Close() //nolint:errcheck
+
+			height, err := store.Import(cmd.Context(), s, f, genDoc)
+			if err != nil {
+				return fmt.Errorf("failed to import blocks (last imported height: %d): %w", height, err)
+			}
+
+			logger.Info("Imported blocks", "height", height, "input", input)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagArchiveInput, "", "path of the archive file to import")
+	_ = cmd.MarkFlagRequired(flagArchiveInput)
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	rollconf "github.com/rollkit/rollkit/config"
+	rollnode "github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/store"
+)
+
+const flagMigrateDryRun = "dry-run"
+
+// NewMigrateCmd returns the command that migrates node store to the current schema version.
+func NewMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate node store to the current schema version",
+		Long: `Apply pending migrations to the node store, converting data saved by previous versions of rollkit to the current schema version.
+Migrations are also applied automatically on node start. With --dry-run, the store is not modified and the changes that would be made are reported.
+The node must be stopped while migrating.`,
+		Example: `  rollkit migrate --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parseConfig(cmd); err != nil {
+				return err
+			}
+			rollconf.GetNodeConfig(&nodeConfig, config)
+
+			dryRun, err := cmd.Flags().GetBool(flagMigrateDryRun)
+			if err != nil {
+				return err
+			}
+
+			results, err := rollnode.MigrateStore(cmd.Context(), nodeConfig, dryRun, logger)
+			if err != nil {
+				return err
+			}
+
+			if len(results) == 0 {
+				fmt.Printf("Store is up to date (schema version %d).\n", store.SchemaVersion)
+				return nil
+			}
+			verb := "changed"
+			if dryRun {
+				verb = "would be changed"
+			}
+			for _, r := range results {
+				fmt.Printf("Migration to schema version %d: %s (%d entries %s)\n", r.Version, r.Description, r.Entries, verb)
+			}
+			if dryRun {
+				fmt.Printf("Dry run: store was not modified, run without --%s to apply the migrations.\n", flagMigrateDryRun)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(flagMigrateDryRun, false, "report changes without modifying the store")
+
+	return cmd
+}
@@ -38,6 +38,8 @@ import (
 	rollconf "github.com/rollkit/rollkit/config"
 	rollnode "github.com/rollkit/rollkit/node"
 	rollrpc "github.com/rollkit/rollkit/rpc"
+	rollgrpc "github.com/rollkit/rollkit/rpc/grpc"
+	rolljson "github.com/rollkit/rollkit/rpc/json"
 	rolltypes "github.com/rollkit/rollkit/types"
 )
 
@@ -126,7 +128,8 @@ func NewRunNodeCmd() *cobra.Command {
 			}
 
 			// initialize the metrics
-			metrics := rollnode.DefaultMetricsProvider(cometconf.DefaultInstrumentationConfig())
+			instrumentation := cometconf.DefaultInstrumentationConfig()
+			metrics := rollnode.DefaultMetricsProvider(instrumentation)
 
 			// Try and launch a mock JSON RPC DA server if there is no DA server running.
 			// NOTE: if the user supplied an address for a running DA server, and the address doesn't match, this will launch a mock DA server. This is ok because the logs will tell the user that a mock DA server is being used.
@@ -180,27 +183,82 @@ func NewRunNodeCmd() *cobra.Command {
 			}
 
 			// Launch the RPC server
-			server := rollrpc.NewServer(rollnode, config.RPC, logger)
+			accessControl, err := rollrpc.AccessControlFromConfig(nodeConfig.RPC)
+			if err != nil {
+				return fmt.Errorf("invalid RPC access control configuration: %w", err)
+			}
+			rateLimit, err := rollrpc.RateLimitFromConfig(nodeConfig.RPC)
+			if err != nil {
+				return fmt.Errorf("invalid RPC rate limit configuration: %w", err)
+			}
+			rpcMetrics := rolljson.NopMetrics()
+			if instrumentation.Prometheus {
+				rpcMetrics = rolljson.PrometheusMetrics(instrumentation.Namespace, "chain_id", genDoc.ChainID)
+			}
+			rpcOpts := []rolljson.HandlerOption{
+				rolljson.WithAccessControl(accessControl),
+				rolljson.WithRateLimit(rateLimit),
+				rolljson.WithMetrics(rpcMetrics),
+			}
+			if nodeConfig.RPC.EthAPI {
+				rpcOpts = append(rpcOpts, rolljson.WithEthAPI())
+			}
+			server := rollrpc.NewServer(rollnode, config.RPC, logger, rpcOpts...)
 			err = server.Start()
 			if err != nil {
 				return fmt.Errorf("failed to launch RPC server: %w", err)
 			}
+			defer stopService(server, "RPC server")
+
+			// Launch the gRPC server
+			var grpcServer *rollrpc.GRPCServer
+			if grpcAddr := nodeConfig.RPC.GRPCListenAddress; grpcAddr != "" {
+				if grpcAddr == config.RPC.ListenAddress {
+					return errors.New("gRPC must not listen on JSON-RPC address")
+				}
+				guard := rolljson.NewCallGuard(accessControl, rateLimit)
+				grpcServer = rollrpc.NewGRPCServer(rollnode, grpcAddr, logger, rollgrpc.WithCallGuard(guard)...)
+				if err := grpcServer.Start(); err != nil {
+					return fmt.Errorf("failed to launch gRPC server: %w", err)
+				}
+				defer stopService(grpcServer, "gRPC server")
+			}
 
 			// Start the node
 			if err := rollnode.Start(); err != nil {
 				return fmt.Errorf("failed to start node: %w", err)
 			}
+			defer stopService(rollnode, "node")
+
+			// Launch the admin RPC server, it requires running node to manage peers
+			var adminServer *rollrpc.AdminServer
+			if adminAddr := nodeConfig.RPC.AdminListenAddress; adminAddr != "" {
+				if adminAddr == config.RPC.ListenAddress {
+					return errors.New("admin RPC must not listen on public RPC address")
+				}
+				adminServer, err = rollrpc.NewAdminServer(rollnode, adminAddr, logger)
+				if err != nil {
+					return fmt.Errorf("failed to create admin RPC server: %w", err)
+				}
+				if err := adminServer.Start(); err != nil {
+					return fmt.Errorf("failed to launch admin RPC server: %w", err)
+				}
+				defer stopService(adminServer, "admin RPC server")
+			}
 
 			// TODO: Do rollkit nodes not have information about them? CometBFT has node.switch.NodeInfo()
 			logger.Info("Started node")
 
-			// Stop upon receiving SIGTERM or CTRL-C.
+			// Stop upon receiving SIGTERM or CTRL-C. TrapSignal exits the process, so deferred calls are not executed.
 			cometos.TrapSignal(logger, func() {
-				if rollnode.IsRunning() {
-					if err := rollnode.Stop(); err != nil {
-						logger.Error("unable to stop the node", "error", err)
-					}
+				if adminServer != nil {
+					stopService(adminServer, "admin RPC server")
+				}
+				if grpcServer != nil {
+					stopService(grpcServer, "gRPC server")
 				}
+				stopService(rollnode, "node")
+				stopService(server, "RPC server")
 			})
 
 			// Check if we are running in CI mode
@@ -236,6 +294,22 @@ func NewRunNodeCmd() *cobra.Command {
 	return cmd
 }
 
+// stoppable is implemented by the node and the RPC servers.
+type stoppable interface {
+	IsRunning() bool
+	Stop() error
+}
+
+// stopService stops the service if it's still running, logging failures.
+func stopService(s stoppable, name string) {
+	if !s.IsRunning() {
+		return
+	}
+	if err := s.Stop(); err != nil {
+		logger.Error("unable to stop the "+name, "error", err)
+	}
+}
+
 // addNodeFlags exposes some common configuration options on the command-line
 // These are exposed for convenience of commands embedding a rollkit node
 func addNodeFlags(cmd *cobra.Command) {
@@ -400,7 +474,7 @@ func parseFlags(cmd *cobra.Command) error {
 	}
 
 	// special handling for the p2p external address, due to inconsistencies in mapstructure and flag name
-	if cmd.Flags().Lookup("p2p.external-address").Changed {
+	if flag := cmd.Flags().Lookup("p2p.external-address"); flag != nil && flag.Changed {
 		config.P2P.ExternalAddress = viper.GetString("p2p.external-address")
 	}
 
@@ -43,6 +43,7 @@ func TestParseFlags(t *testing.T) {
 		"--rollkit.da_mempool_ttl", "10",
 		"--rollkit.da_namespace", "namespace",
 		"--rollkit.da_start_height", "100",
+		"--rollkit.db_backend", "pebble",
 		"--rollkit.lazy_aggregator",
 		"--rollkit.lazy_block_time", "2m",
 		"--rollkit.light",
@@ -95,6 +96,7 @@ func TestParseFlags(t *testing.T) {
 		{"DAMempoolTTL", nodeConfig.DAMempoolTTL, uint64(10)},
 		{"DANamespace", nodeConfig.DANamespace, "namespace"},
 		{"DAStartHeight", nodeConfig.DAStartHeight, uint64(100)},
+		{"RollkitDBBackend", nodeConfig.DBBackend, "pebble"},
 		{"LazyAggregator", nodeConfig.LazyAggregator, true},
 		{"LazyBlockTime", nodeConfig.LazyBlockTime, 2 * time.Minute},
 		{"Light", nodeConfig.Light, true},
@@ -267,3 +269,27 @@ func TestStartMockSequencerServer(t *testing.T) {
 		})
 	}
 }
+
+type fakeService struct {
+	running bool
+	stops   int
+}
+
+func (s *fakeService) IsRunning() bool { return s.running }
+
+func (s *fakeService) Stop() error {
+	s.stops++
+	s.running = false
+	return nil
+}
+
+func TestStopService(t *testing.T) {
+	s := &fakeService{running: true}
+	stopService(s, "test")
+	assert.False(t, s.running)
+	assert.Equal(t, 1, s.stops)
+
+	// already stopped services (e.g. stopped on CI mode exit or by signal handler) are not stopped again
+	stopService(s, "test")
+	assert.Equal(t, 1, s.stops)
+}
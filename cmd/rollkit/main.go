@@ -22,6 +22,9 @@ func main() {
 		cmd.VersionCmd,
 		cmd.NewTomlCmd(),
 		cmd.RebuildCmd,
+		cmd.NewExportCmd(),
+		cmd.NewImportCmd(),
+		cmd.NewMigrateCmd(),
 	)
 
 	// In case there is a rollkit.toml file in the current dir or somewhere up the
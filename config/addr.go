@@ -13,13 +13,18 @@ var (
 
 // TranslateAddresses updates conf by changing Cosmos-style addresses to Multiaddr format.
 func TranslateAddresses(conf *NodeConfig) error {
-	if conf.P2P.ListenAddress != "" {
-		addr, err := GetMultiAddr(conf.P2P.ListenAddress)
-		if err != nil {
-			return err
+	listenAddrs := strings.Split(conf.P2P.ListenAddress, ",")
+	for i, listenAddr := range listenAddrs {
+		// multiaddrs (e.g. QUIC addresses) are used as is
+		if listenAddr != "" && !strings.HasPrefix(listenAddr, "/") {
+			addr, err := GetMultiAddr(listenAddr)
+			if err != nil {
+				return err
+			}
+			listenAddrs[i] = addr.String()
 		}
-		conf.P2P.ListenAddress = addr.String()
 	}
+	conf.P2P.ListenAddress = strings.Join(listenAddrs, ",")
 
 	seeds := strings.Split(conf.P2P.Seeds, ",")
 	for i, seed := range seeds {
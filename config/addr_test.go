@@ -28,6 +28,12 @@ func TestTranslateAddresses(t *testing.T) {
 			NodeConfig{P2P: P2PConfig{ListenAddress: validRollkit}},
 			"",
 		},
+		{
+			"multiple listen addresses",
+			NodeConfig{P2P: P2PConfig{ListenAddress: validCosmos + ",/ip4/127.0.0.1/udp/1234/quic-v1"}},
+			NodeConfig{P2P: P2PConfig{ListenAddress: validRollkit + ",/ip4/127.0.0.1/udp/1234/quic-v1"}},
+			"",
+		},
 		{
 			"valid seed address",
 			NodeConfig{P2P: P2PConfig{Seeds: validCosmos + "," + validCosmos}},
@@ -46,6 +46,60 @@ const (
 	FlagSequencerAddress = "rollkit.sequencer_address"
 	// FlagSequencerRollupID is a flag for specifying the sequencer middleware rollup ID
 	FlagSequencerRollupID = "rollkit.sequencer_rollup_id"
+	// FlagDBBackend is a flag for specifying the database backend
+	FlagDBBackend = "rollkit.db_backend"
+	// FlagMempoolType is a flag for specifying the mempool implementation
+	FlagMempoolType = "rollkit.mempool_type"
+	// FlagP2PTrustedPeers is a flag for specifying trusted peers, e.g. sequencer
+	FlagP2PTrustedPeers = "rollkit.p2p_trusted_peers"
+	// FlagP2PBanThreshold is a flag for specifying the reputation score below which peers are banned
+	FlagP2PBanThreshold = "rollkit.p2p_ban_threshold"
+	// FlagP2PBanDuration is a flag for specifying the duration of peer bans
+	FlagP2PBanDuration = "rollkit.p2p_ban_duration"
+	// FlagP2PPrivateNetworkKey is a flag for specifying the pre-shared key of private P2P network
+	FlagP2PPrivateNetworkKey = "rollkit.p2p_private_network_key" // #nosec G101
+	// FlagP2PNATPortMap is a flag for enabling port mapping in NAT-enabled routers
+	FlagP2PNATPortMap = "rollkit.p2p_nat_port_map"
+	// FlagP2PHolePunching is a flag for enabling NAT traversal with hole punching
+	FlagP2PHolePunching = "rollkit.p2p_hole_punching"
+	// FlagP2PRelayService is a flag for enabling circuit relay service
+	FlagP2PRelayService = "rollkit.p2p_relay_service"
+	// FlagP2PStaticRelays is a flag for specifying relay nodes used when node is behind NAT
+	FlagP2PStaticRelays = "rollkit.p2p_static_relays"
+	// FlagP2PTxBatchInterval is a flag for specifying how long transactions are buffered before being gossiped in a batch
+	FlagP2PTxBatchInterval = "rollkit.p2p_tx_batch_interval"
+	// FlagP2PGossipRateLimit is a flag for specifying the number of gossiped messages per second accepted from a peer
+	FlagP2PGossipRateLimit = "rollkit.p2p_gossip_rate_limit"
+	// FlagP2PGossipBurst is a flag for specifying the number of gossiped messages accepted from a peer at once
+	FlagP2PGossipBurst = "rollkit.p2p_gossip_burst"
+	// FlagP2PExchangeRateLimit is a flag for specifying the number of exchange requests per second served to a peer
+	FlagP2PExchangeRateLimit = "rollkit.p2p_exchange_rate_limit"
+	// FlagP2PExchangeBurst is a flag for specifying the number of exchange requests served to a peer at once
+	FlagP2PExchangeBurst = "rollkit.p2p_exchange_burst"
+	// FlagFullNodeRPCAddress is a flag for specifying the full node RPC used by light node to query application state
+	FlagFullNodeRPCAddress = "rollkit.full_node_rpc_address"
+	// FlagGRPCAddress is a flag for specifying the listen address of gRPC server
+	FlagGRPCAddress = "rollkit.grpc_address"
+	// FlagAdminRPCAddress is a flag for specifying the listen address of admin-only RPC server
+	FlagAdminRPCAddress = "rollkit.admin_rpc_address"
+	// FlagRPCAllowedMethods is a flag for specifying methods served by RPC server
+	FlagRPCAllowedMethods = "rollkit.rpc_allowed_methods"
+	// FlagRPCDeniedMethods is a flag for specifying methods never served by RPC server
+	FlagRPCDeniedMethods = "rollkit.rpc_denied_methods"
+	// FlagRPCPublicMethods is a flag for specifying RPC methods available without authentication
+	FlagRPCPublicMethods = "rollkit.rpc_public_methods"
+	// FlagRPCAuthTokens is a flag for specifying RPC bearer tokens and methods allowed for them
+	FlagRPCAuthTokens = "rollkit.rpc_auth_tokens" // #nosec G101
+	// FlagRPCJWTSecret is a flag for specifying the secret used to verify RPC JWT bearer tokens
+	FlagRPCJWTSecret = "rollkit.rpc_jwt_secret" // #nosec G101
+	// FlagRPCRateLimit is a flag for specifying the cost of RPC requests per second allowed for a single client
+	FlagRPCRateLimit = "rollkit.rpc_rate_limit"
+	// FlagRPCRateLimitBurst is a flag for specifying the cost of RPC requests allowed for a single client at once
+	FlagRPCRateLimitBurst = "rollkit.rpc_rate_limit_burst"
+	// FlagRPCMethodCosts is a flag for overriding costs of RPC methods used for rate limiting
+	FlagRPCMethodCosts = "rollkit.rpc_method_costs"
+	// FlagRPCEthAPI is a flag for enabling Ethereum JSON-RPC adapter
+	FlagRPCEthAPI = "rollkit.rpc_eth_api"
 )
 
 // NodeConfig stores Rollkit node configuration.
@@ -61,11 +115,19 @@ type NodeConfig struct {
 	DAAddress          string `mapstructure:"da_address"`
 	DAAuthToken        string `mapstructure:"da_auth_token"`
 	Light              bool   `mapstructure:"light"`
+	// FullNodeRPCAddress is an RPC address of full node, used by light node to query application state.
+	// Query results are verified against verified headers, so the full node doesn't have to be trusted.
+	FullNodeRPCAddress string `mapstructure:"full_node_rpc_address"`
 	HeaderConfig       `mapstructure:",squash"`
 	Instrumentation    *cmcfg.InstrumentationConfig `mapstructure:"instrumentation"`
 	DAGasPrice         float64                      `mapstructure:"da_gas_price"`
 	DAGasMultiplier    float64                      `mapstructure:"da_gas_multiplier"`
 	DASubmitOptions    string                       `mapstructure:"da_submit_options"`
+	// DBBackend selects key-value store implementation used by the node (badger, pebble or memory)
+	DBBackend string `mapstructure:"db_backend"`
+	// MempoolType selects mempool implementation: clist (transactions ordered by arrival) or priority
+	// (transactions ordered by priority reported by the application, evicting the lowest priority ones when full)
+	MempoolType string `mapstructure:"mempool_type"`
 
 	// CLI flags
 	DANamespace       string `mapstructure:"da_namespace"`
@@ -142,11 +204,38 @@ func (nc *NodeConfig) GetViperConfig(v *viper.Viper) error {
 	nc.LazyAggregator = v.GetBool(FlagLazyAggregator)
 	nc.Light = v.GetBool(FlagLight)
 	nc.TrustedHash = v.GetString(FlagTrustedHash)
+	nc.FullNodeRPCAddress = v.GetString(FlagFullNodeRPCAddress)
 	nc.MaxPendingBlocks = v.GetUint64(FlagMaxPendingBlocks)
 	nc.DAMempoolTTL = v.GetUint64(FlagDAMempoolTTL)
 	nc.LazyBlockTime = v.GetDuration(FlagLazyBlockTime)
 	nc.SequencerAddress = v.GetString(FlagSequencerAddress)
 	nc.SequencerRollupID = v.GetString(FlagSequencerRollupID)
+	nc.DBBackend = v.GetString(FlagDBBackend)
+	nc.MempoolType = v.GetString(FlagMempoolType)
+	nc.P2P.TrustedPeers = v.GetString(FlagP2PTrustedPeers)
+	nc.P2P.BanThreshold = v.GetFloat64(FlagP2PBanThreshold)
+	nc.P2P.BanDuration = v.GetDuration(FlagP2PBanDuration)
+	nc.P2P.PrivateNetworkKey = v.GetString(FlagP2PPrivateNetworkKey)
+	nc.P2P.NATPortMap = v.GetBool(FlagP2PNATPortMap)
+	nc.P2P.HolePunching = v.GetBool(FlagP2PHolePunching)
+	nc.P2P.RelayService = v.GetBool(FlagP2PRelayService)
+	nc.P2P.StaticRelays = v.GetString(FlagP2PStaticRelays)
+	nc.P2P.TxBatchInterval = v.GetDuration(FlagP2PTxBatchInterval)
+	nc.P2P.GossipRateLimit = v.GetFloat64(FlagP2PGossipRateLimit)
+	nc.P2P.GossipBurst = v.GetInt(FlagP2PGossipBurst)
+	nc.P2P.ExchangeRateLimit = v.GetFloat64(FlagP2PExchangeRateLimit)
+	nc.P2P.ExchangeBurst = v.GetInt(FlagP2PExchangeBurst)
+	nc.RPC.AdminListenAddress = v.GetString(FlagAdminRPCAddress)
+	nc.RPC.GRPCListenAddress = v.GetString(FlagGRPCAddress)
+	nc.RPC.AllowedMethods = v.GetString(FlagRPCAllowedMethods)
+	nc.RPC.DeniedMethods = v.GetString(FlagRPCDeniedMethods)
+	nc.RPC.PublicMethods = v.GetString(FlagRPCPublicMethods)
+	nc.RPC.AuthTokens = v.GetString(FlagRPCAuthTokens)
+	nc.RPC.JWTSecret = v.GetString(FlagRPCJWTSecret)
+	nc.RPC.RateLimit = v.GetFloat64(FlagRPCRateLimit)
+	nc.RPC.RateLimitBurst = v.GetInt(FlagRPCRateLimitBurst)
+	nc.RPC.MethodCosts = v.GetString(FlagRPCMethodCosts)
+	nc.RPC.EthAPI = v.GetBool(FlagRPCEthAPI)
 
 	return nil
 }
@@ -170,9 +259,36 @@ func AddFlags(cmd *cobra.Command) {
 	cmd.Flags().String(FlagDASubmitOptions, def.DASubmitOptions, "DA submit options")
 	cmd.Flags().Bool(FlagLight, def.Light, "run light client")
 	cmd.Flags().String(FlagTrustedHash, def.TrustedHash, "initial trusted hash to start the header exchange service")
+	cmd.Flags().String(FlagFullNodeRPCAddress, def.FullNodeRPCAddress, "full node RPC address used by light client to query application state, with proofs (tcp://host:port)")
 	cmd.Flags().Uint64(FlagMaxPendingBlocks, def.MaxPendingBlocks, "limit of blocks pending DA submission (0 for no limit)")
 	cmd.Flags().Uint64(FlagDAMempoolTTL, def.DAMempoolTTL, "number of DA blocks until transaction is dropped from the mempool")
 	cmd.Flags().Duration(FlagLazyBlockTime, def.LazyBlockTime, "block time (for lazy mode)")
 	cmd.Flags().String(FlagSequencerAddress, def.SequencerAddress, "sequencer middleware address (host:port)")
 	cmd.Flags().String(FlagSequencerRollupID, def.SequencerRollupID, "sequencer middleware rollup ID (default: mock-rollup)")
+	cmd.Flags().String(FlagDBBackend, def.DBBackend, "database backend (badger | pebble | memory)")
+	cmd.Flags().String(FlagMempoolType, def.MempoolType, "mempool implementation (clist | priority)")
+	cmd.Flags().String(FlagP2PTrustedPeers, def.P2P.TrustedPeers, "comma separated list of trusted peers (e.g. sequencer), kept connected and preferred for syncing")
+	cmd.Flags().Float64(FlagP2PBanThreshold, def.P2P.BanThreshold, "reputation score below which peers sending invalid messages are banned (0 to disable)")
+	cmd.Flags().Duration(FlagP2PBanDuration, def.P2P.BanDuration, "duration of peer bans")
+	cmd.Flags().String(FlagP2PPrivateNetworkKey, def.P2P.PrivateNetworkKey, "hex encoded 32 bytes pre-shared key of private P2P network (TCP only)")
+	cmd.Flags().Bool(FlagP2PNATPortMap, def.P2P.NATPortMap, "open ports in NAT-enabled routers with UPnP / NAT-PMP")
+	cmd.Flags().Bool(FlagP2PHolePunching, def.P2P.HolePunching, "enable NAT traversal with hole punching")
+	cmd.Flags().Bool(FlagP2PRelayService, def.P2P.RelayService, "act as circuit relay for other peers")
+	cmd.Flags().String(FlagP2PStaticRelays, def.P2P.StaticRelays, "comma separated list of relay nodes used when node is behind NAT")
+	cmd.Flags().Duration(FlagP2PTxBatchInterval, def.P2P.TxBatchInterval, "interval of batching gossiped transactions (0 to gossip every transaction separately)")
+	cmd.Flags().Float64(FlagP2PGossipRateLimit, def.P2P.GossipRateLimit, "number of gossiped messages per second accepted from a single peer, per topic (0 to disable)")
+	cmd.Flags().Int(FlagP2PGossipBurst, def.P2P.GossipBurst, "number of gossiped messages accepted from a single peer at once, per topic (defaults to rate limit)")
+	cmd.Flags().Float64(FlagP2PExchangeRateLimit, def.P2P.ExchangeRateLimit, "number of header and block exchange requests per second served to a single peer (0 to disable)")
+	cmd.Flags().Int(FlagP2PExchangeBurst, def.P2P.ExchangeBurst, "number of exchange requests served to a single peer at once (defaults to rate limit)")
+	cmd.Flags().String(FlagAdminRPCAddress, def.RPC.AdminListenAddress, "admin-only RPC listen address, used for runtime peer management (tcp://host:port, disabled if empty)")
+	cmd.Flags().String(FlagGRPCAddress, def.RPC.GRPCListenAddress, "gRPC listen address (tcp://host:port, disabled if empty)")
+	cmd.Flags().String(FlagRPCAllowedMethods, def.RPC.AllowedMethods, "comma separated list of methods served by RPC server (all methods if empty)")
+	cmd.Flags().String(FlagRPCDeniedMethods, def.RPC.DeniedMethods, "comma separated list of methods never served by RPC server")
+	cmd.Flags().String(FlagRPCPublicMethods, def.RPC.PublicMethods, "comma separated list of RPC methods available without authentication")
+	cmd.Flags().String(FlagRPCAuthTokens, def.RPC.AuthTokens, "comma separated list of RPC bearer tokens, optionally limited to methods (token[:method1|method2]); enables authentication")
+	cmd.Flags().String(FlagRPCJWTSecret, def.RPC.JWTSecret, "secret used to verify RPC JWT bearer tokens (HMAC); enables authentication")
+	cmd.Flags().Float64(FlagRPCRateLimit, def.RPC.RateLimit, "cost of RPC requests per second allowed for a single client, identified by auth token or IP address (0 to disable)")
+	cmd.Flags().Int(FlagRPCRateLimitBurst, def.RPC.RateLimitBurst, "cost of RPC requests allowed for a single client at once (defaults to rate limit)")
+	cmd.Flags().String(FlagRPCMethodCosts, def.RPC.MethodCosts, "comma separated list of RPC method costs used for rate limiting, overriding defaults (method:cost)")
+	cmd.Flags().Bool(FlagRPCEthAPI, def.RPC.EthAPI, "serve subset of Ethereum JSON-RPC API (eth_* methods) at /eth path of RPC server")
 }
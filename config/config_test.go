@@ -50,6 +50,24 @@ func TestViperAndCobra(t *testing.T) {
 	assert.NoError(cmd.Flags().Set(FlagDAAddress, `{"json":true}`))
 	assert.NoError(cmd.Flags().Set(FlagBlockTime, "1234s"))
 	assert.NoError(cmd.Flags().Set(FlagDANamespace, "0102030405060708"))
+	assert.NoError(cmd.Flags().Set(FlagDBBackend, "pebble"))
+	assert.NoError(cmd.Flags().Set(FlagMempoolType, "priority"))
+	assert.NoError(cmd.Flags().Set(FlagP2PBanThreshold, "-50"))
+	assert.NoError(cmd.Flags().Set(FlagP2PBanDuration, "30m"))
+	assert.NoError(cmd.Flags().Set(FlagAdminRPCAddress, "tcp://127.0.0.1:26659"))
+	assert.NoError(cmd.Flags().Set(FlagP2PHolePunching, "true"))
+	assert.NoError(cmd.Flags().Set(FlagP2PStaticRelays, "/ip4/127.0.0.1/tcp/7676"))
+	assert.NoError(cmd.Flags().Set(FlagP2PTxBatchInterval, "20ms"))
+	assert.NoError(cmd.Flags().Set(FlagP2PTrustedPeers, "/ip4/127.0.0.1/tcp/7677/p2p/12D3KooWM1NFkZozoatQi3JvFE57eBaX56mNgBA68Lk5MTPxBE4U"))
+	assert.NoError(cmd.Flags().Set(FlagP2PGossipRateLimit, "12.5"))
+	assert.NoError(cmd.Flags().Set(FlagP2PExchangeBurst, "40"))
+	assert.NoError(cmd.Flags().Set(FlagFullNodeRPCAddress, "tcp://127.0.0.1:26657"))
+	assert.NoError(cmd.Flags().Set(FlagGRPCAddress, "tcp://127.0.0.1:9090"))
+	assert.NoError(cmd.Flags().Set(FlagRPCPublicMethods, "status,block"))
+	assert.NoError(cmd.Flags().Set(FlagRPCAuthTokens, "admin,reader:status|tx"))
+	assert.NoError(cmd.Flags().Set(FlagRPCRateLimit, "50"))
+	assert.NoError(cmd.Flags().Set(FlagRPCMethodCosts, "tx_search:20"))
+	assert.NoError(cmd.Flags().Set(FlagRPCEthAPI, "true"))
 
 	nc := DefaultNodeConfig
 
@@ -58,4 +76,26 @@ func TestViperAndCobra(t *testing.T) {
 	assert.Equal(true, nc.Aggregator)
 	assert.Equal(`{"json":true}`, nc.DAAddress)
 	assert.Equal(1234*time.Second, nc.BlockTime)
+	assert.Equal("pebble", nc.DBBackend)
+	assert.Equal("priority", nc.MempoolType)
+	assert.Equal(-50.0, nc.P2P.BanThreshold)
+	assert.Equal(30*time.Minute, nc.P2P.BanDuration)
+	assert.Equal("tcp://127.0.0.1:26659", nc.RPC.AdminListenAddress)
+	assert.True(nc.P2P.HolePunching)
+	assert.False(nc.P2P.NATPortMap)
+	assert.Equal("/ip4/127.0.0.1/tcp/7676", nc.P2P.StaticRelays)
+	assert.Equal(20*time.Millisecond, nc.P2P.TxBatchInterval)
+	assert.Equal("/ip4/127.0.0.1/tcp/7677/p2p/12D3KooWM1NFkZozoatQi3JvFE57eBaX56mNgBA68Lk5MTPxBE4U", nc.P2P.TrustedPeers)
+	assert.Equal(12.5, nc.P2P.GossipRateLimit)
+	assert.Equal(0, nc.P2P.GossipBurst)
+	assert.Equal(40, nc.P2P.ExchangeBurst)
+	assert.Equal("tcp://127.0.0.1:26657", nc.FullNodeRPCAddress)
+	assert.Equal("tcp://127.0.0.1:9090", nc.RPC.GRPCListenAddress)
+	assert.Equal("status,block", nc.RPC.PublicMethods)
+	assert.Equal("admin,reader:status|tx", nc.RPC.AuthTokens)
+	assert.Empty(nc.RPC.JWTSecret)
+	assert.Equal(50.0, nc.RPC.RateLimit)
+	assert.Equal(0, nc.RPC.RateLimitBurst)
+	assert.Equal("tx_search:20", nc.RPC.MethodCosts)
+	assert.True(nc.RPC.EthAPI)
 }
@@ -18,6 +18,14 @@ const (
 	DefaultSequencerAddress = "localhost:50051"
 	// DefaultSequencerRollupID is the default rollup ID for the sequencer middleware
 	DefaultSequencerRollupID = "mock-rollup"
+	// DefaultDBBackend is the default database backend
+	DefaultDBBackend = "badger"
+	// DefaultMempoolType is the default mempool implementation
+	DefaultMempoolType = "clist"
+	// DefaultBanThreshold is the default reputation score below which peers are banned
+	DefaultBanThreshold = -100
+	// DefaultBanDuration is the default duration of peer bans
+	DefaultBanDuration = 1 * time.Hour
 )
 
 // DefaultNodeConfig keeps default values of NodeConfig
@@ -25,6 +33,8 @@ var DefaultNodeConfig = NodeConfig{
 	P2P: P2PConfig{
 		ListenAddress: DefaultListenAddress,
 		Seeds:         "",
+		BanThreshold:  DefaultBanThreshold,
+		BanDuration:   DefaultBanDuration,
 	},
 	Aggregator: false,
 	BlockManagerConfig: BlockManagerConfig{
@@ -43,4 +53,6 @@ var DefaultNodeConfig = NodeConfig{
 	Instrumentation:   config.DefaultInstrumentationConfig(),
 	SequencerAddress:  DefaultSequencerAddress,
 	SequencerRollupID: DefaultSequencerRollupID,
+	DBBackend:         DefaultDBBackend,
+	MempoolType:       DefaultMempoolType,
 }
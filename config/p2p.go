@@ -1,9 +1,50 @@
 package config
 
+import "time"
+
 // P2PConfig stores configuration related to peer-to-peer networking.
 type P2PConfig struct {
-	ListenAddress string // Address to listen for incoming connections
+	ListenAddress string // Comma separated list of addresses to listen for incoming connections (e.g. TCP and QUIC multiaddrs)
 	Seeds         string // Comma separated list of seed nodes to connect to
 	BlockedPeers  string // Comma separated list of nodes to ignore
 	AllowedPeers  string // Comma separated list of nodes to whitelist
+
+	// TrustedPeers is a comma separated list of trusted nodes (e.g. sequencer). Node keeps connections with
+	// trusted peers, protects them from connection manager trimming, peers with them directly in gossip, and
+	// prefers them as sources of headers and blocks.
+	TrustedPeers string
+
+	// BanThreshold is the reputation score below which a peer is temporarily banned.
+	// Reputation is lowered by invalid gossiped messages. 0 disables automatic bans.
+	BanThreshold float64
+	// BanDuration defines how long peers are banned for.
+	BanDuration time.Duration
+
+	// PrivateNetworkKey is a hex encoded, 32 bytes pre-shared key. If set, node joins private network,
+	// and connects only to peers using the same key. Private networks support only TCP and WebSocket transports.
+	PrivateNetworkKey string
+	// NATPortMap enables opening ports in NAT-enabled routers with UPnP / NAT-PMP.
+	NATPortMap bool
+	// HolePunching enables NAT traversal with hole punching (DCUtR) for peers behind NAT.
+	HolePunching bool
+	// RelayService enables circuit relay service, allowing this node to act as relay for other peers.
+	RelayService bool
+	// StaticRelays is a comma separated list of relay nodes, used to stay reachable when node is behind NAT.
+	StaticRelays string
+
+	// TxBatchInterval defines how long transactions are buffered before they are gossiped together in a single
+	// batch message. 0 disables batching - every transaction is gossiped in a separate message. Nodes accept
+	// both kinds of messages, regardless of this setting.
+	TxBatchInterval time.Duration
+
+	// GossipRateLimit is the number of gossiped messages per second accepted from a single peer, in each topic.
+	// Messages exceeding the limit are dropped. 0 disables the limit.
+	GossipRateLimit float64
+	// GossipBurst is the number of gossiped messages that can be accepted from a single peer at once, in each topic.
+	GossipBurst int
+	// ExchangeRateLimit is the number of header and block exchange requests per second served to a single peer.
+	// Requests exceeding the limit are rejected. 0 disables the limit.
+	ExchangeRateLimit float64
+	// ExchangeBurst is the number of exchange requests that can be served to a single peer at once.
+	ExchangeBurst int
 }
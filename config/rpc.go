@@ -4,6 +4,43 @@ package config
 type RPCConfig struct {
 	ListenAddress string
 
+	// AdminListenAddress is the listen address of admin-only RPC server, exposing methods changing
+	// state of the node (e.g. dialing and blocking peers). Admin RPC is disabled if empty.
+	// It should never be exposed publicly.
+	AdminListenAddress string `mapstructure:"admin_rpc_address"`
+
+	// GRPCListenAddress is the listen address of gRPC server, exposing typed and streaming equivalent of
+	// JSON-RPC API. gRPC is disabled if empty.
+	GRPCListenAddress string `mapstructure:"grpc_address"`
+
+	// AllowedMethods is a comma separated list of methods served by JSON-RPC server. All methods are
+	// served if empty.
+	AllowedMethods string `mapstructure:"rpc_allowed_methods"`
+	// DeniedMethods is a comma separated list of methods never served by JSON-RPC server.
+	DeniedMethods string `mapstructure:"rpc_denied_methods"`
+	// PublicMethods is a comma separated list of methods that can be called without authentication.
+	// It's used only if authentication is enabled (AuthTokens or JWTSecret is set).
+	PublicMethods string `mapstructure:"rpc_public_methods"`
+	// AuthTokens is a comma separated list of static bearer tokens. Each token can be limited to
+	// specific methods with "token:method1|method2" syntax.
+	AuthTokens string `mapstructure:"rpc_auth_tokens"`
+	// JWTSecret is the HMAC secret used to verify JWT bearer tokens. Methods allowed for a token can
+	// be limited with "methods" claim.
+	JWTSecret string `mapstructure:"rpc_jwt_secret"`
+
+	// RateLimit is the cost of JSON-RPC requests per second allowed for a single client, identified by
+	// auth token or IP address. Rate limiting is disabled if 0.
+	RateLimit float64 `mapstructure:"rpc_rate_limit"`
+	// RateLimitBurst is the cost of requests allowed for a single client at once. Defaults to RateLimit.
+	RateLimitBurst int `mapstructure:"rpc_rate_limit_burst"`
+	// MethodCosts is a comma separated list of "method:cost" pairs, overriding default costs of methods
+	// (e.g. search and subscription methods cost more than simple queries).
+	MethodCosts string `mapstructure:"rpc_method_costs"`
+
+	// EthAPI enables Ethereum JSON-RPC adapter, serving subset of eth_* methods at /eth path of JSON-RPC
+	// server, so Ethereum tooling (e.g. block explorers) can display rollup blocks.
+	EthAPI bool `mapstructure:"rpc_eth_api"`
+
 	// Cross Origin Resource Sharing settings
 	CORSAllowedOrigins []string
 	CORSAllowedMethods []string
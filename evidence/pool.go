@@ -0,0 +1,279 @@
+package evidence
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/third_party/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+const (
+	pendingPrefix   = "pending"
+	committedPrefix = "committed"
+)
+
+var (
+	// ErrEvidenceAlreadyPending is returned when evidence is already in the pool.
+	ErrEvidenceAlreadyPending = errors.New("evidence is already pending")
+	// ErrEvidenceCommitted is returned when evidence was already included in a block.
+	ErrEvidenceCommitted = errors.New("evidence was already committed")
+	// ErrEvidenceExpired is returned when evidence is older than allowed by consensus params.
+	ErrEvidenceExpired = errors.New("evidence has expired")
+	// ErrInvalidEvidence is returned when evidence doesn't prove misbehavior of the sequencer.
+	ErrInvalidEvidence = errors.New("invalid evidence")
+)
+
+// BroadcastFunc sends encoded evidence to other nodes.
+type BroadcastFunc func(ctx context.Context, evidence []byte) error
+
+// Pool stores verified evidence of misbehavior until it's included in a block.
+//
+// Pending and committed evidence is persisted, so evidence is not lost (or included twice) after restart.
+type Pool struct {
+	mtx sync.Mutex
+
+	kv        ds.Datastore
+	store     store.Store
+	broadcast BroadcastFunc
+	logger    log.Logger
+}
+
+// NewPool creates evidence pool persisting evidence in kv. Evidence is verified against blocks and state
+// saved in store. If broadcast is nil, evidence is not sent to other nodes.
+func NewPool(kv ds.Datastore, store store.Store, broadcast BroadcastFunc, logger log.Logger) *Pool {
+	return &Pool{
+		kv:        kv,
+		store:     store,
+		broadcast: broadcast,
+		logger:    logger,
+	}
+}
+
+// AddEvidence verifies evidence and adds it to the pool.
+func (p *Pool) AddEvidence(ctx context.Context, evidence cmtypes.Evidence) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	key := pendingKey(evidence)
+	pending, err := p.kv.Has(ctx, key)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return ErrEvidenceAlreadyPending
+	}
+	state, err := p.store.GetState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if err := p.verify(ctx, state, evidence); err != nil {
+		return err
+	}
+	evBytes, err := types.MarshalEvidence(evidence)
+	if err != nil {
+		return err
+	}
+	if err := p.kv.Put(ctx, key, evBytes); err != nil {
+		return err
+	}
+	p.logger.Info("verified new evidence of misbehavior", "evidence", evidence)
+	return nil
+}
+
+// BroadcastEvidence adds evidence to the pool, and sends it to other nodes. Failure to send evidence is only
+// logged, as evidence is already in the pool.
+func (p *Pool) BroadcastEvidence(ctx context.Context, evidence cmtypes.Evidence) error {
+	if err := p.AddEvidence(ctx, evidence); err != nil {
+		return err
+	}
+	if p.broadcast == nil {
+		return nil
+	}
+	evBytes, err := types.MarshalEvidence(evidence)
+	if err != nil {
+		return err
+	}
+	if err := p.broadcast(ctx, evBytes); err != nil {
+		p.logger.Error("failed to broadcast evidence", "evidence", evidence, "error", err)
+	}
+	return nil
+}
+
+// ReportConflictingHeaders creates evidence of signing two different headers at the same height, adds it to the
+// pool and sends it to other nodes.
+func (p *Pool) ReportConflictingHeaders(ctx context.Context, header1, header2 *types.SignedHeader) (cmtypes.Evidence, error) {
+	evidence, err := types.NewDoubleSignEvidence(header1, header2)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEvidence, err)
+	}
+	return evidence, p.BroadcastEvidence(ctx, evidence)
+}
+
+// PendingEvidence returns pending evidence up to maxBytes (-1 means no limit), and its total size.
+func (p *Pool) PendingEvidence(maxBytes int64) ([]cmtypes.Evidence, int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	pending, err := p.pending(context.Background())
+	if err != nil {
+		p.logger.Error("failed to load pending evidence", "error", err)
+		return nil, 0
+	}
+	var (
+		evidence []cmtypes.Evidence
+		size     int64
+	)
+	for _, ev := range pending {
+		evSize := int64(len(ev.Bytes()))
+		if maxBytes >= 0 && size+evSize > maxBytes {
+			break
+		}
+		evidence = append(evidence, ev)
+		size += evSize
+	}
+	return evidence, size
+}
+
+// CheckEvidence verifies evidence included in a block applied on top of given state.
+func (p *Pool) CheckEvidence(state types.State, evidence cmtypes.EvidenceList) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	ctx := context.Background()
+	seen := make(map[string]struct{}, len(evidence))
+	for _, ev := range evidence {
+		hash := string(ev.Hash())
+		if _, ok := seen[hash]; ok {
+			return fmt.Errorf("%w: duplicate evidence in block: %v", ErrInvalidEvidence, ev)
+		}
+		seen[hash] = struct{}{}
+		if err := p.verify(ctx, state, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update marks evidence included in the last block (of given state) as committed, and removes expired evidence
+// from the pool.
+func (p *Pool) Update(state types.State, evidence cmtypes.EvidenceList) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	ctx := context.Background()
+	for _, ev := range evidence {
+		height := strconv.FormatUint(state.LastBlockHeight, 10)
+		if err := p.kv.Put(ctx, committedKey(ev), []byte(height)); err != nil {
+			p.logger.Error("failed to mark evidence as committed", "evidence", ev, "error", err)
+		}
+		if err := p.kv.Delete(ctx, pendingKey(ev)); err != nil {
+			p.logger.Error("failed to remove committed evidence", "evidence", ev, "error", err)
+		}
+	}
+
+	pending, err := p.pending(ctx)
+	if err != nil {
+		p.logger.Error("failed to load pending evidence", "error", err)
+		return
+	}
+	for _, ev := range pending {
+		if isExpired(state, ev) {
+			p.logger.Info("removing expired evidence", "evidence", ev)
+			if err := p.kv.Delete(ctx, pendingKey(ev)); err != nil {
+				p.logger.Error("failed to remove expired evidence", "evidence", ev, "error", err)
+			}
+		}
+	}
+}
+
+// verify checks if evidence proves misbehavior of the sequencer, and wasn't committed before.
+func (p *Pool) verify(ctx context.Context, state types.State, evidence cmtypes.Evidence) error {
+	ev, ok := evidence.(*types.DoubleSignEvidence)
+	if !ok {
+		return fmt.Errorf("%w: %T", types.ErrUnsupportedEvidence, evidence)
+	}
+	if err := ev.ValidateBasic(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEvidence, err)
+	}
+	committed, err := p.kv.Has(ctx, committedKey(ev))
+	if err != nil {
+		return err
+	}
+	if committed {
+		return ErrEvidenceCommitted
+	}
+	if ev.HeaderA.ChainID() != state.ChainID {
+		return fmt.Errorf("%w: wrong chain ID %q", ErrInvalidEvidence, ev.HeaderA.ChainID())
+	}
+	if isExpired(state, ev) {
+		return ErrEvidenceExpired
+	}
+
+	// headers must be signed by the sequencer of given height
+	validators := state.Validators
+	if ev.Height() <= int64(state.LastBlockHeight) { //nolint:gosec
+		header, _, err := p.store.GetBlockData(ctx, uint64(ev.Height())) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("failed to load header at height %d: %w", ev.Height(), err)
+		}
+		validators = header.Validators
+	}
+	if validators == nil || !bytes.Equal(validators.Hash(), ev.HeaderA.Validators.Hash()) {
+		return fmt.Errorf("%w: headers not signed by the sequencer at height %d", ErrInvalidEvidence, ev.Height())
+	}
+	return nil
+}
+
+// pending returns evidence from the pool, ordered by hash.
+func (p *Pool) pending(ctx context.Context) ([]cmtypes.Evidence, error) {
+	results, err := store.PrefixEntries(ctx, p.kv, store.GenerateKey([]string{pendingPrefix}))
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close() //nolint:errcheck
+
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	evidence := make([]cmtypes.Evidence, 0, len(entries))
+	for _, entry := range entries {
+		ev, err := types.UnmarshalEvidence(entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode evidence %s: %w", entry.Key, err)
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence, nil
+}
+
+// isExpired returns true if evidence is older than both max age in blocks and max age duration.
+func isExpired(state types.State, evidence cmtypes.Evidence) bool {
+	params := state.ConsensusParams.Evidence
+	if params == nil {
+		return false
+	}
+	ageBlocks := int64(state.LastBlockHeight) - evidence.Height() //nolint:gosec
+	ageDuration := state.LastBlockTime.Sub(evidence.Time())
+	return ageBlocks > params.MaxAgeNumBlocks && ageDuration > params.MaxAgeDuration
+}
+
+func pendingKey(evidence cmtypes.Evidence) ds.Key {
+	return ds.NewKey(store.GenerateKey([]string{pendingPrefix, hex.EncodeToString(evidence.Hash())}))
+}
+
+func committedKey(evidence cmtypes.Evidence) ds.Key {
+	return ds.NewKey(store.GenerateKey([]string{committedPrefix, hex.EncodeToString(evidence.Hash())}))
+}
@@ -0,0 +1,167 @@
+package evidence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+const chainID = "TestEvidencePool"
+
+// setupPool returns pool with stored header at height 5 and state at height 10, and evidence of double signing at
+// height 5.
+func setupPool(t *testing.T, broadcast BroadcastFunc) (*Pool, store.Store, types.State, *types.DoubleSignEvidence) {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv := store.NewInMemoryMapKVStore()
+	st := store.New(kv)
+
+	privKey := ed25519.GenPrivKey()
+	header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{
+		Height:      5,
+		DataHash:    types.GetRandomBytes(32),
+		PrivKey:     privKey,
+		VotingPower: 1,
+	}, chainID)
+	require.NoError(err)
+	require.NoError(st.SaveBlockData(ctx, header, &types.Data{}, &header.Signature))
+	conflicting, err := types.GetConflictingSignedHeader(header, privKey)
+	require.NoError(err)
+	ev, err := types.NewDoubleSignEvidence(header, conflicting)
+	require.NoError(err)
+
+	state := types.State{
+		ChainID:         chainID,
+		LastBlockHeight: 10,
+		LastBlockTime:   header.Time().Add(time.Minute),
+		Validators:      header.Validators,
+		NextValidators:  header.Validators,
+		LastValidators:  header.Validators,
+		ConsensusParams: cmproto.ConsensusParams{
+			Evidence: &cmproto.EvidenceParams{
+				MaxAgeNumBlocks: 100,
+				MaxAgeDuration:  time.Hour,
+				MaxBytes:        1024 * 1024,
+			},
+		},
+	}
+	require.NoError(st.UpdateState(ctx, state))
+
+	pool := NewPool(store.NewInMemoryMapKVStore(), st, broadcast, log.TestingLogger())
+	return pool, st, state, ev
+}
+
+func TestPool(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	var broadcasted [][]byte
+	pool, _, state, ev := setupPool(t, func(_ context.Context, evidence []byte) error {
+		broadcasted = append(broadcasted, evidence)
+		return nil
+	})
+
+	require.NoError(pool.BroadcastEvidence(ctx, ev))
+	assert.ErrorIs(pool.AddEvidence(ctx, ev), ErrEvidenceAlreadyPending)
+	assert.ErrorIs(pool.BroadcastEvidence(ctx, ev), ErrEvidenceAlreadyPending)
+	require.Len(broadcasted, 1)
+	decoded, err := types.UnmarshalEvidence(broadcasted[0])
+	require.NoError(err)
+	assert.Equal(ev.Hash(), decoded.Hash())
+
+	pending, size := pool.PendingEvidence(-1)
+	require.Len(pending, 1)
+	assert.Equal(ev.Hash(), pending[0].Hash())
+	assert.Equal(int64(len(ev.Bytes())), size)
+	pending, size = pool.PendingEvidence(size - 1)
+	assert.Empty(pending)
+	assert.Zero(size)
+
+	assert.NoError(pool.CheckEvidence(state, cmtypes.EvidenceList{ev}))
+	assert.ErrorIs(pool.CheckEvidence(state, cmtypes.EvidenceList{ev, ev}), ErrInvalidEvidence)
+
+	// committed evidence is removed from the pool, and never accepted again
+	pool.Update(state, cmtypes.EvidenceList{ev})
+	pending, _ = pool.PendingEvidence(-1)
+	assert.Empty(pending)
+	assert.ErrorIs(pool.AddEvidence(ctx, ev), ErrEvidenceCommitted)
+	assert.ErrorIs(pool.CheckEvidence(state, cmtypes.EvidenceList{ev}), ErrEvidenceCommitted)
+}
+
+func TestPoolRejectsInvalidEvidence(t *testing.T) {
+	ctx := context.Background()
+
+	pool, st, state, ev := setupPool(t, nil)
+
+	assert.ErrorIs(t, pool.AddEvidence(ctx, &cmtypes.DuplicateVoteEvidence{}), types.ErrUnsupportedEvidence)
+
+	t.Run("different sequencer", func(t *testing.T) {
+		privKey := ed25519.GenPrivKey()
+		header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{
+			Height:      5,
+			DataHash:    types.GetRandomBytes(32),
+			PrivKey:     privKey,
+			VotingPower: 1,
+		}, chainID)
+		require.NoError(t, err)
+		conflicting, err := types.GetConflictingSignedHeader(header, privKey)
+		require.NoError(t, err)
+		other, err := types.NewDoubleSignEvidence(header, conflicting)
+		require.NoError(t, err)
+		assert.ErrorIs(t, pool.AddEvidence(ctx, other), ErrInvalidEvidence)
+	})
+
+	t.Run("different chain", func(t *testing.T) {
+		wrongChain := state
+		wrongChain.ChainID = "other chain"
+		assert.ErrorIs(t, pool.CheckEvidence(wrongChain, cmtypes.EvidenceList{ev}), ErrInvalidEvidence)
+	})
+
+	t.Run("expired evidence", func(t *testing.T) {
+		require.NoError(t, pool.AddEvidence(ctx, ev))
+
+		expired := state
+		expired.LastBlockHeight = 200
+		expired.LastBlockTime = ev.Time().Add(2 * time.Hour)
+		// evidence is expired only if it's too old both in blocks and time
+		recent := expired
+		recent.LastBlockTime = ev.Time().Add(time.Minute)
+		assert.NoError(t, pool.CheckEvidence(recent, cmtypes.EvidenceList{ev}))
+		assert.ErrorIs(t, pool.CheckEvidence(expired, cmtypes.EvidenceList{ev}), ErrEvidenceExpired)
+
+		pool.Update(expired, nil)
+		pending, _ := pool.PendingEvidence(-1)
+		assert.Empty(t, pending)
+
+		require.NoError(t, st.UpdateState(ctx, expired))
+		assert.ErrorIs(t, pool.AddEvidence(ctx, ev), ErrEvidenceExpired)
+	})
+}
+
+func TestPoolPersistence(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv := store.NewInMemoryMapKVStore()
+	pool, st, _, ev := setupPool(t, nil)
+	pool.kv = kv
+	require.NoError(pool.AddEvidence(ctx, ev))
+
+	restarted := NewPool(kv, st, nil, log.TestingLogger())
+	pending, _ := restarted.PendingEvidence(-1)
+	require.Len(pending, 1)
+	require.Equal(ev.Hash(), pending[0].Hash())
+}
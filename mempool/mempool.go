@@ -21,6 +21,11 @@ const (
 	UnknownPeerID uint16 = 0
 
 	MaxActiveIDs = math.MaxUint16
+
+	// CListMempoolType selects CListMempool, which orders transactions by arrival.
+	CListMempoolType = "clist"
+	// PriorityMempoolType selects PriorityMempool, which orders transactions by priority.
+	PriorityMempoolType = "priority"
 )
 
 //go:generate ../scripts/mockery_generate.sh Mempool
@@ -0,0 +1,661 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// PriorityEventType is the type of CheckTx event used by applications to report priority of transaction.
+	PriorityEventType = "mempool"
+	// PriorityAttributeKey is the key of PriorityEventType event attribute holding priority (an integer).
+	PriorityAttributeKey = "priority"
+)
+
+// PriorityFunc returns priority of transaction accepted by the application in CheckTx.
+type PriorityFunc func(tx types.Tx, res *abci.ResponseCheckTx) int64
+
+// EventPriority is the default PriorityFunc. It returns priority reported in PriorityAttributeKey attribute of
+// PriorityEventType event of CheckTx response, or 0 if it's missing or invalid.
+//
+// ABCI 2.0 removed the Priority field from ResponseCheckTx, so events are used to pass priority to the mempool.
+func EventPriority(_ types.Tx, res *abci.ResponseCheckTx) int64 {
+	for _, event := range res.Events {
+		if event.Type != PriorityEventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key != PriorityAttributeKey {
+				continue
+			}
+			priority, err := strconv.ParseInt(attr.Value, 10, 64)
+			if err != nil {
+				return 0
+			}
+			return priority
+		}
+	}
+	return 0
+}
+
+// PriorityMempool is an in-memory pool for transactions ordered by priority assigned by the application in
+// CheckTx. Transactions with the same priority are ordered by arrival.
+//
+// When the mempool is full, transactions with the lowest priority are evicted to make room for a transaction
+// with higher priority. If there is not enough room even after evicting all transactions with lower priority,
+// the new transaction is rejected.
+type PriorityMempool struct {
+	// Atomic integers
+	height uint64 // the last block Update()'d to
+
+	// notify listeners (ie. consensus) when txs are available
+	notifiedTxsAvailable bool
+	txAvailMtx           sync.Mutex
+	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
+
+	config *config.MempoolConfig
+
+	// Exclusive mutex for Update method to prevent concurrent execution of
+	// CheckTx or ReapMaxBytesMaxGas(ReapMaxTxs) methods.
+	updateMtx sync.RWMutex
+	preCheck  PreCheckFunc
+	postCheck PostCheckFunc
+	priority  PriorityFunc
+
+	proxyAppConn proxy.AppConnMempool
+
+	// mtx protects fields below, as CheckTx responses may be processed concurrently.
+	mtx      sync.RWMutex
+	txs      []*priorityTx // ordered by priority (descending), then by arrival
+	txsMap   map[types.TxKey]*priorityTx
+	txsBytes int64 // total size of mempool, in bytes
+
+	// Transactions sent to the application for rechecking, in order of requests.
+	recheck []types.Tx
+
+	// Keep a cache of already-seen txs.
+	// This reduces the pressure on the proxyApp.
+	cache TxCache
+
+	logger  log.Logger
+	metrics *Metrics
+}
+
+var _ Mempool = &PriorityMempool{}
+
+// PriorityMempoolOption sets an optional parameter on the mempool.
+type PriorityMempoolOption func(*PriorityMempool)
+
+// NewPriorityMempool returns a new priority mempool with the given configuration and connection to an
+// application. By default, priority of transactions is read with EventPriority.
+func NewPriorityMempool(
+	cfg *config.MempoolConfig,
+	proxyAppConn proxy.AppConnMempool,
+	height uint64,
+	options ...PriorityMempoolOption,
+) *PriorityMempool {
+	mp := &PriorityMempool{
+		config:       cfg,
+		proxyAppConn: proxyAppConn,
+		height:       height,
+		priority:     EventPriority,
+		txsMap:       make(map[types.TxKey]*priorityTx),
+		logger:       log.NewNopLogger(),
+		metrics:      NopMetrics(),
+	}
+
+	if cfg.CacheSize > 0 {
+		mp.cache = NewLRUTxCache(cfg.CacheSize)
+	} else {
+		mp.cache = NopTxCache{}
+	}
+
+	proxyAppConn.SetResponseCallback(mp.globalCb)
+
+	for _, option := range options {
+		option(mp)
+	}
+
+	return mp
+}
+
+// WithPriorityFunc sets the function used to assign priority to transactions.
+func WithPriorityFunc(f PriorityFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.priority = f }
+}
+
+// WithPriorityPreCheck sets a filter for the mempool to reject a tx if f(tx) returns
+// false. See WithPreCheck.
+func WithPriorityPreCheck(f PreCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.preCheck = f }
+}
+
+// WithPriorityPostCheck sets a filter for the mempool to reject a tx if f(tx) returns
+// false. See WithPostCheck.
+func WithPriorityPostCheck(f PostCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.postCheck = f }
+}
+
+// WithPriorityMetrics sets the metrics.
+func WithPriorityMetrics(metrics *Metrics) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.metrics = metrics }
+}
+
+// NOTE: not thread safe - should only be called once, on startup
+func (mem *PriorityMempool) EnableTxsAvailable() {
+	mem.txsAvailable = make(chan struct{}, 1)
+}
+
+// SetLogger sets the Logger.
+func (mem *PriorityMempool) SetLogger(l log.Logger) {
+	mem.logger = l
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) Lock() {
+	mem.updateMtx.Lock()
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) Unlock() {
+	mem.updateMtx.Unlock()
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) Size() int {
+	mem.mtx.RLock()
+	defer mem.mtx.RUnlock()
+	return len(mem.txs)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) SizeBytes() int64 {
+	mem.mtx.RLock()
+	defer mem.mtx.RUnlock()
+	return mem.txsBytes
+}
+
+// Lock() must be help by the caller during execution.
+func (mem *PriorityMempool) FlushAppConn() error {
+	return mem.proxyAppConn.Flush(context.TODO())
+}
+
+// Flush removes all transactions from the mempool and the cache.
+func (mem *PriorityMempool) Flush() {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	mem.cache.Reset()
+	mem.txs = nil
+	mem.txsMap = make(map[types.TxKey]*priorityTx)
+	mem.txsBytes = 0
+}
+
+// CheckTx executes a new transaction against the application, and adds it to the mempool if it's valid.
+//
+// Priority of transaction is known only after the application responds, so ErrMempoolIsFull is returned only
+// if the response was processed before CheckTx returns (e.g. with local ABCI client). Otherwise, the
+// transaction is rejected asynchronously, and counted in RejectedTxs metric.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) CheckTx(
+	tx types.Tx,
+	cb func(*abci.ResponseCheckTx),
+	txInfo TxInfo,
+) error {
+	mem.updateMtx.RLock()
+	// use defer to unlock mutex because application (*local client*) might panic
+	defer mem.updateMtx.RUnlock()
+
+	txSize := len(tx)
+
+	if txSize > mem.config.MaxTxBytes {
+		return ErrTxTooLarge{
+			Max:    mem.config.MaxTxBytes,
+			Actual: txSize,
+		}
+	}
+
+	if mem.preCheck != nil {
+		if err := mem.preCheck(tx); err != nil {
+			return ErrPreCheck{
+				Reason: err,
+			}
+		}
+	}
+
+	// NOTE: proxyAppConn may error if tx buffer is full
+	if err := mem.proxyAppConn.Error(); err != nil {
+		return err
+	}
+
+	if !mem.cache.Push(tx) { // if the transaction already exists in the cache
+		// Record a new sender for a tx we've already seen, if it's still in the mempool.
+		mem.mtx.RLock()
+		if memTx, ok := mem.txsMap[tx.Key()]; ok {
+			memTx.senders.LoadOrStore(txInfo.SenderID, true)
+		}
+		mem.mtx.RUnlock()
+		return ErrTxInCache
+	}
+
+	reqRes, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.RequestCheckTx{Tx: tx})
+	if err != nil {
+		return err
+	}
+	errCh := make(chan error, 1)
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb, errCh))
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// Global callback that will be called after every ABCI response. It only handles responses to recheck
+// requests; responses to new transactions are handled by request specific callbacks (see reqResCb).
+func (mem *PriorityMempool) globalCb(req *abci.Request, res *abci.Response) {
+	checkTx := req.GetCheckTx()
+	if checkTx == nil || checkTx.Type != abci.CheckTxType_Recheck {
+		return
+	}
+
+	mem.metrics.RecheckTimes.Add(1)
+	mem.resCbRecheck(checkTx.Tx, res)
+
+	// update metrics
+	mem.metrics.Size.Set(float64(mem.Size()))
+	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+}
+
+// Request specific callback that records the peer that sent us the tx, and calls externalCb passed by the
+// caller of CheckTx when response processing is complete. The result of adding the tx to the mempool is sent
+// to errCh.
+func (mem *PriorityMempool) reqResCb(
+	tx []byte,
+	peerID uint16,
+	peerP2PID p2p.ID,
+	externalCb func(*abci.ResponseCheckTx),
+	errCh chan<- error,
+) func(res *abci.Response) {
+	return func(res *abci.Response) {
+		errCh <- mem.resCbFirstTime(tx, peerID, peerP2PID, res)
+
+		// update metrics
+		mem.metrics.Size.Set(float64(mem.Size()))
+		mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+
+		// passed in by the caller of CheckTx, eg. the RPC
+		if externalCb != nil {
+			externalCb(res.GetCheckTx())
+		}
+	}
+}
+
+// callback, which is called after the app checked the tx for the first time.
+func (mem *PriorityMempool) resCbFirstTime(
+	tx []byte,
+	peerID uint16,
+	peerP2PID p2p.ID,
+	res *abci.Response,
+) error {
+	r, ok := res.Value.(*abci.Response_CheckTx)
+	if !ok {
+		// ignore other messages
+		return nil
+	}
+
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(tx, r.CheckTx)
+	}
+	if r.CheckTx.Code != abci.CodeTypeOK || postCheckErr != nil {
+		// ignore bad transaction
+		mem.logger.Debug(
+			"rejected bad transaction",
+			"tx", types.Tx(tx).Hash(),
+			"peerID", peerP2PID,
+			"res", r,
+			"err", postCheckErr,
+		)
+		mem.metrics.FailedTxs.Add(1)
+
+		if !mem.config.KeepInvalidTxsInCache {
+			// remove from cache (it might be good later)
+			mem.cache.Remove(tx)
+		}
+		return nil
+	}
+
+	memTx := &priorityTx{
+		mempoolTx: &mempoolTx{
+			height:    atomic.LoadUint64(&mem.height),
+			gasWanted: r.CheckTx.GasWanted,
+			tx:        tx,
+		},
+		priority: mem.priority(tx, r.CheckTx),
+	}
+	memTx.senders.Store(peerID, true)
+	if err := mem.addTx(memTx); err != nil {
+		// remove from cache (mempool might have a space later)
+		mem.cache.Remove(tx)
+		mem.metrics.RejectedTxs.Add(1)
+		mem.logger.Debug("rejected transaction", "tx", types.Tx(tx).Hash(), "priority", memTx.priority, "err", err)
+		return err
+	}
+	mem.logger.Debug(
+		"added good transaction",
+		"tx", types.Tx(tx).Hash(),
+		"priority", memTx.priority,
+		"res", r,
+		"height", memTx.height,
+		"total", mem.Size(),
+	)
+	mem.notifyTxsAvailable()
+	return nil
+}
+
+// addTx adds transaction to the mempool, evicting transactions with lower priority if the mempool is full.
+// ErrMempoolIsFull is returned if not enough space can be freed.
+func (mem *PriorityMempool) addTx(memTx *priorityTx) error {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	key := memTx.tx.Key()
+	if _, ok := mem.txsMap[key]; ok {
+		return nil
+	}
+
+	txSize := int64(len(memTx.tx))
+	txsBytes := mem.txsBytes
+	evict := 0
+	for len(mem.txs)-evict >= mem.config.Size || txsBytes+txSize > mem.config.MaxTxsBytes {
+		// the last transaction has the lowest priority, and arrived the latest
+		last := len(mem.txs) - 1 - evict
+		if last < 0 || mem.txs[last].priority >= memTx.priority {
+			return ErrMempoolIsFull{
+				NumTxs:      len(mem.txs),
+				MaxTxs:      mem.config.Size,
+				TxsBytes:    mem.txsBytes,
+				MaxTxsBytes: mem.config.MaxTxsBytes,
+			}
+		}
+		txsBytes -= int64(len(mem.txs[last].tx))
+		evict++
+	}
+
+	for _, evicted := range mem.txs[len(mem.txs)-evict:] {
+		delete(mem.txsMap, evicted.tx.Key())
+		// evicted transaction is valid, so it can be resubmitted later
+		mem.cache.Remove(evicted.tx)
+		mem.metrics.EvictedTxs.Add(1)
+		mem.logger.Debug("evicted transaction", "tx", evicted.tx.Hash(), "priority", evicted.priority)
+	}
+	mem.txs = slices.Delete(mem.txs, len(mem.txs)-evict, len(mem.txs))
+	mem.txsBytes = txsBytes
+
+	// insert after all transactions with the same or higher priority
+	i := sort.Search(len(mem.txs), func(i int) bool { return mem.txs[i].priority < memTx.priority })
+	mem.txs = slices.Insert(mem.txs, i, memTx)
+	mem.txsMap[key] = memTx
+	mem.txsBytes += txSize
+	mem.metrics.TxSizeBytes.Observe(float64(txSize))
+	return nil
+}
+
+// removeTx removes transaction from the mempool. It returns false if transaction is not in the mempool.
+func (mem *PriorityMempool) removeTx(txKey types.TxKey) bool {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	memTx, ok := mem.txsMap[txKey]
+	if !ok {
+		return false
+	}
+	i := sort.Search(len(mem.txs), func(i int) bool { return mem.txs[i].priority <= memTx.priority })
+	for ; i < len(mem.txs); i++ {
+		if mem.txs[i] == memTx {
+			mem.txs = slices.Delete(mem.txs, i, i+1)
+			break
+		}
+	}
+	delete(mem.txsMap, txKey)
+	mem.txsBytes -= int64(len(memTx.tx))
+	return true
+}
+
+// RemoveTxByKey removes a transaction from the mempool by its TxKey index.
+func (mem *PriorityMempool) RemoveTxByKey(txKey types.TxKey) error {
+	if !mem.removeTx(txKey) {
+		return errors.New("transaction not found")
+	}
+	return nil
+}
+
+// callback, which is called after the app rechecked the tx.
+func (mem *PriorityMempool) resCbRecheck(tx types.Tx, res *abci.Response) {
+	r, ok := res.Value.(*abci.Response_CheckTx)
+	if !ok {
+		// ignore other messages
+		return
+	}
+
+	// Responses arrive in order of requests, so skip transactions the application didn't respond to.
+	mem.mtx.Lock()
+	for len(mem.recheck) > 0 && !bytes.Equal(mem.recheck[0], tx) {
+		mem.logger.Error(
+			"re-CheckTx transaction mismatch",
+			"got", tx,
+			"expected", mem.recheck[0],
+		)
+		mem.recheck = mem.recheck[1:]
+	}
+	if len(mem.recheck) == 0 {
+		// we reached the end of the recheck list without finding a matching tx
+		mem.mtx.Unlock()
+		return
+	}
+	mem.recheck = mem.recheck[1:]
+	done := len(mem.recheck) == 0
+	mem.mtx.Unlock()
+
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(tx, r.CheckTx)
+	}
+
+	if (r.CheckTx.Code != abci.CodeTypeOK) || postCheckErr != nil {
+		// Tx became invalidated due to newly committed block.
+		mem.logger.Debug("tx is no longer valid", "tx", tx.Hash(), "res", r, "err", postCheckErr)
+		mem.removeTx(tx.Key())
+		// We remove the invalid tx from the cache because it might be good later
+		if !mem.config.KeepInvalidTxsInCache {
+			mem.cache.Remove(tx)
+		}
+	}
+	if done {
+		mem.logger.Debug("done rechecking txs")
+
+		// in case the recheck removed all txs
+		if mem.Size() > 0 {
+			mem.notifyTxsAvailable()
+		}
+	}
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) TxsAvailable() <-chan struct{} {
+	return mem.txsAvailable
+}
+
+func (mem *PriorityMempool) notifyTxsAvailable() {
+	mem.txAvailMtx.Lock()
+	defer mem.txAvailMtx.Unlock()
+	if mem.Size() == 0 {
+		panic("notified txs available but mempool is empty!")
+	}
+	if mem.txsAvailable != nil && !mem.notifiedTxsAvailable {
+		// channel cap is 1, so this will send once
+		mem.notifiedTxsAvailable = true
+		select {
+		case mem.txsAvailable <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ReapMaxBytesMaxGas reaps transactions in order of priority. Transactions exceeding remaining bytes or gas
+// are skipped, so transactions with lower priority may still be included.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.mtx.RLock()
+	defer mem.mtx.RUnlock()
+
+	var (
+		totalGas    int64
+		runningSize int64
+	)
+
+	txs := make([]types.Tx, 0, len(mem.txs))
+	for _, memTx := range mem.txs {
+		// Check total gas requirement and total size requirement.
+		// If maxGas is negative, skip this check.
+		newTotalGas := totalGas + memTx.gasWanted
+		totalDataSize := runningSize + types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
+		if (maxGas > -1 && newTotalGas > maxGas) || (maxBytes > -1 && totalDataSize > maxBytes) {
+			continue
+		}
+		totalGas = newTotalGas
+		runningSize = totalDataSize
+		txs = append(txs, memTx.tx)
+	}
+	return txs
+}
+
+// ReapMaxTxs reaps up to max transactions with the highest priority.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxTxs(max int) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.mtx.RLock()
+	defer mem.mtx.RUnlock()
+
+	if max < 0 || max > len(mem.txs) {
+		max = len(mem.txs)
+	}
+
+	txs := make([]types.Tx, 0, max)
+	for _, memTx := range mem.txs[:max] {
+		txs = append(txs, memTx.tx)
+	}
+	return txs
+}
+
+// Lock() must be help by the caller during execution.
+func (mem *PriorityMempool) Update(
+	height uint64,
+	txs types.Txs,
+	txResults []*abci.ExecTxResult,
+	preCheck PreCheckFunc,
+	postCheck PostCheckFunc,
+) error {
+	// Set height
+	atomic.StoreUint64(&mem.height, height)
+	mem.notifiedTxsAvailable = false
+
+	if preCheck != nil {
+		mem.preCheck = preCheck
+	}
+	if postCheck != nil {
+		mem.postCheck = postCheck
+	}
+
+	for i, tx := range txs {
+		if txResults[i].Code == abci.CodeTypeOK {
+			// Add valid committed tx to the cache (if missing).
+			_ = mem.cache.Push(tx)
+		} else if !mem.config.KeepInvalidTxsInCache {
+			// Allow invalid transactions to be resubmitted.
+			mem.cache.Remove(tx)
+		}
+
+		// Remove committed tx from the mempool.
+		if err := mem.RemoveTxByKey(tx.Key()); err != nil {
+			mem.logger.Debug("Committed transaction not in local mempool (not an error)",
+				"key", tx.Key(),
+				"error", err.Error())
+		}
+	}
+
+	// Either recheck non-committed txs to see if they became invalid
+	// or just notify there're some txs left.
+	if mem.Size() > 0 {
+		if mem.config.Recheck {
+			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
+			mem.recheckTxs()
+		} else {
+			mem.notifyTxsAvailable()
+		}
+	}
+
+	// Update metrics
+	mem.metrics.Size.Set(float64(mem.Size()))
+	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+
+	return nil
+}
+
+func (mem *PriorityMempool) recheckTxs() {
+	mem.mtx.Lock()
+	txs := make([]types.Tx, len(mem.txs))
+	for i, memTx := range mem.txs {
+		txs[i] = memTx.tx
+	}
+	mem.recheck = txs
+	mem.mtx.Unlock()
+
+	// Push txs to proxyAppConn
+	// NOTE: globalCb may be called concurrently (or synchronously, with local client), so mtx must not be held.
+	for _, tx := range txs {
+		_, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.RequestCheckTx{
+			Tx:   tx,
+			Type: abci.CheckTxType_Recheck,
+		})
+		if err != nil {
+			mem.logger.Error("recheckTx", err, "err")
+			return
+		}
+	}
+}
+
+//--------------------------------------------------------------------------------
+
+// priorityTx is a transaction that successfully ran, with priority assigned by the application
+type priorityTx struct {
+	*mempoolTx
+	priority int64
+}
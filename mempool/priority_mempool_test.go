@@ -0,0 +1,137 @@
+package mempool
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// priorityApp accepts transactions in "priority/payload" format, and reports their priority in CheckTx events.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(_ context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	priority, _, ok := strings.Cut(string(req.Tx), "/")
+	if _, err := strconv.ParseInt(priority, 10, 64); !ok || err != nil {
+		return &abci.ResponseCheckTx{Code: 1}, nil
+	}
+	return &abci.ResponseCheckTx{
+		Code:      abci.CodeTypeOK,
+		GasWanted: 1,
+		Events: []abci.Event{{
+			Type:       PriorityEventType,
+			Attributes: []abci.EventAttribute{{Key: PriorityAttributeKey, Value: priority}},
+		}},
+	}, nil
+}
+
+func priorityTxs(priorities ...int) types.Txs {
+	txs := make(types.Txs, len(priorities))
+	for i, priority := range priorities {
+		txs[i] = types.Tx(fmt.Sprintf("%d/%d", priority, i))
+	}
+	return txs
+}
+
+func newPriorityMempool(t *testing.T, size int, maxTxsBytes int64) *PriorityMempool {
+	cfg := ResetTestRoot("mempool_test")
+	cfg.Mempool.Size = size
+	cfg.Mempool.MaxTxsBytes = maxTxsBytes
+	mp, cleanup := newMempoolWithAppAndConfig(PriorityMempoolType, proxy.NewLocalClientCreator(priorityApp{}), cfg)
+	t.Cleanup(cleanup)
+	return mp.(*PriorityMempool)
+}
+
+func TestPriorityMempoolOrdering(t *testing.T) {
+	mp := newPriorityMempool(t, 10, 1000)
+
+	txs := priorityTxs(1, 5, 3, 5, -2)
+	for _, tx := range txs {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// higher priority first, ties broken by arrival
+	expected := types.Txs{txs[1], txs[3], txs[2], txs[0], txs[4]}
+	assert.Equal(t, expected, mp.ReapMaxTxs(-1))
+	assert.Equal(t, expected[:2], mp.ReapMaxTxs(2))
+	assert.Equal(t, expected[:3], mp.ReapMaxBytesMaxGas(-1, 3))
+
+	// removing transactions keeps the order
+	require.NoError(t, mp.RemoveTxByKey(txs[3].Key()))
+	require.NoError(t, mp.Update(1, types.Txs{txs[2]}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	assert.Equal(t, types.Txs{txs[1], txs[0], txs[4]}, mp.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	mp := newPriorityMempool(t, 3, 1000)
+
+	txs := priorityTxs(1, 2, 3, 4, 1, 2)
+	for _, tx := range txs[:3] {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// transaction with the lowest priority is evicted
+	require.NoError(t, mp.CheckTx(txs[3], nil, TxInfo{}))
+	assert.Equal(t, types.Txs{txs[3], txs[2], txs[1]}, mp.ReapMaxTxs(-1))
+
+	// evicted transaction can be resubmitted, but it's rejected as there is nothing to evict
+	assert.IsType(t, ErrMempoolIsFull{}, mp.CheckTx(txs[0], nil, TxInfo{}))
+	assert.IsType(t, ErrMempoolIsFull{}, mp.CheckTx(txs[4], nil, TxInfo{}))
+	// transactions with the same priority are not evicted
+	assert.IsType(t, ErrMempoolIsFull{}, mp.CheckTx(txs[5], nil, TxInfo{}))
+	assert.Equal(t, types.Txs{txs[3], txs[2], txs[1]}, mp.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolEvictionBySize(t *testing.T) {
+	small := priorityTxs(1, 2, 3)
+	large := types.Tx("4/" + strings.Repeat("x", 10))
+	tooLarge := types.Tx("2/" + strings.Repeat("x", 20))
+	mp := newPriorityMempool(t, 10, int64(len(large)+len(small[2])))
+
+	for _, tx := range small {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// too large transaction would require evicting transaction with higher priority, so nothing is evicted
+	assert.IsType(t, ErrMempoolIsFull{}, mp.CheckTx(tooLarge, nil, TxInfo{}))
+	assert.Equal(t, 3, mp.Size())
+
+	// as many transactions with the lowest priority as needed are evicted
+	require.NoError(t, mp.CheckTx(large, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{large, small[2]}, mp.ReapMaxTxs(-1))
+	assert.EqualValues(t, len(large)+len(small[2]), mp.SizeBytes())
+}
+
+func TestEventPriority(t *testing.T) {
+	event := func(typ, key, value string) abci.Event {
+		return abci.Event{Type: typ, Attributes: []abci.EventAttribute{{Key: key, Value: value}}}
+	}
+
+	cases := []struct {
+		name     string
+		events   []abci.Event
+		expected int64
+	}{
+		{"no events", nil, 0},
+		{"priority", []abci.Event{event(PriorityEventType, PriorityAttributeKey, "42")}, 42},
+		{"negative priority", []abci.Event{event(PriorityEventType, PriorityAttributeKey, "-7")}, -7},
+		{"invalid priority", []abci.Event{event(PriorityEventType, PriorityAttributeKey, "high")}, 0},
+		{"other event", []abci.Event{event("transfer", PriorityAttributeKey, "42")}, 0},
+		{"other attribute", []abci.Event{event(PriorityEventType, "fee", "42")}, 0},
+		{"multiple events", []abci.Event{event("transfer", "amount", "1"), event(PriorityEventType, PriorityAttributeKey, "3")}, 3},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, EventPriority(nil, &abci.ResponseCheckTx{Events: c.events}))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/hex"
@@ -11,6 +12,7 @@ import (
 
 	ds "github.com/ipfs/go-datastore"
 	ktds "github.com/ipfs/go-datastore/keytransform"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -32,6 +34,7 @@ import (
 	"github.com/rollkit/rollkit/block"
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/evidence"
 	"github.com/rollkit/rollkit/mempool"
 	"github.com/rollkit/rollkit/p2p"
 	"github.com/rollkit/rollkit/state"
@@ -45,8 +48,9 @@ import (
 
 // prefixes used in KV store to separate main node data from DALC data
 var (
-	mainPrefix    = "0"
-	indexerPrefix = "1" // indexPrefix uses "i", so using "0-2" to avoid clash
+	mainPrefix     = "0"
+	indexerPrefix  = "1" // indexPrefix uses "i", so using "0-2" to avoid clash
+	evidencePrefix = "2"
 )
 
 const (
@@ -55,6 +59,9 @@ const (
 	genesisChunkSize = 16 * 1024 * 1024 // 16 MiB
 )
 
+// ErrImportedStateMismatch is returned when ABCI app doesn't match the state of blocks imported into the store.
+var ErrImportedStateMismatch = errors.New("app state doesn't match imported blocks")
+
 var _ Node = &FullNode{}
 
 // FullNode represents a client node in Rollkit network.
@@ -78,6 +85,7 @@ type FullNode struct {
 	Mempool      mempool.Mempool
 	mempoolIDs   *mempoolIDs
 	Store        store.Store
+	EvidencePool *evidence.Pool
 	blockManager *block.Manager
 	client       rpcclient.Client
 
@@ -145,6 +153,9 @@ func newFullNode(
 	}
 
 	mainKV := newPrefixKV(baseKV, mainPrefix)
+	if _, err := migrateStore(ctx, mainKV, false, logger); err != nil {
+		return nil, err
+	}
 	headerSyncService, err := initHeaderSyncService(mainKV, nodeConfig, genesis, p2pClient, logger)
 	if err != nil {
 		return nil, err
@@ -155,13 +166,21 @@ func newFullNode(
 		return nil, err
 	}
 
-	mempool := initMempool(proxyApp, memplMetrics)
+	mempool, err := initMempool(proxyApp, nodeConfig.MempoolType, memplMetrics)
+	if err != nil {
+		return nil, err
+	}
 
 	seqClient := seqGRPC.NewClient()
 	mempoolReaper := initMempoolReaper(mempool, []byte(genesis.ChainID), seqClient, logger.With("module", "reaper"))
 
 	store := store.New(mainKV)
-	blockManager, err := initBlockManager(signingKey, nodeConfig, genesis, store, mempool, mempoolReaper, seqClient, proxyApp, dalc, eventBus, logger, headerSyncService, dataSyncService, seqMetrics, smMetrics)
+	if err := verifyImportedState(ctx, store, proxyApp); err != nil {
+		return nil, err
+	}
+	evidencePool := evidence.NewPool(newPrefixKV(baseKV, evidencePrefix), store, p2pClient.GossipEvidence, logger.With("module", "evidence"))
+
+	blockManager, err := initBlockManager(signingKey, nodeConfig, genesis, store, mempool, mempoolReaper, evidencePool, seqClient, proxyApp, dalc, eventBus, logger, headerSyncService, dataSyncService, seqMetrics, smMetrics)
 	if err != nil {
 		return nil, err
 	}
@@ -185,6 +204,7 @@ func newFullNode(
 		mempoolReaper:  mempoolReaper,
 		mempoolIDs:     newMempoolIDs(),
 		Store:          store,
+		EvidencePool:   evidencePool,
 		TxIndexer:      txIndexer,
 		IndexerService: indexerService,
 		BlockIndexer:   blockIndexer,
@@ -197,6 +217,7 @@ func newFullNode(
 
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 	node.p2pClient.SetTxValidator(node.newTxValidator(p2pMetrics))
+	node.p2pClient.SetEvidenceValidator(node.newEvidenceValidator())
 	node.client = NewFullClient(node)
 
 	return node, nil
@@ -226,7 +247,89 @@ func initBaseKV(nodeConfig config.NodeConfig, logger log.Logger) (ds.TxnDatastor
 		logger.Info("WARNING: working in in-memory mode")
 		return store.NewDefaultInMemoryKVStore()
 	}
-	return store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, "rollkit")
+	return store.NewKVStore(nodeConfig.DBBackend, nodeConfig.RootDir, nodeConfig.DBPath, "rollkit")
+}
+
+// OpenStore opens the main store of a full node with given configuration.
+//
+// It's intended for tools operating on node data (e.g. export and import of blocks) while the node is not running.
+// Store height is restored from the last saved state.
+func OpenStore(ctx context.Context, nodeConfig config.NodeConfig, logger log.Logger) (store.Store, error) {
+	baseKV, err := initBaseKV(nodeConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	mainKV := newPrefixKV(baseKV, mainPrefix)
+	if _, err := migrateStore(ctx, mainKV, false, logger); err != nil {
+		return nil, errors.Join(err, mainKV.Close())
+	}
+	s := store.New(mainKV)
+	state, err := s.GetState(ctx)
+	switch {
+	case err == nil:
+		s.SetHeight(ctx, state.LastBlockHeight)
+	case !errors.Is(err, ds.ErrNotFound):
+		return nil, errors.Join(err, s.Close())
+	}
+	return s, nil
+}
+
+// MigrateStore applies pending schema migrations to the main store of a full node with given configuration.
+//
+// If dryRun is true, store is not modified, and returned results describe changes that would be made.
+func MigrateStore(ctx context.Context, nodeConfig config.NodeConfig, dryRun bool, logger log.Logger) ([]store.MigrationResult, error) {
+	baseKV, err := initBaseKV(nodeConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	mainKV := newPrefixKV(baseKV, mainPrefix)
+	results, err := migrateStore(ctx, mainKV, dryRun, logger)
+	return results, errors.Join(err, mainKV.Close())
+}
+
+// migrateStore converts data saved by previous versions of the node to the current store schema version.
+func migrateStore(ctx context.Context, mainKV ds.TxnDatastore, dryRun bool, logger log.Logger) ([]store.MigrationResult, error) {
+	results, err := store.Migrate(ctx, mainKV, dryRun)
+	if err != nil {
+		return results, fmt.Errorf("failed to migrate store: %w", err)
+	}
+	if !dryRun {
+		for _, r := range results {
+			logger.Info("migrated store", "version", r.Version, "description", r.Description, "entries", r.Entries)
+		}
+	}
+	return results, nil
+}
+
+// verifyImportedState makes sure that the ABCI app was restored to the state of blocks imported with
+// store.Import. Imported blocks are not executed by the node, so the app has to be restored from a snapshot
+// taken at the height of the last imported block. The check is skipped once node applies blocks on top of
+// the imported ones.
+func verifyImportedState(ctx context.Context, s store.Store, proxyApp proxy.AppConns) error {
+	importedHeight, imported, err := store.GetImportedHeight(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to load imported height: %w", err)
+	}
+	if !imported {
+		return nil
+	}
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if state.LastBlockHeight != importedHeight {
+		return nil
+	}
+	info, err := proxyApp.Query().Info(ctx, proxy.RequestInfo)
+	if err != nil {
+		return fmt.Errorf("failed to query app info: %w", err)
+	}
+	if uint64(info.LastBlockHeight) != state.LastBlockHeight || !bytes.Equal(info.LastBlockAppHash, state.AppHash) { //nolint:gosec
+		return fmt.Errorf("%w: blocks up to height %d (app hash %X) were imported, but app is at height %d (app hash %X); "+
+			"app has to be restored from a snapshot taken at the imported height",
+			ErrImportedStateMismatch, state.LastBlockHeight, state.AppHash, info.LastBlockHeight, info.LastBlockAppHash)
+	}
+	return nil
 }
 
 func initDALC(nodeConfig config.NodeConfig, logger log.Logger) (*da.DAClient, error) {
@@ -253,10 +356,18 @@ func initDALC(nodeConfig config.NodeConfig, logger log.Logger) (*da.DAClient, er
 		namespace, submitOpts, logger.With("module", "da_client")), nil
 }
 
-func initMempool(proxyApp proxy.AppConns, memplMetrics *mempool.Metrics) *mempool.CListMempool {
-	mempool := mempool.NewCListMempool(llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0, mempool.WithMetrics(memplMetrics))
-	mempool.EnableTxsAvailable()
-	return mempool
+func initMempool(proxyApp proxy.AppConns, mempoolType string, memplMetrics *mempool.Metrics) (mempool.Mempool, error) {
+	var mp mempool.Mempool
+	switch mempoolType {
+	case "", mempool.CListMempoolType:
+		mp = mempool.NewCListMempool(llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0, mempool.WithMetrics(memplMetrics))
+	case mempool.PriorityMempoolType:
+		mp = mempool.NewPriorityMempool(llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0, mempool.WithPriorityMetrics(memplMetrics))
+	default:
+		return nil, fmt.Errorf("unknown mempool type: %q", mempoolType)
+	}
+	mp.EnableTxsAvailable()
+	return mp, nil
 }
 
 func initMempoolReaper(m mempool.Mempool, rollupID []byte, seqClient *seqGRPC.Client, logger log.Logger) *mempool.CListMempoolReaper {
@@ -279,8 +390,8 @@ func initDataSyncService(mainKV ds.TxnDatastore, nodeConfig config.NodeConfig, g
 	return dataSyncService, nil
 }
 
-func initBlockManager(signingKey crypto.PrivKey, nodeConfig config.NodeConfig, genesis *cmtypes.GenesisDoc, store store.Store, mempool mempool.Mempool, mempoolReaper *mempool.CListMempoolReaper, seqClient *seqGRPC.Client, proxyApp proxy.AppConns, dalc *da.DAClient, eventBus *cmtypes.EventBus, logger log.Logger, headerSyncService *block.HeaderSyncService, dataSyncService *block.DataSyncService, seqMetrics *block.Metrics, execMetrics *state.Metrics) (*block.Manager, error) {
-	blockManager, err := block.NewManager(signingKey, nodeConfig.BlockManagerConfig, genesis, store, mempool, mempoolReaper, seqClient, proxyApp.Consensus(), dalc, eventBus, logger.With("module", "BlockManager"), headerSyncService.Store(), dataSyncService.Store(), seqMetrics, execMetrics)
+func initBlockManager(signingKey crypto.PrivKey, nodeConfig config.NodeConfig, genesis *cmtypes.GenesisDoc, store store.Store, mempool mempool.Mempool, mempoolReaper *mempool.CListMempoolReaper, evpool *evidence.Pool, seqClient *seqGRPC.Client, proxyApp proxy.AppConns, dalc *da.DAClient, eventBus *cmtypes.EventBus, logger log.Logger, headerSyncService *block.HeaderSyncService, dataSyncService *block.DataSyncService, seqMetrics *block.Metrics, execMetrics *state.Metrics) (*block.Manager, error) {
+	blockManager, err := block.NewManager(signingKey, nodeConfig.BlockManagerConfig, genesis, store, mempool, mempoolReaper, evpool, seqClient, proxyApp.Consensus(), dalc, eventBus, logger.With("module", "BlockManager"), headerSyncService.Store(), dataSyncService.Store(), seqMetrics, execMetrics)
 	if err != nil {
 		return nil, fmt.Errorf("error while initializing BlockManager: %w", err)
 	}
@@ -496,7 +607,7 @@ func (n *FullNode) AppClient() proxy.AppConns {
 // newTxValidator creates a pubsub validator that uses the node's mempool to check the
 // transaction. If the transaction is valid, then it is added to the mempool
 func (n *FullNode) newTxValidator(metrics *p2p.Metrics) p2p.GossipValidator {
-	return func(m *p2p.GossipMessage) bool {
+	return func(m *p2p.GossipMessage) pubsub.ValidationResult {
 		n.Logger.Debug("transaction received", "bytes", len(m.Data))
 		msgBytes := m.Data
 		labels := []string{
@@ -516,20 +627,50 @@ func (n *FullNode) newTxValidator(metrics *p2p.Metrics) p2p.GossipValidator {
 			SenderID:    n.mempoolIDs.GetForPeer(m.From),
 			SenderP2PID: corep2p.ID(m.From),
 		})
+		// transactions not accepted by this node are ignored, as they may be valid for other nodes
 		switch {
 		case errors.Is(err, mempool.ErrTxInCache):
-			return true
+			return pubsub.ValidationAccept
 		case errors.Is(err, mempool.ErrMempoolIsFull{}):
-			return true
+			return pubsub.ValidationAccept
 		case errors.Is(err, mempool.ErrTxTooLarge{}):
-			return false
+			return pubsub.ValidationIgnore
 		case errors.Is(err, mempool.ErrPreCheck{}):
-			return false
+			return pubsub.ValidationIgnore
 		default:
 		}
 		checkTxResp := <-checkTxResCh
 
-		return checkTxResp.Code == abci.CodeTypeOK
+		if checkTxResp.Code != abci.CodeTypeOK {
+			return pubsub.ValidationIgnore
+		}
+		return pubsub.ValidationAccept
+	}
+}
+
+// newEvidenceValidator creates a pubsub validator that verifies evidence and adds it to the evidence pool.
+// Only new, valid evidence is propagated to other peers. Evidence that can't be decoded or verified is
+// rejected; evidence that is valid, but not acceptable to this node (e.g. expired) is ignored.
+func (n *FullNode) newEvidenceValidator() p2p.GossipValidator {
+	return func(m *p2p.GossipMessage) pubsub.ValidationResult {
+		// evidence published by this node was added to the pool before broadcasting
+		if m.From == n.p2pClient.Host().ID() {
+			return pubsub.ValidationAccept
+		}
+		n.Logger.Debug("evidence received", "bytes", len(m.Data))
+		ev, err := types.UnmarshalEvidence(m.Data)
+		if err != nil {
+			n.Logger.Debug("failed to decode evidence", "peer", m.From, "error", err)
+			return pubsub.ValidationReject
+		}
+		if err := n.EvidencePool.AddEvidence(n.ctx, ev); err != nil {
+			n.Logger.Debug("evidence rejected", "peer", m.From, "error", err)
+			if errors.Is(err, evidence.ErrInvalidEvidence) {
+				return pubsub.ValidationReject
+			}
+			return pubsub.ValidationIgnore
+		}
+		return pubsub.ValidationAccept
 	}
 }
 
@@ -10,7 +10,6 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
 	cmbytes "github.com/cometbft/cometbft/libs/bytes"
-	cmpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	cmquery "github.com/cometbft/cometbft/libs/pubsub/query"
 	corep2p "github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/proxy"
@@ -18,9 +17,13 @@ import (
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	cmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	rconfig "github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/p2p"
+	"github.com/rollkit/rollkit/store"
 	"github.com/rollkit/rollkit/types"
 	abciconv "github.com/rollkit/rollkit/types/abci"
 )
@@ -238,31 +241,20 @@ func (c *FullClient) BroadcastTxSync(ctx context.Context, tx cmtypes.Tx) (*ctype
 }
 
 // Subscribe subscribe given subscriber to a query.
+//
+// Events are buffered up to outCapacity (see subscription for details on slow subscribers).
 func (c *FullClient) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
-	q, err := cmquery.New(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse query: %w", err)
-	}
+	return c.subscribe(ctx, subscriber, query, 0, outCapacity...)
+}
 
-	outCap := 1
-	if len(outCapacity) > 0 {
-		outCap = outCapacity[0]
+// SubscribeFromHeight subscribes given subscriber to a query, like Subscribe, but before delivering new events,
+// NewBlock and Tx events of stored blocks starting at fromHeight are replayed. It allows subscribers to resume
+// subscription (e.g. after reconnecting) without missing events.
+func (c *FullClient) SubscribeFromHeight(ctx context.Context, subscriber, query string, fromHeight int64, outCapacity ...int) (<-chan ctypes.ResultEvent, error) {
+	if fromHeight <= 0 {
+		return nil, fmt.Errorf("invalid height %d: must be greater than 0", fromHeight)
 	}
-
-	var sub cmtypes.Subscription
-	if outCap > 0 {
-		sub, err = c.EventBus.Subscribe(ctx, subscriber, q, outCap)
-	} else {
-		sub, err = c.EventBus.SubscribeUnbuffered(ctx, subscriber, q)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe: %w", err)
-	}
-
-	outc := make(chan ctypes.ResultEvent, outCap)
-	go c.eventsRoutine(sub, subscriber, q, outc)
-
-	return outc, nil
+	return c.subscribe(ctx, subscriber, query, fromHeight, outCapacity...)
 }
 
 // Unsubscribe unsubscribes given subscriber from a query.
@@ -321,19 +313,20 @@ func (c *FullClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight in
 	}
 	c.Logger.Debug("BlockchainInfo", "maxHeight", maxHeight, "minHeight", minHeight)
 
+	it, err := c.node.Store.IterateBlocks(ctx, uint64(minHeight), uint64(maxHeight), store.Descending) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
 	blocks := make([]*cmtypes.BlockMeta, 0, maxHeight-minHeight+1)
-	for height := maxHeight; height >= minHeight; height-- {
-		header, data, err := c.node.Store.GetBlockData(ctx, uint64(height))
+	for it.Next() {
+		cmblockmeta, err := abciconv.ToABCIBlockMeta(it.Header(), it.Data())
 		if err != nil {
 			return nil, err
 		}
-		if header != nil && data != nil {
-			cmblockmeta, err := abciconv.ToABCIBlockMeta(header, data)
-			if err != nil {
-				return nil, err
-			}
-			blocks = append(blocks, cmblockmeta)
-		}
+		blocks = append(blocks, cmblockmeta)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
 	}
 
 	return &ctypes.ResultBlockchainInfo{
@@ -343,15 +336,58 @@ func (c *FullClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight in
 
 }
 
+// BannedPeers returns list of peers temporarily banned for misbehavior.
+func (c *FullClient) BannedPeers() []p2p.PeerBan {
+	return c.node.p2pClient.BannedPeers()
+}
+
+// DialPeers connects to given peers. If persistent is true, peers are dialed again after restart.
+func (c *FullClient) DialPeers(ctx context.Context, peers []string, persistent bool) error {
+	return c.node.p2pClient.DialPeers(ctx, peers, persistent)
+}
+
+// BlockPeer blocks connections with given peer. If persistent is false, the block is lifted after restart.
+func (c *FullClient) BlockPeer(ctx context.Context, id string, persistent bool) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return fmt.Errorf("invalid peer ID: %w", err)
+	}
+	return c.node.p2pClient.BlockPeer(ctx, peerID, persistent)
+}
+
+// UnblockPeer lifts the block (or ban) of given peer.
+func (c *FullClient) UnblockPeer(ctx context.Context, id string) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return fmt.Errorf("invalid peer ID: %w", err)
+	}
+	return c.node.p2pClient.UnblockPeer(ctx, peerID)
+}
+
+// BlockedPeers returns IDs of blocked peers, including banned peers.
+func (c *FullClient) BlockedPeers() []string {
+	blocked := c.node.p2pClient.BlockedPeers()
+	ids := make([]string, 0, len(blocked))
+	for _, id := range blocked {
+		ids = append(ids, id.String())
+	}
+	return ids
+}
+
 // NetInfo returns basic information about client P2P connections.
 func (c *FullClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
+	return p2pNetInfo(c.node.p2pClient), nil
+}
+
+// p2pNetInfo returns basic information about P2P connections of given client.
+func p2pNetInfo(client *p2p.Client) *ctypes.ResultNetInfo {
 	res := ctypes.ResultNetInfo{
 		Listening: true,
 	}
-	for _, ma := range c.node.p2pClient.Addrs() {
+	for _, ma := range client.Addrs() {
 		res.Listeners = append(res.Listeners, ma.String())
 	}
-	peers := c.node.p2pClient.Peers()
+	peers := client.Peers()
 	res.NPeers = len(peers)
 	for _, peer := range peers {
 		res.Peers = append(res.Peers, ctypes.Peer{
@@ -362,7 +398,7 @@ func (c *FullClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error)
 		})
 	}
 
-	return &res, nil
+	return &res
 }
 
 // DumpConsensusState always returns error as there is no consensus state in Rollkit.
@@ -377,13 +413,14 @@ func (c *FullClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensu
 
 // ConsensusParams returns consensus params at given height.
 func (c *FullClient) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
-	state, err := c.node.Store.GetState(ctx)
+	heightValue := c.normalizeHeight(height)
+	state, err := c.getStateForHeight(ctx, heightValue)
 	if err != nil {
 		return nil, err
 	}
 	params := state.ConsensusParams
 	return &ctypes.ResultConsensusParams{
-		BlockHeight: int64(c.normalizeHeight(height)), //nolint:gosec
+		BlockHeight: int64(heightValue), //nolint:gosec
 		ConsensusParams: cmtypes.ConsensusParams{
 			Block: cmtypes.BlockParams{
 				MaxBytes: params.Block.MaxBytes,
@@ -523,48 +560,64 @@ func (c *FullClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultC
 // Validators returns paginated list of validators at given height.
 func (c *FullClient) Validators(ctx context.Context, heightPtr *int64, pagePtr, perPagePtr *int) (*ctypes.ResultValidators, error) {
 	height := c.normalizeHeight(heightPtr)
-	genesisValidators := c.node.GetGenesis().Validators
-
-	if len(genesisValidators) != 1 {
-		return nil, errors.New("there should be exactly one validator in genesis")
+	state, err := c.getStateForHeight(ctx, height)
+	if err != nil {
+		return nil, err
 	}
-	// Since it's a centralized sequencer
-	// changed behavior to get this from genesis
-	genesisValidator := genesisValidators[0]
-	validator := cmtypes.Validator{
-		Address:          genesisValidator.Address,
-		PubKey:           genesisValidator.PubKey,
-		VotingPower:      int64(1),
-		ProposerPriority: int64(1),
+	if state.Validators == nil || len(state.Validators.Validators) == 0 {
+		return nil, fmt.Errorf("no validators found for height %d", height)
 	}
 
+	validators := state.Validators.Validators
+	totalCount := len(validators)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	v := validators[skipCount:min(skipCount+perPage, totalCount)]
+
 	return &ctypes.ResultValidators{
 		BlockHeight: int64(height), //nolint:gosec
-		Validators: []*cmtypes.Validator{
-			&validator,
-		},
-		Count: 1,
-		Total: 1,
+		Validators:  v,
+		Count:       len(v),
+		Total:       totalCount,
 	}, nil
 }
 
 // Tx returns detailed information about transaction identified by its hash.
 func (c *FullClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	res, err := c.node.TxIndexer.Get(hash)
+	// transaction locations are indexed by the store, so lookup doesn't depend on (optional) tx indexer
+	height, index, err := c.node.Store.GetTxLocation(ctx, hash)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if res == nil {
+	_, data, err := c.node.Store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(data.Txs) {
+		return nil, fmt.Errorf("invalid index of tx (%X) in block %d: %d", hash, height, index)
+	}
+	responses, err := c.node.Store.GetBlockResponses(ctx, height)
+	if errors.Is(err, ds.ErrNotFound) {
+		// block is not executed yet
 		return nil, fmt.Errorf("tx (%X) not found", hash)
 	}
-
-	height := res.Height
-	index := res.Index
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(responses.TxResults) {
+		return nil, fmt.Errorf("missing result of tx (%X) in block %d", hash, height)
+	}
 
 	var proof cmtypes.TxProof
 	if prove {
-		_, data, _ := c.node.Store.GetBlockData(ctx, uint64(height))
 		blockProof := data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
 		proof = cmtypes.TxProof{
 			RootHash: blockProof.RootHash,
@@ -575,10 +628,10 @@ func (c *FullClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.R
 
 	return &ctypes.ResultTx{
 		Hash:     hash,
-		Height:   height,
+		Height:   int64(height), //nolint:gosec
 		Index:    index,
-		TxResult: res.Result,
-		Tx:       res.Tx,
+		TxResult: *responses.TxResults[index],
+		Tx:       cmtypes.Tx(data.Txs[index]),
 		Proof:    proof,
 	}, nil
 }
@@ -800,8 +853,19 @@ func (c *FullClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
 	return result, nil
 }
 
-// BroadcastEvidence is not yet implemented.
+// BroadcastEvidence verifies evidence, adds it to evidence pool and broadcasts it to other nodes.
+//
+// Only evidence of sequencer double signing (types.DoubleSignEvidence) is supported.
 func (c *FullClient) BroadcastEvidence(ctx context.Context, evidence cmtypes.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
+	if evidence == nil {
+		return nil, errors.New("no evidence was provided")
+	}
+	if err := evidence.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("evidence.ValidateBasic failed: %w", err)
+	}
+	if err := c.node.EvidencePool.BroadcastEvidence(ctx, evidence); err != nil {
+		return nil, fmt.Errorf("failed to add evidence: %w", err)
+	}
 	return &ctypes.ResultBroadcastEvidence{
 		Hash: evidence.Hash(),
 	}, nil
@@ -874,58 +938,6 @@ func (c *FullClient) HeaderByHash(ctx context.Context, hash cmbytes.HexBytes) (*
 	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
 }
 
-func (c *FullClient) eventsRoutine(sub cmtypes.Subscription, subscriber string, q cmpubsub.Query, outc chan<- ctypes.ResultEvent) {
-	defer close(outc)
-	for {
-		select {
-		case msg := <-sub.Out():
-			result := ctypes.ResultEvent{Query: q.String(), Data: msg.Data(), Events: msg.Events()}
-			select {
-			case outc <- result:
-			default:
-				// The default case can happen if the outc chan
-				// is full or if it was initialized incorrectly
-				// with a capacity of 0. Since this function has
-				// no control over re-initializing the outc
-				// chan, we do not block on a capacity of 0.
-				full := cap(outc) != 0
-				c.Logger.Error("wanted to publish ResultEvent, but out channel is full:", full, "result:", result, "query:", result.Query)
-			}
-		case <-sub.Canceled():
-			if sub != nil && errors.Is(sub.Err(), cmpubsub.ErrUnsubscribed) {
-				return
-			}
-
-			c.Logger.Error("subscription was cancelled, resubscribing...", "err", sub.Err(), "query", q.String())
-			sub = c.resubscribe(subscriber, q)
-			if sub == nil { // client was stopped
-				return
-			}
-		case <-c.Quit():
-			return
-		}
-	}
-}
-
-// Try to resubscribe with exponential backoff.
-func (c *FullClient) resubscribe(subscriber string, q cmpubsub.Query) cmtypes.Subscription {
-	attempts := 0
-	for {
-		if !c.IsRunning() {
-			return nil
-		}
-
-		sub, err := c.EventBus.Subscribe(c.node.ctx, subscriber, q)
-		if err == nil {
-			return sub
-		}
-
-		attempts++
-		// 10ms -> 20ms -> 40ms
-		time.Sleep((10 << uint(attempts)) * time.Millisecond)
-	}
-}
-
 func (c *FullClient) appClient() proxy.AppConns {
 	return c.node.AppClient()
 }
@@ -941,6 +953,28 @@ func (c *FullClient) normalizeHeight(height *int64) uint64 {
 	return heightValue
 }
 
+// getStateForHeight returns the state that was used to create and validate block at given height,
+// i.e. the state saved after applying the previous block.
+//
+// Nodes upgraded from versions that didn't keep historical states don't have such entries for
+// old heights; in this case the latest state is returned.
+func (c *FullClient) getStateForHeight(ctx context.Context, height uint64) (types.State, error) {
+	if storeHeight := c.node.Store.Height(); height > storeHeight {
+		return types.State{}, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", height, storeHeight)
+	}
+	if height > 0 {
+		state, err := c.node.Store.GetStateAtHeight(ctx, height-1)
+		if err == nil {
+			return state, nil
+		}
+		if !errors.Is(err, ds.ErrNotFound) {
+			return types.State{}, err
+		}
+		c.Logger.Debug("historical state not found, using latest state", "height", height)
+	}
+	return c.node.Store.GetState(ctx)
+}
+
 func (rpc *FullClient) getBlockMeta(ctx context.Context, n uint64) *cmtypes.BlockMeta {
 	header, data, err := rpc.node.Store.GetBlockData(ctx, n)
 	if err != nil {
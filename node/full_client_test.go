@@ -19,6 +19,7 @@ import (
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/p2p"
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/proxy"
 	cmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
@@ -28,6 +29,7 @@ import (
 	"github.com/cometbft/cometbft/light"
 
 	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/state/txindex/null"
 	test "github.com/rollkit/rollkit/test/log"
 	"github.com/rollkit/rollkit/test/mocks"
 	"github.com/rollkit/rollkit/types"
@@ -56,6 +58,13 @@ func getBlockMeta(rpc *FullClient, n int64) *cmtypes.BlockMeta {
 }
 
 func getRPC(t *testing.T, chainID string) (*mocks.Application, *FullClient) {
+	t.Helper()
+	app, rpc, _ := getRPCWithKey(t, chainID)
+	return app, rpc
+}
+
+// getRPCWithKey returns client of a node that wasn't started, and the signing key of the sequencer.
+func getRPCWithKey(t *testing.T, chainID string) (*mocks.Application, *FullClient, cmcrypto.PrivKey) {
 	t.Helper()
 	require := require.New(t)
 	app := &mocks.Application{}
@@ -85,7 +94,7 @@ func getRPC(t *testing.T, chainID string) (*mocks.Application, *FullClient) {
 	rpc := NewFullClient(node)
 	require.NotNil(rpc)
 
-	return app, rpc
+	return app, rpc, genesisValidatorKey
 }
 
 // From state/indexer/block/kv/kv_test
@@ -594,6 +603,14 @@ func TestTx(t *testing.T) {
 	assert.EqualValues(tx1, resTx.Tx)
 	assert.EqualValues(res.Hash, resTx.Hash)
 
+	// lookup by hash doesn't depend on tx indexer
+	rpc.node.TxIndexer = &null.TxIndex{}
+	resTx, errTx = rpc.Tx(ctx, res.Hash, true)
+	require.NoError(errTx)
+	assert.EqualValues(tx1, resTx.Tx)
+	assert.EqualValues(tx1, resTx.Proof.Data)
+	assert.NoError(resTx.Proof.Validate(resTx.Proof.RootHash))
+
 	tx2 := cmtypes.Tx("tx2")
 	resTx, errTx = rpc.Tx(ctx, tx2.Hash(), true)
 	assert.Nil(resTx)
@@ -1118,3 +1135,302 @@ func TestNetInfo(t *testing.T) {
 	assert.True(netInfo.Listening)
 	assert.Equal(0, len(netInfo.Peers))
 }
+
+func TestHistoricalConsensusParamsAndValidators(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t, "TestHistoricalConsensusParamsAndValidators")
+	ctx := context.Background()
+
+	valSet1 := types.GetRandomValidatorSet()
+	valSet2 := types.GetRandomValidatorSet()
+	state := func(height uint64, maxBytes int64, valSet *cmtypes.ValidatorSet) types.State {
+		return types.State{
+			LastBlockHeight: height,
+			ConsensusParams: cmproto.ConsensusParams{
+				Block:     &cmproto.BlockParams{MaxBytes: maxBytes, MaxGas: -1},
+				Evidence:  &cmproto.EvidenceParams{},
+				Validator: &cmproto.ValidatorParams{},
+				Version:   &cmproto.VersionParams{},
+			},
+			Validators:     valSet,
+			NextValidators: valSet,
+			LastValidators: valSet,
+		}
+	}
+	// params and validators saved after block H are used for block H+1
+	require.NoError(rpc.node.Store.UpdateState(ctx, state(0, 1000, valSet1)))
+	require.NoError(rpc.node.Store.UpdateState(ctx, state(1, 2000, valSet2)))
+	rpc.node.Store.SetHeight(ctx, 2)
+
+	height1, height3 := int64(1), int64(3)
+	params, err := rpc.ConsensusParams(ctx, &height1)
+	require.NoError(err)
+	assert.Equal(int64(1), params.BlockHeight)
+	assert.Equal(int64(1000), params.ConsensusParams.Block.MaxBytes)
+
+	params, err = rpc.ConsensusParams(ctx, nil)
+	require.NoError(err)
+	assert.Equal(int64(2), params.BlockHeight)
+	assert.Equal(int64(2000), params.ConsensusParams.Block.MaxBytes)
+
+	vals, err := rpc.Validators(ctx, &height1, nil, nil)
+	require.NoError(err)
+	assert.Equal(valSet1.Validators[0].Address, vals.Validators[0].Address)
+
+	vals, err = rpc.Validators(ctx, nil, nil, nil)
+	require.NoError(err)
+	assert.Equal(valSet2.Validators[0].Address, vals.Validators[0].Address)
+	assert.Equal(1, vals.Total)
+
+	_, err = rpc.ConsensusParams(ctx, &height3)
+	assert.Error(err)
+}
+
+func TestRollkitInfo(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	_, rpc := getRPC(t, "TestRollkitInfo")
+
+	mode, err := rpc.NodeMode(ctx)
+	require.NoError(err)
+	assert.False(mode.Aggregator)
+	assert.True(mode.Proposer)
+	assert.Equal(MockSequencerAddress, mode.SequencerAddress)
+
+	// blocks in store are pending until submitted to DA
+	rpc.node.Store.SetHeight(ctx, 3)
+	pending, err := rpc.PendingHeaders(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(3), pending.Count)
+	assert.Equal(uint64(0), pending.LastSubmittedHeight)
+
+	syncStatus, err := rpc.SyncStatus(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(3), syncStatus.StoreHeight)
+	assert.Equal(uint64(0), syncStatus.HeaderStoreHeight)
+	assert.Equal(uint64(0), syncStatus.DataStoreHeight)
+
+	daStatus, err := rpc.DAStatus(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(0), daStatus.DAIncludedHeight)
+	assert.Equal(rpc.node.blockManager.GetDAHeight(), daStatus.DAHeight)
+}
+
+func TestSubscriptionOverflow(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	_, rpc := getRPC(t, "TestSubscriptionOverflow")
+
+	const capacity = 2
+	sub, err := rpc.Subscribe(ctx, "subscriber", cmtypes.EventQueryNewBlock.String(), capacity)
+	require.NoError(err)
+
+	// subscriber doesn't consume events
+	for h := int64(1); h <= 5; h++ {
+		block := cmtypes.MakeBlock(h, nil, &cmtypes.Commit{}, nil)
+		require.NoError(rpc.EventBus.PublishEventNewBlock(cmtypes.EventDataNewBlock{Block: block}))
+	}
+	// events are published asynchronously; subscription is terminated once they overflow
+	require.Eventually(func() bool {
+		return rpc.EventBus.NumClientSubscriptions("subscriber") == 0
+	}, time.Second, 10*time.Millisecond)
+
+	var lastHeight int64
+	var terminated *EventDataSubscriptionTerminated
+	for e := range sub {
+		switch data := e.Data.(type) {
+		case cmtypes.EventDataNewBlock:
+			require.Nil(terminated, "event delivered after termination")
+			assert.Equal(lastHeight+1, data.Block.Height)
+			lastHeight = data.Block.Height
+		case EventDataSubscriptionTerminated:
+			terminated = &data
+		}
+	}
+	// channel is closed after termination event
+	require.NotNil(terminated)
+	assert.Equal(ErrSubscriptionOverflow.Error(), terminated.Reason)
+	assert.Equal(lastHeight, terminated.LastHeight)
+	assert.LessOrEqual(lastHeight, int64(capacity))
+}
+
+func TestSubscribeFromHeight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	chainID := "TestSubscribeFromHeight"
+	_, rpc := getRPC(t, chainID)
+
+	const nTxs = 2
+	for h := uint64(1); h <= 3; h++ {
+		header, data := types.GetRandomBlock(h, nTxs, chainID)
+		require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		txResults := make([]*abci.ExecTxResult, nTxs)
+		for i := range txResults {
+			txResults[i] = &abci.ExecTxResult{Code: abci.CodeTypeOK}
+		}
+		require.NoError(rpc.node.Store.SaveBlockResponses(ctx, h, &abci.ResponseFinalizeBlock{TxResults: txResults}))
+	}
+	rpc.node.Store.SetHeight(ctx, 3)
+
+	_, err := rpc.SubscribeFromHeight(ctx, "subscriber", cmtypes.EventQueryTx.String(), 0)
+	assert.Error(err)
+
+	sub, err := rpc.SubscribeFromHeight(ctx, "subscriber", cmtypes.EventQueryTx.String(), 2, 1)
+	require.NoError(err)
+
+	// stored events are replayed even if they don't fit into the buffer
+	for h := int64(2); h <= 3; h++ {
+		for i := uint32(0); i < nTxs; i++ {
+			e := <-sub
+			data, ok := e.Data.(cmtypes.EventDataTx)
+			require.True(ok)
+			assert.Equal(h, data.Height)
+			assert.Equal(i, data.Index)
+			assert.Equal(fmt.Sprintf("%d", h), e.Events[cmtypes.TxHeightKey][0])
+		}
+	}
+
+	// already replayed events are not delivered again
+	for _, h := range []int64{3, 4} {
+		// indexer service expects block events before tx events
+		require.NoError(rpc.EventBus.PublishEventNewBlockEvents(cmtypes.EventDataNewBlockEvents{Height: h, NumTxs: 1}))
+		require.NoError(rpc.EventBus.PublishEventTx(cmtypes.EventDataTx{TxResult: abci.TxResult{Height: h, Tx: []byte("tx")}}))
+	}
+	select {
+	case e := <-sub:
+		data, ok := e.Data.(cmtypes.EventDataTx)
+		require.True(ok)
+		assert.Equal(int64(4), data.Height)
+	case <-time.After(time.Second):
+		t.Fatal("new event was not delivered")
+	}
+}
+
+func TestSubscribeFromHeightWhileProducingBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	chainID := "TestSubscribeFromHeightWhileProducingBlocks"
+	_, rpc := getRPC(t, chainID)
+
+	const nTxs = 2
+	// storeBlock stores the block the same way as block manager: events are published before the block is stored
+	storeBlock := func(h uint64, publish bool) {
+		header, data := types.GetRandomBlock(h, nTxs, chainID)
+		txResults := make([]*abci.ExecTxResult, nTxs)
+		for i := range txResults {
+			txResults[i] = &abci.ExecTxResult{Code: abci.CodeTypeOK}
+		}
+		if publish {
+			require.NoError(rpc.EventBus.PublishEventNewBlockEvents(cmtypes.EventDataNewBlockEvents{Height: int64(h), NumTxs: nTxs})) //nolint:gosec
+			for i, tx := range data.Txs {
+				require.NoError(rpc.EventBus.PublishEventTx(cmtypes.EventDataTx{TxResult: abci.TxResult{
+					Height: int64(h), Index: uint32(i), Tx: cmtypes.Tx(tx), Result: *txResults[i], //nolint:gosec
+				}}))
+			}
+		}
+		require.NoError(rpc.node.Store.SaveBlockData(ctx, header, data, &types.Signature{}))
+		require.NoError(rpc.node.Store.SaveBlockResponses(ctx, h, &abci.ResponseFinalizeBlock{TxResults: txResults}))
+		rpc.node.Store.SetHeight(ctx, h)
+	}
+	for h := uint64(1); h <= 3; h++ {
+		storeBlock(h, false)
+	}
+
+	sub, err := rpc.SubscribeFromHeight(ctx, "subscriber", cmtypes.EventQueryTx.String(), 1, 1)
+	require.NoError(err)
+
+	// blocks are produced while subscriber is still consuming replayed events
+	for h := uint64(4); h <= 10; h++ {
+		storeBlock(h, true)
+	}
+	// the last block is being committed, it's not stored yet
+	require.NoError(rpc.EventBus.PublishEventNewBlockEvents(cmtypes.EventDataNewBlockEvents{Height: 11, NumTxs: 1}))
+	require.NoError(rpc.EventBus.PublishEventTx(cmtypes.EventDataTx{TxResult: abci.TxResult{Height: 11, Tx: []byte("tx")}}))
+	// give event bus time to deliver all events
+	time.Sleep(100 * time.Millisecond)
+
+	for h := int64(1); h <= 11; h++ {
+		n := uint32(nTxs)
+		if h == 11 {
+			n = 1
+		}
+		for i := uint32(0); i < n; i++ {
+			select {
+			case e := <-sub:
+				data, ok := e.Data.(cmtypes.EventDataTx)
+				require.True(ok, "unexpected event %T", e.Data)
+				assert.Equal(h, data.Height)
+				assert.Equal(i, data.Index)
+			case <-time.After(time.Second):
+				t.Fatalf("event at height %d was not delivered", h)
+			}
+		}
+	}
+	assert.Equal(1, rpc.EventBus.NumClientSubscriptions("subscriber"))
+}
+
+func TestBroadcastEvidence(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	chainID := "TestBroadcastEvidence"
+	_, rpc, privKey := getRPCWithKey(t, chainID)
+
+	header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{
+		Height:      1,
+		DataHash:    types.GetRandomBytes(32),
+		PrivKey:     privKey,
+		VotingPower: 1,
+	}, chainID)
+	require.NoError(err)
+	conflicting, err := types.GetConflictingSignedHeader(header, privKey)
+	require.NoError(err)
+	ev, err := types.NewDoubleSignEvidence(header, conflicting)
+	require.NoError(err)
+
+	state, err := types.NewFromGenesisDoc(rpc.node.genesis)
+	require.NoError(err)
+	require.NoError(rpc.node.Store.UpdateState(ctx, state))
+
+	_, err = rpc.BroadcastEvidence(ctx, nil)
+	assert.Error(err)
+
+	res, err := rpc.BroadcastEvidence(ctx, ev)
+	require.NoError(err)
+	assert.Equal(ev.Hash(), res.Hash)
+
+	_, err = rpc.BroadcastEvidence(ctx, ev)
+	assert.Error(err)
+
+	pending, err := rpc.PendingEvidence(ctx)
+	require.NoError(err)
+	require.Len(pending.Evidence, 1)
+	assert.Equal(ev.Hash(), pending.Evidence[0].Hash())
+
+	// evidence of headers not signed by the sequencer is rejected
+	otherKey := ed25519.GenPrivKey()
+	other, err := types.GetConflictingSignedHeader(header, otherKey)
+	require.NoError(err)
+	other.Validators = cmtypes.NewValidatorSet([]*cmtypes.Validator{cmtypes.NewValidator(otherKey.PubKey(), 1)})
+	other.ProposerAddress = otherKey.PubKey().Address()
+	signature, err := types.GetSignature(other.Header, otherKey)
+	require.NoError(err)
+	other.Signature = *signature
+	other2, err := types.GetConflictingSignedHeader(other, otherKey)
+	require.NoError(err)
+	otherEv, err := types.NewDoubleSignEvidence(other, other2)
+	require.NoError(err)
+	_, err = rpc.BroadcastEvidence(ctx, otherEv)
+	assert.Error(err)
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
@@ -161,6 +162,133 @@ func TestTxGossipingAndAggregation(t *testing.T) {
 	}
 }
 
+// TestLightNodeTxRelay ensures that transactions relayed between full and light nodes don't lower
+// reputation of the peers, even though light node doesn't accept any transactions from other peers.
+func TestLightNodeTxRelay(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	keys := make([]crypto.PrivKey, 2)
+	for i := range keys {
+		keys[i], _, _ = crypto.GenerateEd25519Key(rand.Reader)
+	}
+	chainID := "TestLightNodeTxRelay"
+	node, _ := createAndConfigureNode(ctx, 0, true, false, chainID, keys, getBMConfig(), getMockDA(t), t)
+	light, _ := createNode(ctx, 1, false, true, keys, getBMConfig(), chainID, false, t)
+	fullNode, lightNode := node.(*FullNode), light.(*LightNode)
+
+	startNodeWithCleanup(t, fullNode)
+	require.NoError(waitForFirstBlock(fullNode, Header))
+	startNodeWithCleanup(t, lightNode)
+	fullID, lightID := fullNode.p2pClient.Host().ID(), lightNode.P2P.Host().ID()
+
+	// wait until transactions gossiped by light node are delivered to full node
+	sent := 0
+	require.Eventually(func() bool {
+		require.NoError(lightNode.P2P.GossipTx(ctx, []byte(fmt.Sprintf("light-tx-%d", sent))))
+		sent++
+		return fullNode.p2pClient.PeerScore(lightID) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// more transactions than needed to ban the peer, if every transaction lowered its reputation
+	const numTxs = 20
+
+	for i := 0; i < numTxs; i++ {
+		require.NoError(fullNode.p2pClient.GossipTx(ctx, []byte(fmt.Sprintf("full-tx-%d", i))))
+	}
+	require.Never(func() bool {
+		return lightNode.P2P.PeerScore(fullID) < 0
+	}, 2*time.Second, 50*time.Millisecond)
+
+	require.Empty(lightNode.P2P.BannedPeers())
+	require.Empty(fullNode.p2pClient.BannedPeers())
+	require.Len(lightNode.P2P.Peers(), 1)
+}
+
+// TestEvidenceGossiping ensures that evidence broadcasted by a full node reaches the aggregator, which includes
+// it in a block, and that evidence gossiped repeatedly doesn't lower reputation of the peers.
+func TestEvidenceGossiping(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	keys := make([]crypto.PrivKey, 2)
+	for i := range keys {
+		keys[i], _, _ = crypto.GenerateEd25519Key(rand.Reader)
+	}
+	chainID := "TestEvidenceGossiping"
+	dalc := getMockDA(t)
+	node1, _ := createAndConfigureNode(ctx, 0, true, false, chainID, keys, getBMConfig(), dalc, t)
+	node2, _ := createAndConfigureNode(ctx, 1, false, false, chainID, keys, getBMConfig(), dalc, t)
+	aggregator, fullNode := node1.(*FullNode), node2.(*FullNode)
+
+	startNodeWithCleanup(t, aggregator)
+	require.NoError(waitForFirstBlock(aggregator, Header))
+	startNodeWithCleanup(t, fullNode)
+	require.NoError(waitForFirstBlock(fullNode, Header))
+
+	// sequencer signs two different headers at the same (future) height
+	sequencerKey, err := keys[0].Raw()
+	require.NoError(err)
+	privKey := ed25519.PrivKey(sequencerKey)
+	header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{
+		Height:      1000,
+		DataHash:    types.GetRandomBytes(32),
+		PrivKey:     privKey,
+		VotingPower: 1,
+	}, chainID)
+	require.NoError(err)
+	conflicting, err := types.GetConflictingSignedHeader(header, privKey)
+	require.NoError(err)
+	ev, err := types.NewDoubleSignEvidence(header, conflicting)
+	require.NoError(err)
+	evBytes, err := types.MarshalEvidence(ev)
+	require.NoError(err)
+
+	// evidence is either pending, or already included in a block by the aggregator
+	received := func() bool {
+		pending, _ := aggregator.EvidencePool.PendingEvidence(-1)
+		for _, e := range pending {
+			if bytes.Equal(e.Hash(), ev.Hash()) {
+				return true
+			}
+		}
+		for h := uint64(1); h <= aggregator.Store.Height(); h++ {
+			_, data, err := aggregator.Store.GetBlockData(ctx, h)
+			require.NoError(err)
+			for _, e := range data.Evidence.Evidence {
+				if bytes.Equal(e.Hash(), ev.Hash()) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	// evidence is added to the pool of the full node before gossiping; it's re-published until the
+	// aggregator is subscribed to evidence topic
+	require.NoError(fullNode.EvidencePool.BroadcastEvidence(ctx, ev))
+	require.Eventually(func() bool {
+		if received() {
+			return true
+		}
+		require.NoError(fullNode.p2pClient.GossipEvidence(ctx, evBytes))
+		return false
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// already known evidence is ignored, without penalizing the sender
+	for i := 0; i < 20; i++ {
+		require.NoError(fullNode.p2pClient.GossipEvidence(ctx, evBytes))
+	}
+	require.Never(func() bool {
+		return aggregator.p2pClient.PeerScore(fullNode.p2pClient.Host().ID()) < 0
+	}, 2*time.Second, 50*time.Millisecond)
+
+	require.Empty(aggregator.p2pClient.BannedPeers())
+	require.Empty(fullNode.p2pClient.BannedPeers())
+}
+
 func TestLazyAggregator(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
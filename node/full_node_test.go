@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
@@ -16,6 +17,7 @@ import (
 	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/proxy"
 	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,6 +32,7 @@ import (
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
 	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/store"
 	test "github.com/rollkit/rollkit/test/log"
 	"github.com/rollkit/rollkit/test/mocks"
 	"github.com/rollkit/rollkit/types"
@@ -54,6 +57,36 @@ func TestMempoolDirectly(t *testing.T) {
 	verifyMempoolSize(node, t)
 }
 
+func TestMempoolType(t *testing.T) {
+	ctx := context.Background()
+	chainID := "TestMempoolType"
+	genesis, genesisValidatorKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, chainID)
+	signingKey, err := types.PrivKeyToSigningKey(genesisValidatorKey)
+	require.NoError(t, err)
+
+	cases := []struct {
+		mempoolType string
+		expected    mempool.Mempool
+	}{
+		{"", &mempool.CListMempool{}},
+		{mempool.CListMempoolType, &mempool.CListMempool{}},
+		{mempool.PriorityMempoolType, &mempool.PriorityMempool{}},
+		{"unknown", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.mempoolType, func(t *testing.T) {
+			nodeConfig := config.NodeConfig{DAAddress: MockDAAddress, DANamespace: MockDANamespace, MempoolType: c.mempoolType}
+			node, err := newFullNode(ctx, nodeConfig, generateSingleKey(), signingKey, proxy.NewLocalClientCreator(setupMockApplication()), genesis, DefaultMetricsProvider(cmconfig.DefaultInstrumentationConfig()), test.NewFileLogger(t))
+			if c.expected == nil {
+				assert.ErrorContains(t, err, "unknown mempool type")
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, c.expected, node.Mempool)
+		})
+	}
+}
+
 // Tests that the node is able to sync multiple blocks even if blocks arrive out of order
 func TestTrySyncNextBlockMultiple(t *testing.T) {
 	chainID := "TestTrySyncNextBlockMultiple"
@@ -390,6 +423,66 @@ func TestVoteExtension(t *testing.T) {
 	})
 }
 
+func TestVerifyImportedState(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+	chainID := "TestVerifyImportedState"
+
+	genesis, privKey := types.GetGenesisWithPrivkey(types.DefaultSigningKeyType, chainID)
+	genesis.InitialHeight = 1
+	src := store.New(newTestKV(t))
+	header, data, _ := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, NTxs: 1, PrivKey: privKey}, chainID)
+	for height := uint64(1); height <= 2; height++ {
+		if height > 1 {
+			header, data = types.GetRandomNextBlock(header, data, privKey, []byte{1, 2, 3}, 1, chainID)
+		}
+		require.NoError(src.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(src.UpdateState(ctx, types.State{
+			ChainID:         chainID,
+			LastBlockHeight: height,
+			AppHash:         []byte{byte(height)},
+			Validators:      header.Validators,
+			NextValidators:  header.Validators,
+			LastValidators:  header.Validators,
+		}))
+		src.SetHeight(ctx, height)
+	}
+	var archive bytes.Buffer
+	require.NoError(store.Export(ctx, src, &archive, 1, 2, 0))
+
+	newProxyApp := func(info *abci.ResponseInfo) proxy.AppConns {
+		app := &mocks.Application{}
+		app.On("Info", mock.Anything, mock.Anything).Return(info, nil)
+		proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
+		require.NoError(proxyApp.Start())
+		t.Cleanup(func() {
+			_ = proxyApp.Stop()
+		})
+		return proxyApp
+	}
+
+	// store without imported blocks doesn't require app info
+	s := store.New(newTestKV(t))
+	require.NoError(verifyImportedState(ctx, s, newProxyApp(&abci.ResponseInfo{})))
+
+	_, err := store.Import(ctx, s, bytes.NewReader(archive.Bytes()), genesis)
+	require.NoError(err)
+
+	err = verifyImportedState(ctx, s, newProxyApp(&abci.ResponseInfo{}))
+	assert.ErrorIs(err, ErrImportedStateMismatch)
+	err = verifyImportedState(ctx, s, newProxyApp(&abci.ResponseInfo{LastBlockHeight: 2, LastBlockAppHash: []byte{1}}))
+	assert.ErrorIs(err, ErrImportedStateMismatch)
+	require.NoError(verifyImportedState(ctx, s, newProxyApp(&abci.ResponseInfo{LastBlockHeight: 2, LastBlockAppHash: []byte{2}})))
+}
+
+func newTestKV(t *testing.T) ds.TxnDatastore {
+	t.Helper()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	return kv
+}
+
 // Create & configure node with app. Get signing key for mock functions.
 func createNodeAndApp(ctx context.Context, chainID string, voteExtensionEnableHeight int64, signingKeyType string, t *testing.T) (*mocks.Application, Node, cmcrypto.PubKey) {
 	require := require.New(t)
@@ -9,8 +9,10 @@ import (
 	"github.com/cometbft/cometbft/libs/service"
 	proxy "github.com/cometbft/cometbft/proxy"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	cmtypes "github.com/cometbft/cometbft/types"
 	ds "github.com/ipfs/go-datastore"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/crypto"
 
 	"github.com/rollkit/rollkit/block"
@@ -31,6 +33,11 @@ type LightNode struct {
 
 	hSyncService *block.HeaderSyncService
 
+	genesis *cmtypes.GenesisDoc
+
+	// fullNodeRPC is used to query application state; nil if full node RPC is not configured
+	fullNodeRPC rpcclient.ABCIClient
+
 	client rpcclient.Client
 
 	ctx    context.Context
@@ -84,10 +91,20 @@ func newLightNode(
 		return nil, fmt.Errorf("error while initializing HeaderSyncService: %w", err)
 	}
 
+	var fullNodeRPC rpcclient.ABCIClient
+	if conf.FullNodeRPCAddress != "" {
+		fullNodeRPC, err = rpchttp.New(conf.FullNodeRPCAddress, "/websocket")
+		if err != nil {
+			return nil, fmt.Errorf("error while creating full node RPC client: %w", err)
+		}
+	}
+
 	node := &LightNode{
 		P2P:          client,
 		proxyApp:     proxyApp,
 		hSyncService: headerSyncService,
+		genesis:      genesis,
+		fullNodeRPC:  fullNodeRPC,
 		cancel:       cancel,
 		ctx:          ctx,
 	}
@@ -106,7 +123,7 @@ func openDatastore(conf config.NodeConfig, logger log.Logger) (ds.TxnDatastore,
 		logger.Info("WARNING: working in in-memory mode")
 		return store.NewDefaultInMemoryKVStore()
 	}
-	return store.NewDefaultKVStore(conf.RootDir, conf.DBPath, "rollkit-light")
+	return store.NewKVStore(conf.DBBackend, conf.RootDir, conf.DBPath, "rollkit-light")
 }
 
 // Cancel calls the underlying context's cancel function.
@@ -136,9 +153,13 @@ func (ln *LightNode) OnStop() {
 	ln.Logger.Error("errors while stopping node:", "errors", err)
 }
 
-// Dummy validator that always returns a callback function with boolean `false`
+// Dummy validator that ignores all gossiped transactions, except the ones published by light node itself.
+// Transactions are ignored rather than rejected, so that peers relaying them are not penalized.
 func (ln *LightNode) falseValidator() p2p.GossipValidator {
-	return func(*p2p.GossipMessage) bool {
-		return false
+	return func(m *p2p.GossipMessage) pubsub.ValidationResult {
+		if m.From == ln.P2P.Host().ID() {
+			return pubsub.ValidationAccept
+		}
+		return pubsub.ValidationIgnore
 	}
 }
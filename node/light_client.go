@@ -2,191 +2,520 @@ package node
 
 import (
 	"context"
-
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/merkle"
 	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	lrpc "github.com/cometbft/cometbft/light/rpc"
+	corep2p "github.com/cometbft/cometbft/p2p"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+
+	"github.com/celestiaorg/go-header"
+	goheaderstore "github.com/celestiaorg/go-header/store"
+
+	rconfig "github.com/rollkit/rollkit/config"
+	rtypes "github.com/rollkit/rollkit/types"
+	abciconv "github.com/rollkit/rollkit/types/abci"
 )
 
+// ErrNotAvailableInLightNode is returned by methods requiring data that light node doesn't have,
+// e.g. blocks, transactions, mempool or application state.
+var ErrNotAvailableInLightNode = errors.New("not available in light node")
+
+// ErrHeaderNotSynced is returned when data can't be verified, because light node hasn't synced
+// the required header yet.
+var ErrHeaderNotSynced = errors.New("header not yet synced")
+
 var _ rpcclient.Client = &LightClient{}
 
-// LightClient is a Client interface for the LightNode
+// LightClient is a Client interface for the LightNode.
+//
+// Light node has only headers, verified by header sync service; methods requiring other data return
+// ErrNotAvailableInLightNode.
 type LightClient struct {
 	types.EventBus
 	node *LightNode
+
+	prt       *merkle.ProofRuntime
+	keyPathFn lrpc.KeyPathFunc
 }
 
 // NewLightClient returns a new LightClient for the LightNode
 func NewLightClient(node *LightNode) *LightClient {
 	return &LightClient{
-		node: node,
+		node:      node,
+		prt:       merkle.DefaultProofRuntime(),
+		keyPathFn: lrpc.DefaultMerkleKeyPathFn(),
 	}
 }
 
 // ABCIInfo returns basic information about application state.
 func (c *LightClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // ABCIQuery queries for data from application.
 func (c *LightClient) ABCIQuery(ctx context.Context, path string, data cmbytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
-	panic("Not implemented")
+	return c.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
 }
 
 // ABCIQueryWithOptions queries for data from application.
+//
+// Query is forwarded to configured full node (always requesting proofs). Returned merkle proofs are verified
+// against AppHash of the verified header, so the full node doesn't have to be trusted. State at height H
+// is committed in header H+1, so if the light node hasn't synced header H+1 yet, the latest state is queried
+// at the highest height verifiable with synced headers, and query at explicit height returns ErrHeaderNotSynced.
 func (c *LightClient) ABCIQueryWithOptions(ctx context.Context, path string, data cmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
-	panic("Not implemented")
+	if c.node.fullNodeRPC == nil {
+		return nil, fmt.Errorf("%w: full node RPC address is not configured", ErrNotAvailableInLightNode)
+	}
+
+	headers := c.node.hSyncService.Store()
+	resp, err := queryVerifiable(ctx, c.node.fullNodeRPC, headers.Height(), path, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	header, err := headers.GetByHeight(ctx, uint64(resp.Height)+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header at height %d: %w", resp.Height+1, err)
+	}
+	if err := verifyABCIQuery(c.prt, c.keyPathFn, path, resp, header.AppHash); err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultABCIQuery{Response: resp}, nil
+}
+
+// queryVerifiable forwards query to full node, making sure that the state in response is committed in
+// header that is already synced (up to syncedHeight). Query for the latest state is retried at the
+// highest verifiable height if needed.
+func queryVerifiable(ctx context.Context, client rpcclient.ABCIClient, syncedHeight uint64, path string, data cmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (abci.ResponseQuery, error) {
+	opts.Prove = true
+	res, err := client.ABCIQueryWithOptions(ctx, path, data, opts)
+	if err != nil {
+		return abci.ResponseQuery{}, err
+	}
+	resp := res.Response
+	if resp.IsErr() {
+		return abci.ResponseQuery{}, fmt.Errorf("error response code: %d, log: %s", resp.Code, resp.Log)
+	}
+	if resp.Height <= 0 {
+		return abci.ResponseQuery{}, fmt.Errorf("invalid query response height: %d", resp.Height)
+	}
+	if uint64(resp.Height) < syncedHeight {
+		return resp, nil
+	}
+	if opts.Height != 0 || syncedHeight < 2 {
+		return abci.ResponseQuery{}, fmt.Errorf("%w: state at height %d is committed in header %d, synced height is %d",
+			ErrHeaderNotSynced, resp.Height, resp.Height+1, syncedHeight)
+	}
+	opts.Height = int64(syncedHeight) - 1 //nolint:gosec
+	return queryVerifiable(ctx, client, syncedHeight, path, data, opts)
 }
 
-// BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
-// More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit
+// RegisterOpDecoder registers decoder of proof operations of given type, used to verify ABCI query results.
+// By default, only proof operations defined in CometBFT are supported.
+func (c *LightClient) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
+	c.prt.RegisterOpDecoder(typ, dec)
+}
+
+// BroadcastTxCommit always returns error, as light node can't observe execution of transactions.
 func (c *LightClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
-// BroadcastTxAsync returns right away, with no response. Does not wait for
-// CheckTx nor DeliverTx results.
+// BroadcastTxAsync gossips the transaction to the P2P network, and returns right away.
+// Does not wait for CheckTx nor DeliverTx results.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_async
 func (c *LightClient) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	panic("Not implemented")
+	if err := c.node.P2P.GossipTx(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to gossip tx: %w", err)
+	}
+	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
 }
 
-// BroadcastTxSync returns with the response from CheckTx. Does not wait for
-// DeliverTx result.
+// BroadcastTxSync gossips the transaction to the P2P network.
+//
+// Light node doesn't have a mempool, so the transaction is checked only by full nodes receiving it,
+// and the result contains only hash of the transaction.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_sync
 func (c *LightClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	panic("Not implemented")
+	return c.BroadcastTxAsync(ctx, tx)
 }
 
 // Subscribe subscribe given subscriber to a query.
 func (c *LightClient) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // Unsubscribe unsubscribes given subscriber from a query.
 func (c *LightClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
-	panic("Not implemented")
+	return ErrNotAvailableInLightNode
 }
 
 // Genesis returns entire genesis.
 func (c *LightClient) Genesis(_ context.Context) (*ctypes.ResultGenesis, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // GenesisChunked returns given chunk of genesis.
 func (c *LightClient) GenesisChunked(context context.Context, id uint) (*ctypes.ResultGenesisChunk, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // BlockchainInfo returns ABCI block meta information for given height range.
+//
+// Light node doesn't have block data, so BlockSize and NumTxs of returned block metas are not set.
 func (c *LightClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
-	panic("Not implemented")
+	const limit int64 = 20
+
+	store := c.node.hSyncService.Store()
+	height := store.Height()
+	base, err := headerStoreTail(ctx, store, uint64(c.node.genesis.InitialHeight)) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	minHeight, maxHeight, err = filterMinMax(
+		int64(base),   //nolint:gosec
+		int64(height), //nolint:gosec
+		minHeight,
+		maxHeight,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*types.BlockMeta, 0, maxHeight-minHeight+1)
+	for h := maxHeight; h >= minHeight && h > 0; h-- {
+		header, err := store.GetByHeight(ctx, uint64(h))
+		if err != nil {
+			return nil, err
+		}
+		blockMeta, err := lightBlockMeta(header)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, blockMeta)
+	}
+
+	return &ctypes.ResultBlockchainInfo{
+		LastHeight: int64(height), //nolint:gosec
+		BlockMetas: blocks,
+	}, nil
 }
 
 // NetInfo returns basic information about client P2P connections.
 func (c *LightClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
-	panic("Not implemented")
+	return p2pNetInfo(c.node.P2P), nil
 }
 
 // DumpConsensusState always returns error as there is no consensus state in Rollkit.
 func (c *LightClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
-	panic("Not implemented")
+	return nil, ErrConsensusStateNotAvailable
 }
 
 // ConsensusState always returns error as there is no consensus state in Rollkit.
 func (c *LightClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
-	panic("Not implemented")
+	return nil, ErrConsensusStateNotAvailable
 }
 
 // ConsensusParams returns consensus params at given height.
-//
-// Currently, consensus params changes are not supported and this method returns params as defined in genesis.
 func (c *LightClient) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // Health endpoint returns empty value. It can be used to monitor service availability.
 func (c *LightClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
-	panic("Not implemented")
+	return &ctypes.ResultHealth{}, nil
 }
 
 // Block method returns BlockID and block itself for given height.
-//
-// If height is nil, it returns information about last known block.
 func (c *LightClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // BlockByHash returns BlockID and block itself for given hash.
 func (c *LightClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // BlockResults returns information about transactions, events and updates of validator set and consensus params.
 func (c *LightClient) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // Commit returns signed header (aka commit) at given height.
+//
+// If height is nil, it returns commit of the latest verified header.
 func (c *LightClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	panic("Not implemented")
+	header, err := c.getHeader(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// we should have a single validator
+	if len(header.Validators.Validators) == 0 {
+		return nil, errors.New("empty validator set found in header")
+	}
+
+	val := header.Validators.Validators[0].Address
+	commit := rtypes.GetABCICommit(header.Height(), header.Hash(), val, header.Time(), header.Signature)
+
+	block, err := abciconv.ToABCIBlock(header, &rtypes.Data{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ctypes.NewResultCommit(&block.Header, commit, true), nil
 }
 
 // Validators returns paginated list of validators at given height.
 func (c *LightClient) Validators(ctx context.Context, heightPtr *int64, pagePtr, perPagePtr *int) (*ctypes.ResultValidators, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // Tx returns detailed information about transaction identified by its hash.
 func (c *LightClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // TxSearch returns detailed information about transactions matching query.
 func (c *LightClient) TxSearch(ctx context.Context, query string, prove bool, pagePtr, perPagePtr *int, orderBy string) (*ctypes.ResultTxSearch, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // BlockSearch defines a method to search for a paginated set of blocks by
 // BeginBlock and EndBlock event search criteria.
 func (c *LightClient) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (*ctypes.ResultBlockSearch, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
-// Status returns detailed information about current status of the node.
+// Status returns detailed information about current status of the node, based on verified headers.
 func (c *LightClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
-	panic("Not implemented")
-}
+	var (
+		latestBlockHash cmbytes.HexBytes
+		latestAppHash   cmbytes.HexBytes
+		latestBlockTime time.Time
+		blockVersion    = version.BlockProtocol
+		appVersion      uint64
+
+		earliestBlockHash   cmbytes.HexBytes
+		earliestAppHash     cmbytes.HexBytes
+		earliestBlockHeight int64
+		earliestBlockTime   time.Time
+
+		store        = c.node.hSyncService.Store()
+		latestHeight = store.Height()
+	)
+
+	if latestHeight != 0 {
+		head, err := store.GetByHeight(ctx, latestHeight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find latest header: %w", err)
+		}
+		latestBlockHash = cmbytes.HexBytes(head.DataHash)
+		latestAppHash = cmbytes.HexBytes(head.AppHash)
+		latestBlockTime = head.Time()
+		blockVersion = head.Version.Block
+		appVersion = head.Version.App
+
+		// store of light node started with trusted hash doesn't contain initial header
+		initial, err := store.GetByHeight(ctx, uint64(c.node.genesis.InitialHeight)) //nolint:gosec
+		switch {
+		case err == nil:
+			earliestBlockHash = cmbytes.HexBytes(initial.DataHash)
+			earliestAppHash = cmbytes.HexBytes(initial.AppHash)
+			earliestBlockHeight = int64(initial.Height()) //nolint:gosec
+			earliestBlockTime = initial.Time()
+		case !errors.Is(err, header.ErrNotFound):
+			return nil, fmt.Errorf("failed to find earliest header: %w", err)
+		}
+	}
+
+	genesisValidators := c.node.genesis.Validators
+	if len(genesisValidators) != 1 {
+		return nil, errors.New("there should be exactly one validator in genesis")
+	}
+	genesisValidator := genesisValidators[0]
+
+	id, addr, network, err := c.node.P2P.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node p2p2 info: %w", err)
+	}
 
-// BroadcastEvidence is not yet implemented.
+	return &ctypes.ResultStatus{
+		NodeInfo: corep2p.DefaultNodeInfo{
+			ProtocolVersion: corep2p.NewProtocolVersion(version.P2PProtocol, blockVersion, appVersion),
+			DefaultNodeID:   id,
+			ListenAddr:      addr,
+			Network:         network,
+			Version:         rconfig.Version,
+			Moniker:         config.DefaultBaseConfig().Moniker,
+			Other: corep2p.DefaultNodeInfoOther{
+				TxIndex: "off",
+			},
+		},
+		SyncInfo: ctypes.SyncInfo{
+			LatestBlockHash:     latestBlockHash,
+			LatestAppHash:       latestAppHash,
+			LatestBlockHeight:   int64(latestHeight), //nolint:gosec
+			LatestBlockTime:     latestBlockTime,
+			EarliestBlockHash:   earliestBlockHash,
+			EarliestAppHash:     earliestAppHash,
+			EarliestBlockHeight: earliestBlockHeight,
+			EarliestBlockTime:   earliestBlockTime,
+			CatchingUp:          false,
+		},
+		ValidatorInfo: ctypes.ValidatorInfo{
+			Address:     genesisValidator.Address,
+			PubKey:      genesisValidator.PubKey,
+			VotingPower: 1,
+		},
+	}, nil
+}
+
+// BroadcastEvidence is not supported by light node.
 func (c *LightClient) BroadcastEvidence(ctx context.Context, evidence types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // NumUnconfirmedTxs returns information about transactions in mempool.
 func (c *LightClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // UnconfirmedTxs returns transactions in mempool.
 func (c *LightClient) UnconfirmedTxs(ctx context.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxs, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
 // CheckTx executes a new transaction against the application to determine its validity.
-//
-// If valid, the tx is automatically added to the mempool.
 func (c *LightClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
-	panic("Not implemented")
+	return nil, ErrNotAvailableInLightNode
 }
 
-// Header satisfies the client interface but is not implemented
+// Header returns verified header at given height.
+//
+// If height is nil, it returns the latest verified header.
 func (c *LightClient) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
-	panic("Not implemented")
+	header, err := c.getHeader(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	blockMeta, err := lightBlockMeta(header)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
 }
 
-// HeaderByHash satisfies the client interface but is not implemented
+// HeaderByHash returns verified header with given hash.
 func (c *LightClient) HeaderByHash(ctx context.Context, hash cmbytes.HexBytes) (*ctypes.ResultHeader, error) {
-	panic("Not implemented")
+	header, err := c.node.hSyncService.Store().Get(ctx, header.Hash(hash))
+	if err != nil {
+		return nil, err
+	}
+	blockMeta, err := lightBlockMeta(header)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
+}
+
+// getHeader returns verified header at given height, or the latest header if height is nil.
+func (c *LightClient) getHeader(ctx context.Context, height *int64) (*rtypes.SignedHeader, error) {
+	store := c.node.hSyncService.Store()
+	heightValue := store.Height()
+	if height != nil {
+		if *height <= 0 {
+			return nil, fmt.Errorf("height must be greater than 0, but got %d", *height)
+		}
+		heightValue = uint64(*height)
+	}
+	if heightValue == 0 {
+		return nil, errors.New("no verified headers available yet")
+	}
+	// store waits for headers above its head, so they are rejected explicitly
+	if heightValue > store.Height() {
+		return nil, fmt.Errorf("height %d is not available, latest height is %d", heightValue, store.Height())
+	}
+	header, err := store.GetByHeight(ctx, heightValue)
+	if err != nil {
+		return nil, fmt.Errorf("header at height %d not found: %w", heightValue, err)
+	}
+	return header, nil
+}
+
+// lightBlockMeta converts header to block meta. Light node doesn't have block data, so BlockSize
+// and NumTxs are not set.
+func lightBlockMeta(header *rtypes.SignedHeader) (*types.BlockMeta, error) {
+	blockMeta, err := abciconv.ToABCIBlockMeta(header, &rtypes.Data{})
+	if err != nil {
+		return nil, err
+	}
+	blockMeta.BlockSize = 0
+	blockMeta.NumTxs = 0
+	return blockMeta, nil
+}
+
+// verifyABCIQuery verifies merkle proofs of ABCI query response against given AppHash.
+// Values are verified with proofs of existence, empty results with proofs of absence.
+func verifyABCIQuery(prt *merkle.ProofRuntime, keyPathFn lrpc.KeyPathFunc, path string, resp abci.ResponseQuery, appHash []byte) error {
+	if len(resp.Key) == 0 {
+		return errors.New("empty key in query response")
+	}
+	if resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0 {
+		return errors.New("no proof ops in query response")
+	}
+
+	if resp.Value != nil {
+		kp, err := keyPathFn(path, resp.Key)
+		if err != nil {
+			return fmt.Errorf("can't build merkle key path: %w", err)
+		}
+		if err := prt.VerifyValue(resp.ProofOps, appHash, kp.String(), resp.Value); err != nil {
+			return fmt.Errorf("verify value proof: %w", err)
+		}
+		return nil
+	}
+
+	if err := prt.VerifyAbsence(resp.ProofOps, appHash, string(resp.Key)); err != nil {
+		return fmt.Errorf("verify absence proof: %w", err)
+	}
+	return nil
+}
+
+// headerStoreTail returns height of the lowest header in store, or 0 if store is empty.
+//
+// Store of light node started with trusted hash doesn't contain headers below the trusted one. Headers
+// above the lowest one are contiguous, so the lowest header is found with binary search.
+func headerStoreTail(ctx context.Context, store *goheaderstore.Store[*rtypes.SignedHeader], initialHeight uint64) (uint64, error) {
+	height := store.Height()
+	if height == 0 {
+		return 0, nil
+	}
+	initialHeight = max(initialHeight, 1)
+	if initialHeight > height {
+		return height, nil
+	}
+	var searchErr error
+	i := sort.Search(int(height-initialHeight), func(i int) bool { //nolint:gosec
+		_, err := store.GetByHeight(ctx, initialHeight+uint64(i)) //nolint:gosec
+		if err != nil && !errors.Is(err, header.ErrNotFound) {
+			searchErr = err
+		}
+		return err == nil
+	})
+	if searchErr != nil {
+		return 0, fmt.Errorf("failed to find lowest header: %w", searchErr)
+	}
+	return initialHeight + uint64(i), nil //nolint:gosec
 }
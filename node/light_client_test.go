@@ -2,219 +2,299 @@ package node
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
 	"testing"
+	"time"
 
+	goheaderstore "github.com/celestiaorg/go-header/store"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
 )
 
-// TestLightClient_Panics tests that all methods of LightClient and ensures that
-// they panic as they are not implemented. This is to ensure that when the
-// methods are implemented in the future we don't forget to add testing. When
-// methods are implemented, they should be removed from this test and have their
-// own test written.
-func TestLightClient_Panics(t *testing.T) {
+// TestLightClient_NotAvailable tests that methods requiring data not available in light node
+// return errors instead of panicking.
+func TestLightClient_NotAvailable(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	ln := initAndStartNodeWithCleanup(ctx, t, Light, "TestLightClient_Panics")
+	ln := initAndStartNodeWithCleanup(ctx, t, Light, "TestLightClient_NotAvailable")
 	require.IsType(t, new(LightNode), ln)
+	client := ln.GetClient()
 
 	tests := []struct {
 		name string
-		fn   func()
+		fn   func() error
 	}{
-		{
-			name: "ABCIInfo",
-			fn: func() {
-				_, _ = ln.GetClient().ABCIInfo(ctx)
-			},
-		},
-		{
-			name: "ABCIQuery",
-			fn: func() {
-				_, _ = ln.GetClient().ABCIQuery(ctx, "", nil)
-			},
-		},
-		{
-			name: "ABCIQueryWithOptions",
-			fn: func() {
-				_, _ = ln.GetClient().ABCIQueryWithOptions(ctx, "", nil, rpcclient.ABCIQueryOptions{})
-			},
-		},
-		{
-			name: "BroadcastTxCommit",
-			fn: func() {
-				_, _ = ln.GetClient().BroadcastTxSync(ctx, []byte{})
-			},
-		},
-		{
-			name: "BroadcastTxAsync",
-			fn: func() {
-				_, _ = ln.GetClient().BroadcastTxSync(ctx, []byte{})
-			},
-		},
-		{
-			name: "BroadcastTxSync",
-			fn: func() {
-				_, _ = ln.GetClient().BroadcastTxSync(ctx, []byte{})
-			},
-		},
-		{
-			name: "Subscribe",
-			fn: func() {
-				_, _ = ln.GetClient().Subscribe(ctx, "", "", 0)
-			},
-		},
-		{
-			name: "Block",
-			fn: func() {
-				_, _ = ln.GetClient().Block(ctx, nil)
-			},
-		},
-		{
-			name: "BlockByHash",
-			fn: func() {
-				_, _ = ln.GetClient().BlockByHash(ctx, []byte{})
-			},
-		},
-		{
-			name: "BlockResults",
-			fn: func() {
-				_, _ = ln.GetClient().BlockResults(ctx, nil)
-			},
-		},
-		{
-			name: "BlockSearch",
-			fn: func() {
-				_, _ = ln.GetClient().BlockSearch(ctx, "", nil, nil, "")
-			},
-		},
-		{
-			name: "BlockchainInfo",
-			fn: func() {
-				_, _ = ln.GetClient().BlockchainInfo(ctx, 0, 0)
-			},
-		},
-		{
-			name: "BroadcastEvidence",
-			fn: func() {
-				_, _ = ln.GetClient().BroadcastEvidence(ctx, nil)
-			},
-		},
-		{
-			name: "CheckTx",
-			fn: func() {
-				_, _ = ln.GetClient().CheckTx(ctx, []byte{})
-			},
-		},
-		{
-			name: "Commit",
-			fn: func() {
-				_, _ = ln.GetClient().Commit(ctx, nil)
-			},
-		},
-		{
-			name: "ConsensusParams",
-			fn: func() {
-				_, _ = ln.GetClient().ConsensusParams(ctx, nil)
-			},
-		},
-		{
-			name: "ConsensusState",
-			fn: func() {
-				_, _ = ln.GetClient().ConsensusState(ctx)
-			},
-		},
-		{
-			name: "DumpConsensusState",
-			fn: func() {
-				_, _ = ln.GetClient().DumpConsensusState(ctx)
-			},
-		},
-		{
-			name: "Genesis",
-			fn: func() {
-				_, _ = ln.GetClient().Genesis(ctx)
-			},
-		},
-		{
-			name: "GenesisChunked",
-			fn: func() {
-				_, _ = ln.GetClient().GenesisChunked(ctx, 0)
-			},
-		},
-		{
-			name: "Header",
-			fn: func() {
-				_, _ = ln.GetClient().Header(ctx, nil)
-			},
-		},
-		{
-			name: "HeaderByHash",
-			fn: func() {
-				_, _ = ln.GetClient().HeaderByHash(ctx, []byte{})
-			},
-		},
-		{
-			name: "Health",
-			fn: func() {
-				_, _ = ln.GetClient().Health(ctx)
-			},
-		},
-		{
-			name: "NetInfo",
-			fn: func() {
-				_, _ = ln.GetClient().NetInfo(ctx)
-			},
-		},
-		{
-			name: "NumUnconfirmedTxs",
-			fn: func() {
-				_, _ = ln.GetClient().NumUnconfirmedTxs(ctx)
-			},
-		},
-		{
-			name: "Status",
-			fn: func() {
-				_, _ = ln.GetClient().Status(ctx)
-			},
-		},
-		{
-			name: "Tx",
-			fn: func() {
-				_, _ = ln.GetClient().Tx(ctx, []byte{}, false)
-			},
-		},
-		{
-			name: "TxSearch",
-			fn: func() {
-				_, _ = ln.GetClient().TxSearch(ctx, "", false, nil, nil, "")
-			},
-		},
-		{
-			name: "UnconfirmedTxs",
-			fn: func() {
-				_, _ = ln.GetClient().UnconfirmedTxs(ctx, nil)
-			},
-		},
-		{
-			name: "Unsubscribe",
-			fn: func() {
-				_ = ln.GetClient().Unsubscribe(ctx, "", "")
-			},
-		},
-		{
-			name: "Validators",
-			fn: func() {
-				_, _ = ln.GetClient().Validators(ctx, nil, nil, nil)
-			},
-		},
+		{"ABCIInfo", func() error { _, err := client.ABCIInfo(ctx); return err }},
+		{"ABCIQuery", func() error { _, err := client.ABCIQuery(ctx, "", nil); return err }},
+		{"ABCIQueryWithOptions", func() error {
+			_, err := client.ABCIQueryWithOptions(ctx, "", nil, rpcclient.ABCIQueryOptions{})
+			return err
+		}},
+		{"BroadcastTxCommit", func() error { _, err := client.BroadcastTxCommit(ctx, []byte{}); return err }},
+		{"Subscribe", func() error { _, err := client.Subscribe(ctx, "", "", 0); return err }},
+		{"Unsubscribe", func() error { return client.Unsubscribe(ctx, "", "") }},
+		{"Block", func() error { _, err := client.Block(ctx, nil); return err }},
+		{"BlockByHash", func() error { _, err := client.BlockByHash(ctx, []byte{}); return err }},
+		{"BlockResults", func() error { _, err := client.BlockResults(ctx, nil); return err }},
+		{"BlockSearch", func() error { _, err := client.BlockSearch(ctx, "", nil, nil, ""); return err }},
+		{"BroadcastEvidence", func() error { _, err := client.BroadcastEvidence(ctx, nil); return err }},
+		{"CheckTx", func() error { _, err := client.CheckTx(ctx, []byte{}); return err }},
+		{"ConsensusParams", func() error { _, err := client.ConsensusParams(ctx, nil); return err }},
+		{"Genesis", func() error { _, err := client.Genesis(ctx); return err }},
+		{"GenesisChunked", func() error { _, err := client.GenesisChunked(ctx, 0); return err }},
+		{"NumUnconfirmedTxs", func() error { _, err := client.NumUnconfirmedTxs(ctx); return err }},
+		{"Tx", func() error { _, err := client.Tx(ctx, []byte{}, false); return err }},
+		{"TxSearch", func() error { _, err := client.TxSearch(ctx, "", false, nil, nil, ""); return err }},
+		{"UnconfirmedTxs", func() error { _, err := client.UnconfirmedTxs(ctx, nil); return err }},
+		{"Validators", func() error { _, err := client.Validators(ctx, nil, nil, nil); return err }},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			assert.Panics(t, test.fn)
+			var err error
+			assert.NotPanics(t, func() { err = test.fn() })
+			assert.ErrorIs(t, err, ErrNotAvailableInLightNode)
+		})
+	}
+
+	_, err := client.ConsensusState(ctx)
+	assert.ErrorIs(t, err, ErrConsensusStateNotAvailable)
+	_, err = client.DumpConsensusState(ctx)
+	assert.ErrorIs(t, err, ErrConsensusStateNotAvailable)
+}
+
+func TestLightClient(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	keys := make([]crypto.PrivKey, 2)
+	for i := range keys {
+		keys[i], _, _ = crypto.GenerateEd25519Key(rand.Reader)
+	}
+	dalc := getMockDA(t)
+	bmConfig := getBMConfig()
+	chainID := "TestLightClient"
+	sequencer, _ := createAndConfigureNode(ctx, 0, true, false, chainID, keys, bmConfig, dalc, t)
+	lightNode, _ := createNode(ctx, 1, false, true, keys, bmConfig, chainID, false, t)
+
+	startNodeWithCleanup(t, sequencer)
+	require.NoError(waitForFirstBlock(sequencer, Header))
+	startNodeWithCleanup(t, lightNode)
+	require.NoError(waitForAtLeastNBlocks(sequencer.(*FullNode), 3, Header))
+	require.NoError(verifyNodesSynced(sequencer, lightNode, Header))
+
+	seqClient := sequencer.GetClient()
+	client := lightNode.GetClient()
+
+	height := int64(2)
+	expected, err := seqClient.Header(ctx, &height)
+	require.NoError(err)
+	header, err := client.Header(ctx, &height)
+	require.NoError(err)
+	assert.Equal(expected.Header.Hash(), header.Header.Hash())
+
+	byHash, err := client.HeaderByHash(ctx, header.Header.Hash())
+	require.NoError(err)
+	assert.Equal(header.Header.Hash(), byHash.Header.Hash())
+
+	latest, err := client.Header(ctx, nil)
+	require.NoError(err)
+	assert.GreaterOrEqual(latest.Header.Height, height)
+
+	commit, err := client.Commit(ctx, &height)
+	require.NoError(err)
+	assert.Equal(height, commit.Height)
+	assert.Equal(header.Header.Hash(), commit.Commit.BlockID.Hash)
+
+	info, err := client.BlockchainInfo(ctx, 1, height)
+	require.NoError(err)
+	require.Len(info.BlockMetas, 2)
+	assert.Equal(height, info.BlockMetas[0].Header.Height)
+	assert.Equal(int64(1), info.BlockMetas[1].Header.Height)
+
+	status, err := client.Status(ctx)
+	require.NoError(err)
+	assert.GreaterOrEqual(status.SyncInfo.LatestBlockHeight, height)
+	assert.Equal(int64(1), status.SyncInfo.EarliestBlockHeight)
+
+	netInfo, err := client.NetInfo(ctx)
+	require.NoError(err)
+	assert.Equal(1, netInfo.NPeers)
+
+	_, err = client.Health(ctx)
+	assert.NoError(err)
+
+	_, err = client.Header(ctx, new(int64))
+	assert.Error(err)
+	future := int64(1000)
+	_, err = client.Header(ctx, &future)
+	assert.Error(err)
+
+	// transactions are gossiped to full nodes
+	tx := []byte("light client tx")
+	res, err := client.BroadcastTxSync(ctx, tx)
+	require.NoError(err)
+	assert.EqualValues(cmtypes.Tx(tx).Hash(), res.Hash)
+	require.Eventually(func() bool {
+		_, err := seqClient.Tx(ctx, res.Hash, false)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestHeaderStoreTail(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := goheaderstore.NewStore[*types.SignedHeader](dssync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(err)
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+
+	tail, err := headerStoreTail(ctx, store, 1)
+	require.NoError(err)
+	require.Zero(tail)
+
+	// store initialized with trusted header doesn't contain headers below it
+	privKey := ed25519.GenPrivKey()
+	header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{Height: 5, PrivKey: privKey}, "TestHeaderStoreTail")
+	require.NoError(err)
+	require.NoError(store.Init(ctx, header))
+	for i := 0; i < 10; i++ {
+		header, err = types.GetRandomNextSignedHeader(header, privKey, "TestHeaderStoreTail")
+		require.NoError(err)
+		require.NoError(store.Append(ctx, header))
+	}
+	// headers are appended asynchronously
+	require.Eventually(func() bool {
+		return store.Height() == 15
+	}, time.Second, 10*time.Millisecond)
+
+	tail, err = headerStoreTail(ctx, store, 1)
+	require.NoError(err)
+	require.Equal(uint64(5), tail)
+}
+
+// heightABCIClient answers queries at requested height, or at latestHeight if height is not specified.
+type heightABCIClient struct {
+	rpcclient.ABCIClient
+	latestHeight int64
+	queried      []int64
+}
+
+func (c *heightABCIClient) ABCIQueryWithOptions(_ context.Context, _ string, _ cmbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	height := opts.Height
+	if height == 0 {
+		height = c.latestHeight
+	}
+	c.queried = append(c.queried, opts.Height)
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Height: height}}, nil
+}
+
+func TestQueryVerifiable(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	// latest state is committed in header that is already synced
+	client := &heightABCIClient{latestHeight: 5}
+	resp, err := queryVerifiable(ctx, client, 10, "/key", nil, rpcclient.DefaultABCIQueryOptions)
+	require.NoError(err)
+	assert.Equal(int64(5), resp.Height)
+	assert.Equal([]int64{0}, client.queried)
+
+	// latest state is queried again at the highest height verifiable with synced headers
+	client = &heightABCIClient{latestHeight: 12}
+	resp, err = queryVerifiable(ctx, client, 10, "/key", nil, rpcclient.DefaultABCIQueryOptions)
+	require.NoError(err)
+	assert.Equal(int64(9), resp.Height)
+	assert.Equal([]int64{0, 9}, client.queried)
+
+	// explicit height can't be verified before the header is synced
+	_, err = queryVerifiable(ctx, client, 10, "/key", nil, rpcclient.ABCIQueryOptions{Height: 10})
+	assert.ErrorIs(err, ErrHeaderNotSynced)
+
+	// nothing can be verified before the second header is synced
+	_, err = queryVerifiable(ctx, client, 1, "/key", nil, rpcclient.DefaultABCIQueryOptions)
+	assert.ErrorIs(err, ErrHeaderNotSynced)
+}
+
+func TestVerifyABCIQuery(t *testing.T) {
+	t.Parallel()
+
+	// leaf of simple merkle tree, as hashed by merkle.ValueOp
+	kvLeaf := func(key, value []byte) []byte {
+		leaf := binary.AppendUvarint(nil, uint64(len(key)))
+		leaf = append(leaf, key...)
+		vhash := tmhash.Sum(value)
+		leaf = binary.AppendUvarint(leaf, uint64(len(vhash)))
+		return append(leaf, vhash...)
+	}
+
+	keys := [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}
+	values := [][]byte{[]byte("value1"), []byte("value2"), []byte("value3")}
+	leaves := make([][]byte, len(keys))
+	for i := range keys {
+		leaves[i] = kvLeaf(keys[i], values[i])
+	}
+	appHash, proofs := merkle.ProofsFromByteSlices(leaves)
+
+	prt := merkle.DefaultProofRuntime()
+	keyPathFn := func(_ string, key []byte) (merkle.KeyPath, error) {
+		return new(merkle.KeyPath).AppendKey(key, merkle.KeyEncodingURL), nil
+	}
+	response := func(key, value []byte, proof *merkle.Proof) abci.ResponseQuery {
+		return abci.ResponseQuery{
+			Key:      key,
+			Value:    value,
+			Height:   1,
+			ProofOps: &cmcrypto.ProofOps{Ops: []cmcrypto.ProofOp{merkle.NewValueOp(key, proof).ProofOp()}},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		resp    abci.ResponseQuery
+		appHash []byte
+		wantErr bool
+	}{
+		{"valid", response(keys[1], values[1], proofs[1]), appHash, false},
+		{"wrong value", response(keys[1], values[0], proofs[1]), appHash, true},
+		{"wrong key", response(keys[0], values[1], proofs[1]), appHash, true},
+		{"wrong app hash", response(keys[1], values[1], proofs[1]), tmhash.Sum([]byte("other")), true},
+		{"no proof", abci.ResponseQuery{Key: keys[1], Value: values[1], Height: 1}, appHash, true},
+		{"empty key", abci.ResponseQuery{Value: values[1], Height: 1}, appHash, true},
+		// simple merkle proofs don't support proofs of absence
+		{"absence", response(keys[1], nil, proofs[1]), appHash, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := verifyABCIQuery(prt, keyPathFn, "/key", c.resp, c.appHash)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,91 @@
+package node
+
+import (
+	"context"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+)
+
+// ResultPendingHeaders describes headers waiting for submission to DA layer.
+type ResultPendingHeaders struct {
+	// Number of headers produced, but not yet submitted to DA layer.
+	Count uint64 `json:"count"`
+	// Height of the last header successfully submitted to DA layer.
+	LastSubmittedHeight uint64 `json:"last_submitted_height"`
+}
+
+// ResultDAStatus describes progress of the node in DA layer.
+type ResultDAStatus struct {
+	// Rollup height at which all blocks are included in DA layer.
+	DAIncludedHeight uint64 `json:"da_included_height"`
+	// Height of the next DA block scanned for rollup headers.
+	DAHeight uint64 `json:"da_height"`
+}
+
+// ResultSyncStatus describes heights of the node stores.
+type ResultSyncStatus struct {
+	// Height of the last block applied by the node.
+	StoreHeight uint64 `json:"store_height"`
+	// Height of the header sync service (P2P) store.
+	HeaderStoreHeight uint64 `json:"header_store_height"`
+	// Height of the data sync service (P2P) store.
+	DataStoreHeight uint64 `json:"data_store_height"`
+}
+
+// ResultNodeMode describes the role of the node in the rollup.
+type ResultNodeMode struct {
+	// Node is running in aggregator mode.
+	Aggregator bool `json:"aggregator"`
+	// Aggregator produces blocks only when there are transactions.
+	LazyAggregator bool `json:"lazy_aggregator"`
+	// Node is the proposer (sequencer) of the rollup.
+	Proposer bool `json:"proposer"`
+	// Address of the sequencer middleware used by the node.
+	SequencerAddress string `json:"sequencer_address"`
+}
+
+// ResultPendingEvidence lists evidence of misbehavior waiting for inclusion in a block.
+type ResultPendingEvidence struct {
+	Evidence []cmtypes.Evidence `json:"evidence"`
+}
+
+// PendingHeaders returns number of headers waiting for submission to DA layer and height of the last submitted header.
+func (c *FullClient) PendingHeaders(_ context.Context) (*ResultPendingHeaders, error) {
+	return &ResultPendingHeaders{
+		Count:               c.node.blockManager.GetPendingHeadersCount(),
+		LastSubmittedHeight: c.node.blockManager.GetLastSubmittedHeight(),
+	}, nil
+}
+
+// DAStatus returns DA included height and current DA height being scanned.
+func (c *FullClient) DAStatus(_ context.Context) (*ResultDAStatus, error) {
+	return &ResultDAStatus{
+		DAIncludedHeight: c.node.blockManager.GetDAIncludedHeight(),
+		DAHeight:         c.node.blockManager.GetDAHeight(),
+	}, nil
+}
+
+// SyncStatus returns heights of the block store and sync service stores.
+func (c *FullClient) SyncStatus(_ context.Context) (*ResultSyncStatus, error) {
+	return &ResultSyncStatus{
+		StoreHeight:       c.node.Store.Height(),
+		HeaderStoreHeight: c.node.hSyncService.Store().Height(),
+		DataStoreHeight:   c.node.dSyncService.Store().Height(),
+	}, nil
+}
+
+// NodeMode returns the role of the node in the rollup.
+func (c *FullClient) NodeMode(_ context.Context) (*ResultNodeMode, error) {
+	return &ResultNodeMode{
+		Aggregator:       c.node.nodeConfig.Aggregator,
+		LazyAggregator:   c.node.nodeConfig.LazyAggregator,
+		Proposer:         c.node.blockManager.IsProposer(),
+		SequencerAddress: c.node.nodeConfig.SequencerAddress,
+	}, nil
+}
+
+// PendingEvidence returns evidence of misbehavior waiting for inclusion in a block.
+func (c *FullClient) PendingEvidence(_ context.Context) (*ResultPendingEvidence, error) {
+	evidence, _ := c.node.EvidencePool.PendingEvidence(-1)
+	return &ResultPendingEvidence{Evidence: evidence}, nil
+}
@@ -0,0 +1,339 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	cmpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	abciconv "github.com/rollkit/rollkit/types/abci"
+)
+
+// maxPendingEvents limits the number of new events buffered while stored blocks are replayed. Events of blocks
+// that are stored in the meantime are dropped from the buffer and replayed from store, so the limit applies only
+// to events that can't be replayed (e.g. events of blocks that are being committed).
+const maxPendingEvents = 1000
+
+// ErrSubscriptionOverflow is the reason of termination of subscriptions, which subscribers don't consume events
+// fast enough.
+var ErrSubscriptionOverflow = errors.New("subscriber is not consuming events fast enough")
+
+// EventDataSubscriptionTerminated is the last event delivered by subscription terminated by the node (e.g. because
+// of overflow), right before the subscription channel is closed. Subscribers can resume subscription from
+// LastHeight+1 with FullClient.SubscribeFromHeight.
+type EventDataSubscriptionTerminated struct {
+	Reason string `json:"reason"`
+	// Height of the last delivered block related event (0 if none was delivered).
+	LastHeight int64 `json:"last_height"`
+}
+
+func init() {
+	cmjson.RegisterType(EventDataSubscriptionTerminated{}, "rollkit/event/SubscriptionTerminated")
+}
+
+// subscribe subscribes to a query and starts routine delivering events. If fromHeight is positive, events
+// of stored blocks starting at fromHeight are replayed first.
+func (c *FullClient) subscribe(ctx context.Context, subscriber, query string, fromHeight int64, outCapacity ...int) (<-chan ctypes.ResultEvent, error) {
+	q, err := cmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+
+	var sub cmtypes.Subscription
+	if outCap > 0 {
+		sub, err = c.EventBus.Subscribe(ctx, subscriber, q, outCap)
+	} else {
+		sub, err = c.EventBus.SubscribeUnbuffered(ctx, subscriber, q)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	s := &subscription{
+		client:     c,
+		sub:        sub,
+		subscriber: subscriber,
+		query:      q,
+		out:        make(chan ctypes.ResultEvent),
+		limit:      max(outCap, 1),
+	}
+	if fromHeight > 0 {
+		// blocks stored during replay are replayed as well (see catchUp)
+		s.nextReplayHeight = fromHeight
+		s.replayToHeight = int64(c.node.Store.Height()) //nolint:gosec
+	}
+	go s.run()
+
+	return s.out, nil
+}
+
+// subscription delivers events of a single subscriber and query.
+//
+// Events are queued up to limit. If subscriber doesn't consume events fast enough (queue is full, or event bus
+// cancelled the subscription), subscription is terminated: queued events are delivered, followed by
+// EventDataSubscriptionTerminated event, and the channel is closed. Subscriber is never silently resubscribed,
+// so it always knows if events were missed.
+//
+// If subscription starts at a past height, stored blocks are replayed first, paced by the subscriber. New events
+// received in the meantime are buffered, and events of blocks stored before replay finishes are replayed from
+// store instead, so catching up doesn't depend on capacity of the subscription.
+type subscription struct {
+	client     *FullClient
+	sub        cmtypes.Subscription
+	subscriber string
+	query      cmpubsub.Query
+	out        chan ctypes.ResultEvent
+	limit      int
+
+	queue      []ctypes.ResultEvent
+	terminated bool
+	// lastHeight is the height of the last delivered block related event
+	lastHeight int64
+
+	// stored blocks in range [nextReplayHeight, replayToHeight] are replayed before new events
+	nextReplayHeight int64
+	replayToHeight   int64
+	// pending are new events received during replay, delivered after replay is finished
+	pending []ctypes.ResultEvent
+}
+
+func (s *subscription) replaying() bool {
+	return s.nextReplayHeight > 0 && s.nextReplayHeight <= s.replayToHeight
+}
+
+func (s *subscription) run() {
+	defer close(s.out)
+	for {
+		// replayed events are loaded only if there is space in the queue, so replay is paced by subscriber
+		for !s.terminated && s.replaying() && len(s.queue) < s.limit {
+			if err := s.replayNextHeight(); err != nil {
+				s.terminate(err)
+			} else if !s.replaying() {
+				s.catchUp()
+			}
+		}
+		if s.terminated && len(s.queue) == 0 {
+			return
+		}
+
+		var (
+			out  chan<- ctypes.ResultEvent
+			next ctypes.ResultEvent
+		)
+		if len(s.queue) > 0 {
+			out = s.out
+			next = s.queue[0]
+		}
+		// event bus is drained during replay as well, so that the bus subscription doesn't overflow
+		var in <-chan cmpubsub.Message
+		var canceled <-chan struct{}
+		if !s.terminated {
+			in = s.sub.Out()
+			canceled = s.sub.Canceled()
+		}
+
+		select {
+		case out <- next:
+			s.queue = s.queue[1:]
+			if h, ok := eventHeight(next.Data); ok {
+				s.lastHeight = h
+			}
+		case msg := <-in:
+			if h, ok := eventHeight(msg.Data()); ok && h <= s.replayToHeight {
+				// already replayed from store
+				continue
+			}
+			e := ctypes.ResultEvent{Query: s.query.String(), Data: msg.Data(), Events: msg.Events()}
+			if s.replaying() {
+				// new events are delivered after replay, to keep ordering
+				s.pending = append(s.pending, e)
+				if len(s.pending) > s.limit {
+					s.skipStored()
+				}
+				if len(s.pending) > maxPendingEvents {
+					s.terminate(ErrSubscriptionOverflow)
+				}
+				continue
+			}
+			if len(s.queue) >= s.limit {
+				s.terminate(ErrSubscriptionOverflow)
+				continue
+			}
+			s.queue = append(s.queue, e)
+		case <-canceled:
+			if errors.Is(s.sub.Err(), cmpubsub.ErrUnsubscribed) {
+				return
+			}
+			if errors.Is(s.sub.Err(), cmpubsub.ErrOutOfCapacity) {
+				s.terminate(ErrSubscriptionOverflow)
+			} else {
+				s.terminate(s.sub.Err())
+			}
+		case <-s.client.Quit():
+			return
+		}
+	}
+}
+
+// catchUp is called when replay reaches replayToHeight. Blocks stored since replay started are replayed as well;
+// once replay reaches the store height, pending events are queued.
+func (s *subscription) catchUp() {
+	s.skipStored()
+	if s.replaying() {
+		return
+	}
+	s.queue = append(s.queue, s.pending...)
+	s.pending = nil
+}
+
+// skipStored extends replay up to the current store height, and drops pending events of blocks that will be
+// replayed from store.
+func (s *subscription) skipStored() {
+	s.replayToHeight = max(s.replayToHeight, int64(s.client.node.Store.Height())) //nolint:gosec
+	pending := s.pending[:0]
+	for _, e := range s.pending {
+		if h, ok := eventHeight(e.Data); ok && h <= s.replayToHeight {
+			continue
+		}
+		pending = append(pending, e)
+	}
+	s.pending = pending
+}
+
+// terminate unsubscribes from event bus and queues termination event. Termination event is queued even if
+// the queue is full.
+func (s *subscription) terminate(reason error) {
+	s.client.Logger.Error("terminating subscription", "subscriber", s.subscriber, "query", s.query.String(), "reason", reason)
+	s.terminated = true
+	lastHeight := s.lastHeight
+	// queued events are delivered before termination event
+	for _, e := range s.queue {
+		if h, ok := eventHeight(e.Data); ok {
+			lastHeight = h
+		}
+	}
+	s.queue = append(s.queue, ctypes.ResultEvent{
+		Query: s.query.String(),
+		Data:  EventDataSubscriptionTerminated{Reason: reason.Error(), LastHeight: lastHeight},
+	})
+	err := s.client.EventBus.Unsubscribe(context.Background(), s.subscriber, s.query)
+	if err != nil && !errors.Is(err, cmpubsub.ErrSubscriptionNotFound) {
+		s.client.Logger.Error("failed to unsubscribe", "subscriber", s.subscriber, "query", s.query.String(), "error", err)
+	}
+}
+
+// replayNextHeight queues events of the next stored block matching the query.
+func (s *subscription) replayNextHeight() error {
+	height := s.nextReplayHeight
+	events, err := s.client.storedEvents(context.Background(), uint64(height)) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to replay events at height %d: %w", height, err)
+	}
+	for _, e := range events {
+		match, err := s.query.Matches(e.Events)
+		if err != nil {
+			return fmt.Errorf("failed to match query: %w", err)
+		}
+		if match {
+			e.Query = s.query.String()
+			s.queue = append(s.queue, e)
+		}
+	}
+	s.nextReplayHeight++
+	return nil
+}
+
+// storedEvents recreates NewBlock and Tx events of stored block, as published by the block executor.
+func (c *FullClient) storedEvents(ctx context.Context, height uint64) ([]ctypes.ResultEvent, error) {
+	header, data, err := c.node.Store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.node.Store.GetBlockResponses(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	block, err := abciconv.ToABCIBlock(header, data)
+	if err != nil {
+		return nil, err
+	}
+
+	blockEvents := stringifyEvents(resp.Events)
+	blockEvents[cmtypes.EventTypeKey] = append(blockEvents[cmtypes.EventTypeKey], cmtypes.EventNewBlock)
+	events := []ctypes.ResultEvent{{
+		Data: cmtypes.EventDataNewBlock{
+			Block:               block,
+			BlockID:             cmtypes.BlockID{Hash: cmbytes.HexBytes(header.Hash())},
+			ResultFinalizeBlock: *resp,
+		},
+		Events: blockEvents,
+	}}
+
+	for i, tx := range block.Data.Txs {
+		if i >= len(resp.TxResults) {
+			break
+		}
+		txEvents := stringifyEvents(resp.TxResults[i].Events)
+		txEvents[cmtypes.EventTypeKey] = append(txEvents[cmtypes.EventTypeKey], cmtypes.EventTx)
+		txEvents[cmtypes.TxHashKey] = append(txEvents[cmtypes.TxHashKey], fmt.Sprintf("%X", tx.Hash()))
+		txEvents[cmtypes.TxHeightKey] = append(txEvents[cmtypes.TxHeightKey], fmt.Sprintf("%d", block.Height))
+		events = append(events, ctypes.ResultEvent{
+			Data: cmtypes.EventDataTx{TxResult: abci.TxResult{
+				Height: block.Height,
+				Index:  uint32(i), //nolint:gosec
+				Tx:     tx,
+				Result: *resp.TxResults[i],
+			}},
+			Events: txEvents,
+		})
+	}
+	return events, nil
+}
+
+// stringifyEvents converts ABCI events to composite keys used in queries, the same way as event bus does.
+func stringifyEvents(events []abci.Event) map[string][]string {
+	result := make(map[string][]string)
+	for _, event := range events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 {
+				continue
+			}
+			compositeTag := fmt.Sprintf("%s.%s", event.Type, attr.Key)
+			result[compositeTag] = append(result[compositeTag], attr.Value)
+		}
+	}
+	return result
+}
+
+// eventHeight returns block height of event, if event is related to a block.
+func eventHeight(data cmtypes.TMEventData) (int64, bool) {
+	switch d := data.(type) {
+	case cmtypes.EventDataNewBlock:
+		if d.Block == nil {
+			return 0, false
+		}
+		return d.Block.Height, true
+	case cmtypes.EventDataTx:
+		return d.Height, true
+	case cmtypes.EventDataNewBlockHeader:
+		return d.Header.Height, true
+	case cmtypes.EventDataNewBlockEvents:
+		return d.Height, true
+	}
+	return 0, false
+}
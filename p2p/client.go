@@ -6,11 +6,14 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/cometbft/cometbft/p2p"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	"github.com/libp2p/go-libp2p"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -39,7 +42,12 @@ const (
 	peerLimit = 60
 
 	// txTopicSuffix is added after namespace to create pubsub topic for TX gossiping.
-	txTopicSuffix = "-tx"
+	// Topic is versioned, because messages are prefixed with type byte (see encodeTx), and raw transactions
+	// gossiped by older nodes in "-tx" topic can't be told apart from them.
+	txTopicSuffix = "-tx/v2"
+
+	// evidenceTopicSuffix is added after namespace to create pubsub topic for evidence gossiping.
+	evidenceTopicSuffix = "-evidence"
 )
 
 // Client is a P2P client, implemented with libp2p.
@@ -60,6 +68,34 @@ type Client struct {
 
 	txGossiper  *Gossiper
 	txValidator GossipValidator
+	txBatcher   *txBatcher
+	seenTxs     *lru.Cache[[sha256.Size]byte, struct{}]
+
+	evidenceGossiper  *Gossiper
+	evidenceValidator GossipValidator
+
+	reputation *peerReputation
+
+	// trustedPeers are directly peered, protected and always connected peers (e.g. sequencer)
+	trustedPeers []peer.AddrInfo
+
+	// gossipLimiter limits rate of gossiped messages per peer and topic,
+	// exchangeLimiter limits rate of exchange requests per peer
+	gossipLimiter   *peerRateLimiter
+	exchangeLimiter *peerRateLimiter
+
+	// ds is used to persist peerstore
+	ds datastore.Datastore
+	// peersDS stores peers dialed at runtime, transientDS stores peers blocked at runtime without persistence,
+	// knownDS stores peers that node successfully connected to
+	peersDS     datastore.Datastore
+	transientDS datastore.Datastore
+	knownDS     datastore.Datastore
+
+	// knownPeersCh queues connections to be persisted in knownDS, knownPeersDone is closed when
+	// all queued connections are persisted after Client is closed
+	knownPeersCh   chan knownPeerUpdate
+	knownPeersDone chan struct{}
 
 	// cancel is used to cancel context passed to libp2p functions
 	// it's required because of discovery.Advertise call
@@ -88,12 +124,22 @@ func NewClient(conf config.P2PConfig, privKey crypto.PrivKey, chainID string, ds
 	}
 
 	return &Client{
-		conf:    conf,
-		gater:   gater,
-		privKey: privKey,
-		chainID: chainID,
-		logger:  logger,
-		metrics: metrics,
+		conf:            conf,
+		gater:           gater,
+		reputation:      newPeerReputation(conf.BanThreshold, conf.BanDuration, ds, gater, logger),
+		ds:              ds,
+		peersDS:         namespace.Wrap(ds, datastore.NewKey(persistentPeersNamespace)),
+		transientDS:     namespace.Wrap(ds, datastore.NewKey(transientBlocksNamespace)),
+		knownDS:         namespace.Wrap(ds, datastore.NewKey(knownPeersNamespace)),
+		knownPeersCh:    make(chan knownPeerUpdate, knownPeersQueueSize),
+		knownPeersDone:  make(chan struct{}),
+		seenTxs:         newSeenTxCache(),
+		gossipLimiter:   newPeerRateLimiter(conf.GossipRateLimit, conf.GossipBurst),
+		exchangeLimiter: newPeerRateLimiter(conf.ExchangeRateLimit, conf.ExchangeBurst),
+		privKey:         privKey,
+		chainID:         chainID,
+		logger:          logger,
+		metrics:         metrics,
 	}, nil
 }
 
@@ -103,7 +149,8 @@ func NewClient(conf config.P2PConfig, privKey crypto.PrivKey, chainID string, ds
 // 1. Setup libp2p host, start listening for incoming connections.
 // 2. Setup gossibsub.
 // 3. Setup DHT, establish connection to seed nodes and initialize peer discovery.
-// 4. Use active peer discovery to look for peers from same ORU network.
+// 4. Connect to trusted peers, reconnect to persistent and recently connected peers (known from persisted peerstore).
+// 5. Use active peer discovery to look for peers from same ORU network.
 func (c *Client) Start(ctx context.Context) error {
 	// create new, cancelable context
 	ctx, c.cancel = context.WithCancel(ctx)
@@ -120,6 +167,13 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 	for _, a := range c.host.Addrs() {
 		c.logger.Info("listening on", "address", fmt.Sprintf("%s/p2p/%s", a, c.host.ID()))
 	}
+	go c.persistKnownPeers(ctx)
+	c.host.Network().Notify(&network.NotifyBundle{ConnectedF: c.rememberPeer})
+
+	c.logger.Debug("lifting transient peer blocks")
+	if err := c.liftTransientBlocks(ctx); err != nil {
+		return err
+	}
 
 	c.logger.Debug("blocking blacklisted peers", "blacklist", c.conf.BlockedPeers)
 	if err := c.setupBlockedPeers(c.parseAddrInfoList(c.conf.BlockedPeers)); err != nil {
@@ -127,7 +181,17 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 	}
 
 	c.logger.Debug("allowing whitelisted peers", "whitelist", c.conf.AllowedPeers)
-	if err := c.setupAllowedPeers(c.parseAddrInfoList(c.conf.AllowedPeers)); err != nil {
+	allowedPeers := c.parseAddrInfoList(c.conf.AllowedPeers)
+	if err := c.setupAllowedPeers(allowedPeers); err != nil {
+		return err
+	}
+
+	c.logger.Debug("protecting trusted peers", "trusted", c.conf.TrustedPeers)
+	c.trustedPeers = c.parseAddrInfoList(c.conf.TrustedPeers)
+	c.setupTrustedPeers()
+
+	c.logger.Debug("restoring peer bans")
+	if err := c.reputation.start(ctx, c.host, slices.Concat(allowedPeers, c.trustedPeers)); err != nil {
 		return err
 	}
 
@@ -141,6 +205,19 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 		return err
 	}
 
+	c.logger.Debug("connecting to trusted peers")
+	go c.maintainTrustedPeers(ctx)
+
+	c.logger.Debug("dialing persistent peers")
+	if err := c.dialPersistentPeers(ctx); err != nil {
+		return err
+	}
+
+	c.logger.Debug("dialing known peers")
+	if err := c.dialKnownPeers(ctx); err != nil {
+		return err
+	}
+
 	c.logger.Debug("setting up active peer discovery")
 	if err := c.peerDiscovery(ctx); err != nil {
 		return err
@@ -153,17 +230,26 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 func (c *Client) Close() error {
 	c.cancel()
 
-	return errors.Join(
+	err := errors.Join(
 		c.txGossiper.Close(),
+		c.evidenceGossiper.Close(),
 		c.dht.Close(),
 		c.host.Close(),
 	)
+	<-c.knownPeersDone
+	return err
 }
 
 // GossipTx sends the transaction to the P2P network.
+//
+// If batching is enabled, transaction is gossiped together with other transactions submitted within
+// TxBatchInterval, and GossipTx returns after the batch is published.
 func (c *Client) GossipTx(ctx context.Context, tx []byte) error {
 	c.logger.Debug("Gossiping TX", "len", len(tx))
-	return c.txGossiper.Publish(ctx, tx)
+	if c.txBatcher != nil {
+		return c.txBatcher.add(ctx, tx)
+	}
+	return c.txGossiper.Publish(ctx, encodeTx(tx))
 }
 
 // SetTxValidator sets the callback function, that will be invoked during message gossiping.
@@ -171,6 +257,28 @@ func (c *Client) SetTxValidator(val GossipValidator) {
 	c.txValidator = val
 }
 
+// GossipEvidence sends the evidence of misbehavior to the P2P network.
+func (c *Client) GossipEvidence(ctx context.Context, evidence []byte) error {
+	c.logger.Debug("Gossiping evidence", "len", len(evidence))
+	if c.evidenceGossiper == nil {
+		return errors.New("evidence gossiping is not started")
+	}
+	return c.evidenceGossiper.Publish(ctx, evidence)
+}
+
+// SetEvidenceValidator sets the callback function, that will be invoked for gossiped evidence.
+// Evidence is ignored if validator is not set.
+func (c *Client) SetEvidenceValidator(val GossipValidator) {
+	c.evidenceValidator = val
+}
+
+func (c *Client) validateEvidence(m *GossipMessage) pubsub.ValidationResult {
+	if c.evidenceValidator == nil {
+		return pubsub.ValidationIgnore
+	}
+	return c.evidenceValidator(m)
+}
+
 // Addrs returns listen addresses of Client.
 func (c *Client) Addrs() []multiaddr.Multiaddr {
 	return c.host.Addrs()
@@ -181,6 +289,15 @@ func (c *Client) Host() host.Host {
 	return c.host
 }
 
+// ExchangeHost returns the libp2p node that should be used by exchange servers.
+// Requests to stream handlers registered with it are rate limited per peer, according to P2PConfig.
+func (c *Client) ExchangeHost() host.Host {
+	if c.exchangeLimiter == nil {
+		return c.host
+	}
+	return &rateLimitedHost{Host: c.host, limiter: c.exchangeLimiter, metrics: c.metrics}
+}
+
 // PubSub returns the libp2p node pubsub for adding future subscriptions
 func (c *Client) PubSub() *pubsub.PubSub {
 	return c.ps
@@ -191,6 +308,26 @@ func (c *Client) ConnectionGater() *conngater.BasicConnectionGater {
 	return c.gater
 }
 
+// BanPeer blocks connections with given peer for given duration, and closes existing connections with the peer.
+func (c *Client) BanPeer(ctx context.Context, id peer.ID, duration time.Duration, reason string) error {
+	return c.reputation.ban(ctx, id, duration, reason)
+}
+
+// UnbanPeer lifts the ban of given peer.
+func (c *Client) UnbanPeer(ctx context.Context, id peer.ID) error {
+	return c.reputation.unban(ctx, id)
+}
+
+// BannedPeers returns list of currently banned peers.
+func (c *Client) BannedPeers() []PeerBan {
+	return c.reputation.banned()
+}
+
+// PeerScore returns reputation score of given peer. Reputation is lowered by invalid gossiped messages.
+func (c *Client) PeerScore(id peer.ID) float64 {
+	return c.reputation.score(id)
+}
+
 // Info returns client ID, ListenAddr, and Network info
 func (c *Client) Info() (p2p.ID, string, string, error) {
 	rawKey, err := c.privKey.GetPublic().Raw()
@@ -243,12 +380,19 @@ func (c *Client) Peers() []PeerConnection {
 }
 
 func (c *Client) listen(ctx context.Context) (host.Host, error) {
-	maddr, err := multiaddr.NewMultiaddr(c.conf.ListenAddress)
+	opts, err := c.hostOptions()
 	if err != nil {
 		return nil, err
 	}
 
-	return libp2p.New(libp2p.ListenAddrs(maddr), libp2p.Identity(c.privKey), libp2p.ConnectionGater(c.gater))
+	ps, err := newPeerstore(ctx, c.ds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create peerstore: %w", err)
+	}
+	if ps != nil {
+		opts = append(opts, libp2p.Peerstore(ps))
+	}
+	return libp2p.New(opts...)
 }
 
 func (c *Client) setupDHT(ctx context.Context) error {
@@ -353,17 +497,42 @@ func (c *Client) tryConnect(ctx context.Context, peer peer.AddrInfo) {
 
 func (c *Client) setupGossiping(ctx context.Context) error {
 	var err error
-	c.ps, err = pubsub.NewGossipSub(ctx, c.host)
+	opts := []pubsub.Option{
+		pubsub.WithPeerScore(peerScoreParams(c.reputation), peerScoreThresholds),
+		pubsub.WithRawTracer(reputationTracer{ctx: ctx, ownID: c.host.ID(), reputation: c.reputation}),
+	}
+	if c.gossipLimiter != nil {
+		opts = append(opts, pubsub.WithDefaultValidator(c.gossipRateLimiter))
+	}
+	if len(c.trustedPeers) > 0 {
+		opts = append(opts, pubsub.WithDirectPeers(c.trustedPeers))
+	}
+	c.ps, err = pubsub.NewGossipSub(ctx, c.host, opts...)
 	if err != nil {
 		return err
 	}
 
-	c.txGossiper, err = NewGossiper(c.host, c.ps, c.getTxTopic(), c.logger, WithValidator(c.txValidator))
+	c.txGossiper, err = NewGossiper(c.host, c.ps, c.getTxTopic(), c.logger,
+		withRateLimiter(c.gossipLimiter, c.metrics),
+		WithValidator(c.validateTxs),
+	)
 	if err != nil {
 		return err
 	}
+	if c.conf.TxBatchInterval > 0 {
+		c.txBatcher = newTxBatcher(ctx, c.conf.TxBatchInterval, c.txGossiper.Publish)
+	}
 	go c.txGossiper.ProcessMessages(ctx)
 
+	c.evidenceGossiper, err = NewGossiper(c.host, c.ps, c.getEvidenceTopic(), c.logger,
+		withRateLimiter(c.gossipLimiter, c.metrics),
+		WithValidator(c.validateEvidence),
+	)
+	if err != nil {
+		return err
+	}
+	go c.evidenceGossiper.ProcessMessages(ctx)
+
 	return nil
 }
 
@@ -402,6 +571,10 @@ func (c *Client) getTxTopic() string {
 	return c.getNamespace() + txTopicSuffix
 }
 
+func (c *Client) getEvidenceTopic() string {
+	return c.getNamespace() + evidenceTopicSuffix
+}
+
 // -------
 func sumTruncated(bz []byte) []byte {
 	hash := sha256.Sum256(bz)
@@ -10,6 +10,7 @@ import (
 	"github.com/ipfs/go-datastore"
 	dssync "github.com/ipfs/go-datastore/sync"
 	"github.com/ipfs/go-log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
@@ -114,17 +115,17 @@ func TestGossiping(t *testing.T) {
 	wg.Add(3)
 
 	// ensure that Tx is delivered to client
-	assertRecv := func(tx *GossipMessage) bool {
+	assertRecv := func(tx *GossipMessage) pubsub.ValidationResult {
 		logger.Debug("received tx", "body", string(tx.Data), "from", tx.From)
 		assert.Equal(expectedMsg, tx.Data)
 		wg.Done()
-		return true
+		return pubsub.ValidationAccept
 	}
 
 	// ensure that Tx is not delivered to client
-	assertNotRecv := func(*GossipMessage) bool {
+	assertNotRecv := func(*GossipMessage) pubsub.ValidationResult {
 		t.Fatal("unexpected Tx received")
-		return false
+		return pubsub.ValidationReject
 	}
 
 	validators := []GossipValidator{assertRecv, assertNotRecv, assertNotRecv, assertRecv, assertRecv}
@@ -205,3 +206,48 @@ func TestSeedStringParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestLegacyTxGossiping(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := test.NewFileLogger(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan struct{}, 1)
+	recv := func(*GossipMessage) pubsub.ValidationResult {
+		received <- struct{}{}
+		return pubsub.ValidationAccept
+	}
+	clients := startTestNetwork(ctx, t, 2, map[int]hostDescr{
+		0: {conns: []int{}, chainID: "1"},
+		1: {conns: []int{0}, chainID: "1"},
+	}, []GossipValidator{recv, recv}, logger)
+	clients.WaitForDHT()
+
+	// client 1 acts as a node without batching support, gossiping raw transaction (that happens to
+	// start with the type byte of single transaction message) in legacy topic
+	legacyTopic, err := clients[1].ps.Join(clients[1].getNamespace() + "-tx")
+	require.NoError(err)
+	time.Sleep(1 * time.Second)
+	require.NoError(legacyTopic.Publish(ctx, encodeTx([]byte("legacy tx"))))
+
+	select {
+	case <-received:
+		t.Fatal("unexpected legacy tx received")
+	case <-time.After(1 * time.Second):
+	}
+	legacyPeer := clients[1].host.ID()
+	assert.Zero(clients[0].reputation.score(legacyPeer))
+	assert.False(clients[0].reputation.isBanned(legacyPeer))
+	assert.Contains(clients[0].host.Network().Peers(), legacyPeer)
+
+	// transactions are still exchanged in current topic
+	require.NoError(clients[1].GossipTx(ctx, []byte("tx")))
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tx not received")
+	}
+}
@@ -18,7 +18,12 @@ type GossipMessage struct {
 }
 
 // GossipValidator is a callback function type.
-type GossipValidator func(*GossipMessage) bool
+//
+// Validator returns pubsub.ValidationAccept for messages that should be propagated, and
+// pubsub.ValidationIgnore for well-formed messages that are not acceptable to the node (e.g. transactions
+// rejected by the application). pubsub.ValidationReject is reserved for messages that can't be decoded or
+// have invalid signatures, as rejected messages lower reputation of the peer that delivered them.
+type GossipValidator func(*GossipMessage) pubsub.ValidationResult
 
 // GossiperOption sets optional parameters of Gossiper.
 type GossiperOption func(*Gossiper) error
@@ -26,7 +31,17 @@ type GossiperOption func(*Gossiper) error
 // WithValidator options registers topic validator for Gossiper.
 func WithValidator(validator GossipValidator) GossiperOption {
 	return func(g *Gossiper) error {
-		return g.ps.RegisterTopicValidator(g.topic.String(), wrapValidator(validator))
+		return g.ps.RegisterTopicValidator(g.topic.String(), g.wrapValidator(validator))
+	}
+}
+
+// withRateLimiter option limits rate of messages per peer, before they are passed to validator.
+// It has to precede WithValidator option.
+func withRateLimiter(limiter *peerRateLimiter, metrics *Metrics) GossiperOption {
+	return func(g *Gossiper) error {
+		g.limiter = limiter
+		g.metrics = metrics
+		return nil
 	}
 }
 
@@ -38,6 +53,9 @@ type Gossiper struct {
 	topic *pubsub.Topic
 	sub   *pubsub.Subscription
 
+	limiter *peerRateLimiter
+	metrics *Metrics
+
 	logger log.Logger
 }
 
@@ -104,8 +122,14 @@ func (g *Gossiper) ProcessMessages(ctx context.Context) {
 	}
 }
 
-func wrapValidator(validator GossipValidator) pubsub.Validator {
-	return func(_ context.Context, _ peer.ID, msg *pubsub.Message) bool {
+// wrapValidator converts GossipValidator to pubsub validator. Messages from peers exceeding their
+// rate limit are ignored (dropped without penalty), without calling the validator.
+func (g *Gossiper) wrapValidator(validator GossipValidator) pubsub.ValidatorEx {
+	return func(_ context.Context, src peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		if src != g.ownID && !g.limiter.allow(g.topic.String(), src) {
+			g.metrics.RateLimitedMessages.With("topic", g.topic.String()).Add(1)
+			return pubsub.ValidationIgnore
+		}
 		return validator(&GossipMessage{
 			Data: msg.Data,
 			From: msg.GetFrom(),
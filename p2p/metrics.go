@@ -30,6 +30,12 @@ type Metrics struct {
 	MessageReceiveBytesTotal metrics.Counter `metrics_labels:"message_type"`
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter `metrics_labels:"message_type"`
+	// Number of gossiped transactions dropped without validation, because they were already accepted.
+	DuplicateTxs metrics.Counter
+	// Number of gossiped messages dropped because of per-peer rate limits.
+	RateLimitedMessages metrics.Counter `metrics_labels:"topic"`
+	// Number of exchange requests rejected because of per-peer rate limits.
+	RateLimitedRequests metrics.Counter `metrics_labels:"protocol"`
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -83,6 +89,24 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		DuplicateTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "duplicate_txs",
+			Help:      "Number of gossiped transactions dropped without validation, because they were already accepted.",
+		}, labels).With(labelsAndValues...),
+		RateLimitedMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limited_messages",
+			Help:      "Number of gossiped messages dropped because of per-peer rate limits.",
+		}, append(labels, "topic")).With(labelsAndValues...),
+		RateLimitedRequests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limited_requests",
+			Help:      "Number of exchange requests rejected because of per-peer rate limits.",
+		}, append(labels, "protocol")).With(labelsAndValues...),
 	}
 }
 
@@ -96,5 +120,8 @@ func NopMetrics() *Metrics {
 		NumTxs:                   discard.NewGauge(),
 		MessageReceiveBytesTotal: discard.NewCounter(),
 		MessageSendBytesTotal:    discard.NewCounter(),
+		DuplicateTxs:             discard.NewCounter(),
+		RateLimitedMessages:      discard.NewCounter(),
+		RateLimitedRequests:      discard.NewCounter(),
 	}
 }
@@ -0,0 +1,167 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// persistentPeersNamespace is the datastore namespace used to persist peers dialed at runtime.
+	persistentPeersNamespace = "p2p-peers"
+
+	// transientBlocksNamespace is the datastore namespace used to track peers blocked at runtime
+	// without persistence. Those blocks are lifted when the client is started.
+	transientBlocksNamespace = "p2p-transient-blocks"
+)
+
+// DialPeers connects to given peers. Peers are specified as multiaddrs with peer ID
+// (e.g. /ip4/127.0.0.1/tcp/7676/p2p/12D3KooW...).
+// If persistent is true, peers are saved in datastore and dialed again after restart.
+func (c *Client) DialPeers(ctx context.Context, addrs []string, persistent bool) error {
+	peers := make([]peer.AddrInfo, 0, len(addrs))
+	for _, addr := range addrs {
+		maddr, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return fmt.Errorf("invalid peer address %q: %w", addr, err)
+		}
+		addrInfo, err := peer.AddrInfoFromP2pAddr(maddr)
+		if err != nil {
+			return fmt.Errorf("invalid peer address %q: %w", addr, err)
+		}
+		peers = append(peers, *addrInfo)
+	}
+
+	var errs []error
+	for i, p := range peers {
+		if persistent {
+			if err := c.peersDS.Put(ctx, datastore.NewKey(p.ID.String()), []byte(addrs[i])); err != nil {
+				return fmt.Errorf("failed to persist peer %s: %w", p.ID, err)
+			}
+		}
+		if err := c.host.Connect(ctx, p); err != nil {
+			errs = append(errs, fmt.Errorf("failed to connect to peer %s: %w", p.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BlockPeer blocks connections with given peer, and closes existing connections with the peer.
+// If persistent is false, the peer is unblocked after restart.
+func (c *Client) BlockPeer(ctx context.Context, id peer.ID, persistent bool) error {
+	key := datastore.NewKey(id.String())
+	var err error
+	if persistent {
+		err = c.transientDS.Delete(ctx, key)
+	} else {
+		err = c.transientDS.Put(ctx, key, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.gater.BlockPeer(id); err != nil {
+		return err
+	}
+	if err := c.peersDS.Delete(ctx, key); err != nil {
+		return err
+	}
+	c.logger.Info("blocked peer", "peer", id, "persistent", persistent)
+	return c.host.Network().ClosePeer(id)
+}
+
+// UnblockPeer lifts the block of given peer. Ban of the peer is also lifted, if the peer is banned.
+func (c *Client) UnblockPeer(ctx context.Context, id peer.ID) error {
+	var err error
+	switch {
+	case c.reputation.isBanned(id):
+		err = c.reputation.unban(ctx, id)
+	case slices.Contains(c.gater.ListBlockedPeers(), id):
+		err = c.gater.UnblockPeer(id)
+	default:
+		err = fmt.Errorf("peer %s is not blocked", id)
+	}
+	if err != nil {
+		return err
+	}
+	c.logger.Info("unblocked peer", "peer", id)
+	return c.transientDS.Delete(ctx, datastore.NewKey(id.String()))
+}
+
+// BlockedPeers returns list of peers blocked by connection gater, including banned peers.
+func (c *Client) BlockedPeers() []peer.ID {
+	blocked := c.gater.ListBlockedPeers()
+	slices.Sort(blocked)
+	return blocked
+}
+
+// liftTransientBlocks unblocks peers blocked without persistence before restart.
+func (c *Client) liftTransientBlocks(ctx context.Context) error {
+	ids, err := queryPeerIDs(ctx, c.transientDS)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := c.gater.UnblockPeer(id); err != nil {
+			return err
+		}
+		if err := c.transientDS.Delete(ctx, datastore.NewKey(id.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dialPersistentPeers connects to peers persisted with DialPeers.
+func (c *Client) dialPersistentPeers(ctx context.Context) error {
+	results, err := c.peersDS.Query(ctx, dsq.Query{})
+	if err != nil {
+		return err
+	}
+	defer results.Close() //nolint:errcheck
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+		maddr, err := multiaddr.NewMultiaddr(string(result.Value))
+		if err != nil {
+			c.logger.Error("failed to parse persistent peer", "key", result.Key, "error", err)
+			continue
+		}
+		addrInfo, err := peer.AddrInfoFromP2pAddr(maddr)
+		if err != nil {
+			c.logger.Error("failed to create addr info for persistent peer", "address", maddr, "error", err)
+			continue
+		}
+		go c.tryConnect(ctx, *addrInfo)
+	}
+	return nil
+}
+
+// queryPeerIDs returns IDs of peers used as keys in given datastore.
+func queryPeerIDs(ctx context.Context, ds datastore.Datastore) ([]peer.ID, error) {
+	results, err := ds.Query(ctx, dsq.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close() //nolint:errcheck
+
+	var ids []peer.ID
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		id, err := peer.Decode(datastore.RawKey(result.Key).Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer ID %q: %w", result.Key, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestPeerManagement(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	allowAll := func(*GossipMessage) pubsub.ValidationResult { return pubsub.ValidationAccept }
+	validators := []GossipValidator{allowAll, allowAll}
+	clients := startTestNetwork(ctx, t, 2, map[int]hostDescr{}, validators, test.NewLogger(t))
+	client, other := clients[0], clients[1].host.ID()
+	addr := clients[1].host.Addrs()[0].String() + "/p2p/" + other.String()
+	key := datastore.NewKey(other.String())
+
+	assert.Error(client.DialPeers(ctx, []string{"invalid"}, false))
+
+	require.NoError(client.DialPeers(ctx, []string{addr}, true))
+	assert.Equal(network.Connected, client.host.Network().Connectedness(other))
+	persisted, err := client.peersDS.Has(ctx, key)
+	require.NoError(err)
+	assert.True(persisted)
+
+	// transient block is lifted on restart
+	require.NoError(client.BlockPeer(ctx, other, false))
+	assert.NotEqual(network.Connected, client.host.Network().Connectedness(other))
+	assert.Equal(other, client.BlockedPeers()[0])
+	persisted, err = client.peersDS.Has(ctx, key)
+	require.NoError(err)
+	assert.False(persisted)
+	require.NoError(client.liftTransientBlocks(ctx))
+	assert.Empty(client.BlockedPeers())
+
+	// persistent block survives restart
+	require.NoError(client.BlockPeer(ctx, other, true))
+	require.NoError(client.liftTransientBlocks(ctx))
+	assert.Equal(other, client.BlockedPeers()[0])
+
+	require.NoError(client.UnblockPeer(ctx, other))
+	assert.Empty(client.BlockedPeers())
+	assert.Error(client.UnblockPeer(ctx, other))
+
+	// unblocking banned peer lifts the ban
+	require.NoError(client.BanPeer(ctx, other, 0, "test"))
+	assert.Len(client.BannedPeers(), 1)
+	require.NoError(client.UnblockPeer(ctx, other))
+	assert.Empty(client.BannedPeers())
+	assert.Empty(client.BlockedPeers())
+}
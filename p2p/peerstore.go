@@ -0,0 +1,182 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoreds"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// peerstoreNamespace is the datastore namespace used by persistent peerstore.
+	peerstoreNamespace = "p2p-peerstore"
+
+	// knownPeersNamespace is the datastore namespace used to track peers that node successfully connected to.
+	// libp2p removes metadata of disconnected peers from peerstore, so it's tracked separately.
+	knownPeersNamespace = "p2p-known-peers"
+
+	// knownPeersLimit defines max number of known peers dialed on startup.
+	knownPeersLimit = 20
+	// knownPeerTTL defines how long peers are considered as known since last successful connection.
+	knownPeerTTL = 7 * 24 * time.Hour
+	// knownPeersQueueSize defines how many connections can wait to be persisted as known peers.
+	knownPeersQueueSize = 64
+)
+
+// knownPeerUpdate is a successful outbound connection waiting to be persisted.
+type knownPeerUpdate struct {
+	id    peer.ID
+	value []byte
+}
+
+// newPeerstore creates peerstore persisted in given datastore, so addresses of peers survive restarts.
+// If datastore doesn't support batching, nil is returned and libp2p uses in-memory peerstore.
+func newPeerstore(ctx context.Context, ds datastore.Datastore) (peerstore.Peerstore, error) {
+	if _, ok := ds.(datastore.Batching); !ok {
+		return nil, nil
+	}
+	return pstoreds.NewPeerstore(ctx, namespace.Wrap(ds, datastore.NewKey(peerstoreNamespace)), pstoreds.DefaultOpts())
+}
+
+// rememberPeer records successful outbound connection, so the peer can be dialed after restart.
+// It's called by libp2p for every new connection, so connection is only queued, and persisted
+// asynchronously by persistKnownPeers. If the queue is full, connection is not recorded.
+func (c *Client) rememberPeer(_ network.Network, conn network.Conn) {
+	if conn.Stat().Direction != network.DirOutbound {
+		return
+	}
+	update := knownPeerUpdate{id: conn.RemotePeer(), value: encodeKnownPeer(time.Now(), conn.RemoteMultiaddr())}
+	select {
+	case c.knownPeersCh <- update:
+	default:
+		c.logger.Debug("too many connections waiting to be remembered", "peer", update.id)
+	}
+}
+
+// persistKnownPeers saves connections queued by rememberPeer until ctx is done.
+// Connections queued before ctx is done are saved before it returns.
+func (c *Client) persistKnownPeers(ctx context.Context) {
+	defer close(c.knownPeersDone)
+	save := func(update knownPeerUpdate) {
+		if err := c.knownDS.Put(context.Background(), datastore.NewKey(update.id.String()), update.value); err != nil {
+			c.logger.Error("failed to remember peer", "peer", update.id, "error", err)
+		}
+	}
+	for {
+		select {
+		case update := <-c.knownPeersCh:
+			save(update)
+		case <-ctx.Done():
+			for {
+				select {
+				case update := <-c.knownPeersCh:
+					save(update)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// dialKnownPeers connects to peers that node successfully connected to recently (most recent first).
+// This allows nodes to recover connectivity quickly, even if seed nodes are down.
+func (c *Client) dialKnownPeers(ctx context.Context) error {
+	peers, err := c.knownPeers(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, p := range peers {
+		go c.tryConnect(ctx, p)
+	}
+	return nil
+}
+
+// knownPeers returns up to knownPeersLimit known peers, sorted by time of last connection (most recent first).
+// Addresses of peers are taken from peerstore and from last outbound connection.
+// Peers not connected for more than knownPeerTTL are forgotten.
+func (c *Client) knownPeers(ctx context.Context, now time.Time) ([]peer.AddrInfo, error) {
+	type knownPeer struct {
+		peer.AddrInfo
+		lastConnected time.Time
+	}
+
+	results, err := c.knownDS.Query(ctx, dsq.Query{})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		known   []knownPeer
+		expired []datastore.Key
+	)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, errors.Join(result.Error, results.Close())
+		}
+		id, err := peer.Decode(datastore.RawKey(result.Key).Name())
+		if err != nil {
+			c.logger.Error("invalid known peer", "key", result.Key, "error", err)
+			continue
+		}
+		lastConnected, addr, err := decodeKnownPeer(result.Value)
+		if err != nil || now.Sub(lastConnected) > knownPeerTTL {
+			expired = append(expired, datastore.RawKey(result.Key))
+			continue
+		}
+		if id == c.host.ID() || !c.gater.InterceptPeerDial(id) {
+			continue
+		}
+		addrs := append(c.host.Peerstore().Addrs(id), addr)
+		known = append(known, knownPeer{AddrInfo: peer.AddrInfo{ID: id, Addrs: multiaddr.Unique(addrs)}, lastConnected: lastConnected})
+	}
+	if err := results.Close(); err != nil {
+		return nil, err
+	}
+
+	// expired entries are deleted after results are closed, as datastores may not support
+	// modifications during iteration
+	for _, key := range expired {
+		if err := c.knownDS.Delete(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(known, func(a, b knownPeer) int {
+		return b.lastConnected.Compare(a.lastConnected)
+	})
+	known = known[:min(len(known), knownPeersLimit)]
+
+	peers := make([]peer.AddrInfo, 0, len(known))
+	for _, p := range known {
+		peers = append(peers, p.AddrInfo)
+	}
+	return peers, nil
+}
+
+func encodeKnownPeer(lastConnected time.Time, addr multiaddr.Multiaddr) []byte {
+	value := make([]byte, 8, 8+len(addr.Bytes()))
+	binary.BigEndian.PutUint64(value, uint64(lastConnected.Unix())) //nolint:gosec
+	return append(value, addr.Bytes()...)
+}
+
+func decodeKnownPeer(value []byte) (time.Time, multiaddr.Multiaddr, error) {
+	if len(value) < 8 {
+		return time.Time{}, nil, errors.New("invalid known peer: too short")
+	}
+	addr, err := multiaddr.NewMultiaddrBytes(value[8:])
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return time.Unix(int64(binary.BigEndian.Uint64(value)), 0), addr, nil //nolint:gosec
+}
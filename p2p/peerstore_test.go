@@ -0,0 +1,79 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestReconnectToKnownPeers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"}
+	newClient := func(ds datastore.Datastore) *Client {
+		privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(err)
+		client, err := NewClient(conf, privKey, "TestReconnectToKnownPeers", ds, test.NewLogger(t), NopMetrics())
+		require.NoError(err)
+		require.NoError(client.Start(ctx))
+		return client
+	}
+
+	seed := newClient(dssync.MutexWrap(datastore.NewMapDatastore()))
+	defer func() {
+		_ = seed.Close()
+	}()
+	seedAddr := seed.Addrs()[0].String() + "/p2p/" + seed.host.ID().String()
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	client, err := NewClient(conf, privKey, "TestReconnectToKnownPeers", ds, test.NewLogger(t), NopMetrics())
+	require.NoError(err)
+	require.NoError(client.Start(ctx))
+
+	require.NoError(client.DialPeers(ctx, []string{seedAddr}, false))
+	// connections are persisted asynchronously
+	var known []peer.AddrInfo
+	require.Eventually(func() bool {
+		known, err = client.knownPeers(ctx, time.Now())
+		require.NoError(err)
+		return len(known) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(seed.host.ID(), known[0].ID)
+	_ = client.Close()
+
+	// restarted client reconnects to known peer, without seeds
+	restarted, err := NewClient(conf, privKey, "TestReconnectToKnownPeers", ds, test.NewLogger(t), NopMetrics())
+	require.NoError(err)
+	require.NoError(restarted.Start(ctx))
+	defer func() {
+		_ = restarted.Close()
+	}()
+	assert.Eventually(func() bool {
+		return restarted.host.Network().Connectedness(seed.host.ID()) == network.Connected
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// stale peers are forgotten
+	known, err = restarted.knownPeers(ctx, time.Now().Add(knownPeerTTL+time.Hour))
+	require.NoError(err)
+	assert.Empty(known)
+	known, err = restarted.knownPeers(ctx, time.Now())
+	require.NoError(err)
+	assert.Empty(known)
+}
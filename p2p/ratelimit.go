@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"context"
+	"math"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitersCacheSize defines how many token buckets are tracked by rate limiter.
+// Buckets of least recently active peers are evicted first.
+const rateLimitersCacheSize = 10000
+
+// rateLimiterKey identifies token bucket of a peer in given scope (topic or protocol).
+type rateLimiterKey struct {
+	scope string
+	id    peer.ID
+}
+
+// peerRateLimiter limits rate of messages (or requests) per peer and scope, using token buckets.
+// nil peerRateLimiter allows everything.
+type peerRateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	limiters *lru.Cache[rateLimiterKey, *rate.Limiter]
+}
+
+// newPeerRateLimiter creates rate limiter allowing perSecond events with given burst.
+// If perSecond is not positive, rate limiting is disabled and nil is returned.
+// If burst is not positive, it defaults to perSecond (rounded up).
+func newPeerRateLimiter(perSecond float64, burst int) *peerRateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(perSecond))
+	}
+	limiters, err := lru.New[rateLimiterKey, *rate.Limiter](rateLimitersCacheSize)
+	if err != nil {
+		// only possible with non-positive size
+		panic(err)
+	}
+	return &peerRateLimiter{
+		limit:    rate.Limit(perSecond),
+		burst:    burst,
+		limiters: limiters,
+	}
+}
+
+// allow reports whether peer can send another message (or request) in given scope.
+func (l *peerRateLimiter) allow(scope string, id peer.ID) bool {
+	if l == nil {
+		return true
+	}
+	key := rateLimiterKey{scope: scope, id: id}
+	limiter, ok := l.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		// other goroutine might have added the limiter in the meantime
+		if prev, found, _ := l.limiters.PeekOrAdd(key, limiter); found {
+			limiter = prev
+		}
+	}
+	return limiter.Allow()
+}
+
+// gossipRateLimiter is a default pubsub validator, applied to all topics (e.g. headers and data).
+// Messages from peers exceeding their quota are ignored (dropped without penalty and not forwarded).
+//
+// Pubsub runs default and topic validators concurrently, so transaction and evidence topics are rate limited
+// by their Gossipers instead, to avoid checking messages that are dropped anyway.
+func (c *Client) gossipRateLimiter(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	topic := msg.GetTopic()
+	if from == c.host.ID() || topic == c.getTxTopic() || topic == c.getEvidenceTopic() || c.gossipLimiter.allow(topic, from) {
+		return pubsub.ValidationAccept
+	}
+	c.metrics.RateLimitedMessages.With("topic", topic).Add(1)
+	return pubsub.ValidationIgnore
+}
+
+// rateLimitedHost wraps stream handlers registered with host, to limit rate of requests per peer.
+type rateLimitedHost struct {
+	host.Host
+
+	limiter *peerRateLimiter
+	metrics *Metrics
+}
+
+// SetStreamHandler sets handler for given protocol. Streams from peers exceeding their quota are reset.
+func (h *rateLimitedHost) SetStreamHandler(pid protocol.ID, handler network.StreamHandler) {
+	h.Host.SetStreamHandler(pid, func(s network.Stream) {
+		if !h.limiter.allow(string(pid), s.Conn().RemotePeer()) {
+			h.metrics.RateLimitedRequests.With("protocol", string(pid)).Add(1)
+			_ = s.Reset()
+			return
+		}
+		handler(s)
+	})
+}
@@ -0,0 +1,157 @@
+package p2p
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+func TestPeerRateLimiter(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	peer1, peer2 := newTestPeerID(t), newTestPeerID(t)
+
+	limiter := newPeerRateLimiter(0.001, 2)
+	assert.True(limiter.allow("topic", peer1))
+	assert.True(limiter.allow("topic", peer1))
+	assert.False(limiter.allow("topic", peer1))
+	// buckets are tracked per peer and scope
+	assert.True(limiter.allow("topic", peer2))
+	assert.True(limiter.allow("other", peer1))
+
+	// burst defaults to rate
+	limiter = newPeerRateLimiter(0.5, 0)
+	assert.True(limiter.allow("topic", peer1))
+	assert.False(limiter.allow("topic", peer1))
+
+	// disabled rate limiter allows everything
+	var disabled *peerRateLimiter
+	assert.Nil(newPeerRateLimiter(0, 10))
+	for i := 0; i < 10; i++ {
+		assert.True(disabled.allow("topic", peer1))
+	}
+}
+
+func TestGossipRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received atomic.Int32
+	conf := config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", GossipRateLimit: 0.001, GossipBurst: 2}
+	receiver := startTransportTestClient(ctx, t, conf, func(*GossipMessage) pubsub.ValidationResult {
+		received.Add(1)
+		return pubsub.ValidationAccept
+	})
+	sender := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"}, func(*GossipMessage) pubsub.ValidationResult {
+		return pubsub.ValidationAccept
+	})
+
+	require.NoError(sender.DialPeers(ctx, []string{p2pAddr(t, receiver, multiaddr.P_TCP)}, false))
+	require.Eventually(func() bool {
+		return len(sender.ps.ListPeers(sender.getTxTopic())) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	time.Sleep(time.Second)
+	for _, tx := range []string{"tx1", "tx2", "tx3", "tx4", "tx5"} {
+		require.NoError(sender.GossipTx(ctx, []byte(tx)))
+	}
+	assert.Eventually(func() bool {
+		return received.Load() == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(int32(2), received.Load())
+
+	// evidence is limited only by evidence gossiper
+	var receivedEvidence atomic.Int32
+	receiver.SetEvidenceValidator(func(*GossipMessage) pubsub.ValidationResult {
+		receivedEvidence.Add(1)
+		return pubsub.ValidationAccept
+	})
+	sender.SetEvidenceValidator(func(*GossipMessage) pubsub.ValidationResult {
+		return pubsub.ValidationAccept
+	})
+	for _, ev := range []string{"ev1", "ev2", "ev3"} {
+		require.NoError(sender.GossipEvidence(ctx, []byte(ev)))
+	}
+	assert.Eventually(func() bool {
+		return receivedEvidence.Load() == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(int32(2), receivedEvidence.Load())
+	// quota of transactions and evidence is not consumed by default validator as well
+	for _, topic := range []string{receiver.getTxTopic(), receiver.getEvidenceTopic()} {
+		msg := &pubsub.Message{Message: &pb.Message{Topic: &topic}}
+		for i := 0; i < 3; i++ {
+			assert.Equal(pubsub.ValidationAccept, receiver.gossipRateLimiter(ctx, sender.host.ID(), msg))
+		}
+	}
+
+	// other topics (e.g. headers) are limited by default validator
+	const topic = "TestGossipRateLimit-other"
+	receiverTopic, err := receiver.ps.Join(topic)
+	require.NoError(err)
+	sub, err := receiverTopic.Subscribe()
+	require.NoError(err)
+	senderTopic, err := sender.ps.Join(topic)
+	require.NoError(err)
+	require.Eventually(func() bool {
+		return len(senderTopic.ListPeers()) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	time.Sleep(1 * time.Second)
+	for _, msg := range []string{"msg1", "msg2", "msg3"} {
+		require.NoError(senderTopic.Publish(ctx, []byte(msg)))
+	}
+	// messages are validated concurrently, so any two of them can be accepted
+	for i := 0; i < 2; i++ {
+		_, err := sub.Next(ctx)
+		require.NoError(err)
+	}
+	nextCtx, nextCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer nextCancel()
+	_, err = sub.Next(nextCtx)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestExchangeRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", ExchangeRateLimit: 0.001, ExchangeBurst: 1}, nil)
+	client := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"}, nil)
+
+	const protocolID = "/test/exchange"
+	server.ExchangeHost().SetStreamHandler(protocolID, func(s network.Stream) {
+		_, _ = s.Write([]byte("ok"))
+		_ = s.Close()
+	})
+	require.NoError(client.DialPeers(ctx, []string{p2pAddr(t, server, multiaddr.P_TCP)}, false))
+
+	request := func(id peer.ID) error {
+		s, err := client.host.NewStream(ctx, id, protocolID)
+		if err != nil {
+			return err
+		}
+		defer s.Close() //nolint:errcheck
+		_, err = io.ReadAll(s)
+		return err
+	}
+	assert.NoError(request(server.host.ID()))
+	assert.Error(request(server.host.ID()))
+}
@@ -0,0 +1,374 @@
+package p2p
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	dsq "github.com/ipfs/go-datastore/query"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+const (
+	// maxReputation caps the reputation that peer can earn with valid messages.
+	maxReputation = 20
+	// validMessageReward is added to reputation of peer for every valid message it delivered.
+	validMessageReward = 0.1
+	// invalidMessagePenalty is subtracted from reputation of peer for every invalid message it delivered.
+	invalidMessagePenalty = 10
+
+	// banExpiryInterval defines how often expired bans are lifted and reputation decays.
+	banExpiryInterval = 1 * time.Minute
+	// reputationDecay is the factor applied to reputation of every peer each banExpiryInterval, so that
+	// both penalties and rewards fade over time (half-life of about 7 minutes).
+	reputationDecay = 0.9
+	// reputationDecayToZero is the absolute reputation below which it's reset to 0.
+	reputationDecayToZero = 0.01
+
+	// bansNamespace is the datastore namespace used to persist peer bans.
+	bansNamespace = "p2p-bans"
+)
+
+// PeerBan describes a temporary ban of a peer.
+type PeerBan struct {
+	ID     peer.ID   `json:"id"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+}
+
+// peerReputation tracks reputation of peers, based on validation results of gossiped messages.
+// Peers with reputation lower than configured threshold are temporarily banned with connection gater.
+// Bans are persisted in datastore, so they survive restarts.
+type peerReputation struct {
+	mtx    sync.Mutex
+	scores map[peer.ID]float64
+	bans   map[peer.ID]PeerBan
+	// protected peers (e.g. explicitly allowed in configuration) are never banned automatically
+	protected map[peer.ID]struct{}
+
+	threshold float64
+	duration  time.Duration
+
+	host   host.Host
+	gater  *conngater.BasicConnectionGater
+	ds     datastore.Datastore
+	logger log.Logger
+}
+
+func newPeerReputation(threshold float64, duration time.Duration, ds datastore.Datastore, gater *conngater.BasicConnectionGater, logger log.Logger) *peerReputation {
+	return &peerReputation{
+		scores:    make(map[peer.ID]float64),
+		bans:      make(map[peer.ID]PeerBan),
+		protected: make(map[peer.ID]struct{}),
+		threshold: threshold,
+		duration:  duration,
+		gater:     gater,
+		ds:        namespace.Wrap(ds, datastore.NewKey(bansNamespace)),
+		logger:    logger,
+	}
+}
+
+// start restores persisted bans and starts background lifting of expired bans and decay of reputation.
+func (r *peerReputation) start(ctx context.Context, h host.Host, protected []peer.AddrInfo) error {
+	r.mtx.Lock()
+	r.host = h
+	for _, p := range protected {
+		r.protected[p.ID] = struct{}{}
+	}
+	r.mtx.Unlock()
+
+	if err := r.loadBans(ctx); err != nil {
+		return fmt.Errorf("failed to load peer bans: %w", err)
+	}
+	r.expireBans(ctx, time.Now())
+
+	go func() {
+		ticker := time.NewTicker(banExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				r.expireBans(ctx, now)
+				r.decayScores()
+			}
+		}
+	}()
+	return nil
+}
+
+// score returns current reputation of peer.
+func (r *peerReputation) score(id peer.ID) float64 {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.scores[id]
+}
+
+// isBanned checks if peer is currently banned.
+func (r *peerReputation) isBanned(id peer.ID) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	_, ok := r.bans[id]
+	return ok
+}
+
+// reportValid increases reputation of peer that delivered a valid message.
+func (r *peerReputation) reportValid(id peer.ID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.scores[id] = min(r.scores[id]+validMessageReward, maxReputation)
+}
+
+// reportInvalid decreases reputation of peer that delivered an invalid message, and bans the peer
+// if its reputation drops below threshold.
+func (r *peerReputation) reportInvalid(ctx context.Context, id peer.ID, reason string) {
+	r.mtx.Lock()
+	score := r.scores[id] - invalidMessagePenalty
+	r.scores[id] = score
+	_, isProtected := r.protected[id]
+	_, isBanned := r.bans[id]
+	r.mtx.Unlock()
+
+	r.logger.Debug("invalid message from peer", "peer", id, "reason", reason, "score", score)
+	if r.threshold == 0 || score >= r.threshold || isProtected || isBanned {
+		return
+	}
+	// banning closes connections, so it's done asynchronously to avoid blocking message validation
+	go func() {
+		if err := r.ban(ctx, id, r.duration, fmt.Sprintf("reputation %.1f below threshold: %s", score, reason)); err != nil {
+			r.logger.Error("failed to ban peer", "peer", id, "error", err)
+		}
+	}()
+}
+
+// decayScores moves reputation of all peers towards 0. Peers that are no longer connected are
+// forgotten once their reputation decays to 0.
+func (r *peerReputation) decayScores() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for id, score := range r.scores {
+		score *= reputationDecay
+		if math.Abs(score) < reputationDecayToZero {
+			score = 0
+		}
+		if score == 0 && !r.isConnected(id) {
+			delete(r.scores, id)
+			continue
+		}
+		r.scores[id] = score
+	}
+}
+
+// forget removes reputation of disconnected peer, unless it still carries a reward or a penalty.
+func (r *peerReputation) forget(id peer.ID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.scores[id] == 0 {
+		delete(r.scores, id)
+	}
+}
+
+// isConnected checks if there is an open connection with peer. Must be called with mtx held.
+func (r *peerReputation) isConnected(id peer.ID) bool {
+	return r.host != nil && r.host.Network().Connectedness(id) == network.Connected
+}
+
+// ban blocks the peer with connection gater, closes connections with the peer and persists the ban.
+func (r *peerReputation) ban(ctx context.Context, id peer.ID, duration time.Duration, reason string) error {
+	b := PeerBan{ID: id, Until: time.Now().Add(duration), Reason: reason}
+	if err := r.ds.Put(ctx, datastore.NewKey(id.String()), encodeBan(b)); err != nil {
+		return err
+	}
+	if err := r.gater.BlockPeer(id); err != nil {
+		return err
+	}
+
+	r.mtx.Lock()
+	r.bans[id] = b
+	r.scores[id] = 0
+	h := r.host
+	r.mtx.Unlock()
+
+	r.logger.Info("banned peer", "peer", id, "until", b.Until, "reason", reason)
+	if h != nil {
+		return h.Network().ClosePeer(id)
+	}
+	return nil
+}
+
+// unban lifts the ban of a peer. It returns error if peer is not banned.
+func (r *peerReputation) unban(ctx context.Context, id peer.ID) error {
+	r.mtx.Lock()
+	_, ok := r.bans[id]
+	delete(r.bans, id)
+	r.mtx.Unlock()
+	if !ok {
+		return fmt.Errorf("peer %s is not banned", id)
+	}
+
+	r.logger.Info("unbanned peer", "peer", id)
+	return errors.Join(
+		r.gater.UnblockPeer(id),
+		r.ds.Delete(ctx, datastore.NewKey(id.String())),
+	)
+}
+
+// banned returns all active bans, sorted by peer ID.
+func (r *peerReputation) banned() []PeerBan {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	bans := make([]PeerBan, 0, len(r.bans))
+	for _, b := range r.bans {
+		bans = append(bans, b)
+	}
+	slices.SortFunc(bans, func(a, b PeerBan) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return bans
+}
+
+func (r *peerReputation) expireBans(ctx context.Context, now time.Time) {
+	for _, b := range r.banned() {
+		if b.Until.After(now) {
+			continue
+		}
+		if err := r.unban(ctx, b.ID); err != nil {
+			r.logger.Error("failed to lift expired ban", "peer", b.ID, "error", err)
+		}
+	}
+}
+
+func (r *peerReputation) loadBans(ctx context.Context) error {
+	results, err := r.ds.Query(ctx, dsq.Query{})
+	if err != nil {
+		return err
+	}
+	defer results.Close() //nolint:errcheck
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+		id, err := peer.Decode(datastore.RawKey(result.Key).Name())
+		if err != nil {
+			return fmt.Errorf("invalid peer ID in ban %q: %w", result.Key, err)
+		}
+		b, err := decodeBan(id, result.Value)
+		if err != nil {
+			return err
+		}
+		if err := r.gater.BlockPeer(id); err != nil {
+			return err
+		}
+		r.mtx.Lock()
+		r.bans[id] = b
+		r.mtx.Unlock()
+	}
+	return nil
+}
+
+func encodeBan(b PeerBan) []byte {
+	value := make([]byte, 8, 8+len(b.Reason))
+	binary.BigEndian.PutUint64(value, uint64(b.Until.UnixNano())) //nolint:gosec
+	return append(value, b.Reason...)
+}
+
+func decodeBan(id peer.ID, value []byte) (PeerBan, error) {
+	if len(value) < 8 {
+		return PeerBan{}, fmt.Errorf("invalid ban of peer %s: too short", id)
+	}
+	return PeerBan{
+		ID:     id,
+		Until:  time.Unix(0, int64(binary.BigEndian.Uint64(value))), //nolint:gosec
+		Reason: string(value[8:]),
+	}, nil
+}
+
+// reputationTracer feeds peer reputation with validation results of messages in all gossip topics
+// (transactions, headers and data).
+type reputationTracer struct {
+	ctx        context.Context
+	ownID      peer.ID
+	reputation *peerReputation
+}
+
+var _ pubsub.RawTracer = reputationTracer{}
+
+// DeliverMessage is invoked when a message passed validation.
+func (t reputationTracer) DeliverMessage(msg *pubsub.Message) {
+	if msg.ReceivedFrom != t.ownID {
+		t.reputation.reportValid(msg.ReceivedFrom)
+	}
+}
+
+// RejectMessage is invoked when a message is rejected or ignored.
+func (t reputationTracer) RejectMessage(msg *pubsub.Message, reason string) {
+	if msg.ReceivedFrom == t.ownID {
+		return
+	}
+	switch reason {
+	case pubsub.RejectValidationFailed, pubsub.RejectInvalidSignature, pubsub.RejectMissingSignature,
+		pubsub.RejectUnexpectedSignature, pubsub.RejectUnexpectedAuthInfo:
+		t.reputation.reportInvalid(t.ctx, msg.ReceivedFrom, reason)
+	}
+}
+
+// RemovePeer is invoked when a peer disconnects from pubsub.
+func (t reputationTracer) RemovePeer(id peer.ID) {
+	t.reputation.forget(id)
+}
+
+func (t reputationTracer) AddPeer(peer.ID, protocol.ID)         {}
+func (t reputationTracer) Join(string)                          {}
+func (t reputationTracer) Leave(string)                         {}
+func (t reputationTracer) Graft(peer.ID, string)                {}
+func (t reputationTracer) Prune(peer.ID, string)                {}
+func (t reputationTracer) ValidateMessage(*pubsub.Message)      {}
+func (t reputationTracer) DuplicateMessage(*pubsub.Message)     {}
+func (t reputationTracer) ThrottlePeer(peer.ID)                 {}
+func (t reputationTracer) RecvRPC(*pubsub.RPC)                  {}
+func (t reputationTracer) SendRPC(*pubsub.RPC, peer.ID)         {}
+func (t reputationTracer) DropRPC(*pubsub.RPC, peer.ID)         {}
+func (t reputationTracer) UndeliverableMessage(*pubsub.Message) {}
+
+// peerScoreParams returns GossipSub peer score parameters, using reputation as application specific score.
+func peerScoreParams(r *peerReputation) *pubsub.PeerScoreParams {
+	return &pubsub.PeerScoreParams{
+		Topics:                      make(map[string]*pubsub.TopicScoreParams),
+		AppSpecificScore:            r.score,
+		AppSpecificWeight:           1,
+		IPColocationFactorWeight:    -10,
+		IPColocationFactorThreshold: 10,
+		BehaviourPenaltyWeight:      -1,
+		BehaviourPenaltyThreshold:   6,
+		BehaviourPenaltyDecay:       pubsub.ScoreParameterDecay(10 * time.Minute),
+		DecayInterval:               pubsub.DefaultDecayInterval,
+		DecayToZero:                 pubsub.DefaultDecayToZero,
+		RetainScore:                 1 * time.Hour,
+	}
+}
+
+// peerScoreThresholds defines GossipSub thresholds, matched with the range of reputation scores.
+var peerScoreThresholds = &pubsub.PeerScoreThresholds{
+	GossipThreshold:             -40,
+	PublishThreshold:            -60,
+	GraylistThreshold:           -80,
+	AcceptPXThreshold:           10,
+	OpportunisticGraftThreshold: 5,
+}
@@ -0,0 +1,168 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+	return id
+}
+
+func newTestReputation(t *testing.T, ds datastore.Datastore, threshold float64, duration time.Duration) (*peerReputation, *conngater.BasicConnectionGater) {
+	t.Helper()
+	gater, err := conngater.NewBasicConnectionGater(ds)
+	require.NoError(t, err)
+	return newPeerReputation(threshold, duration, ds, gater, test.NewLogger(t)), gater
+}
+
+func TestPeerReputationBan(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	r, gater := newTestReputation(t, ds, -25, time.Hour)
+	require.NoError(r.start(ctx, nil, nil))
+
+	honest, malicious := newTestPeerID(t), newTestPeerID(t)
+	tracer := reputationTracer{ctx: ctx, reputation: r}
+	for i := 0; i < 10; i++ {
+		tracer.DeliverMessage(&pubsub.Message{ReceivedFrom: honest})
+	}
+	// ignored messages don't affect reputation
+	tracer.RejectMessage(&pubsub.Message{ReceivedFrom: honest}, pubsub.RejectValidationIgnored)
+	assert.InDelta(10*validMessageReward, r.score(honest), 1e-9)
+
+	for i := 0; i < 3; i++ {
+		tracer.RejectMessage(&pubsub.Message{ReceivedFrom: malicious}, pubsub.RejectValidationFailed)
+	}
+	require.Eventually(func() bool {
+		return len(r.banned()) == 1
+	}, time.Second, 10*time.Millisecond)
+	ban := r.banned()[0]
+	assert.Equal(malicious, ban.ID)
+	assert.WithinDuration(time.Now().Add(time.Hour), ban.Until, time.Minute)
+	assert.Contains(gater.ListBlockedPeers(), malicious)
+	assert.NotContains(gater.ListBlockedPeers(), honest)
+
+	// bans are restored from datastore
+	restored, restoredGater := newTestReputation(t, ds, -25, time.Hour)
+	require.NoError(restored.start(ctx, nil, nil))
+	require.Len(restored.banned(), 1)
+	assert.Equal(ban.ID, restored.banned()[0].ID)
+	assert.Equal(ban.Reason, restored.banned()[0].Reason)
+	assert.True(ban.Until.Equal(restored.banned()[0].Until))
+	assert.Contains(restoredGater.ListBlockedPeers(), malicious)
+
+	require.NoError(restored.unban(ctx, malicious))
+	assert.Empty(restored.banned())
+	assert.NotContains(restoredGater.ListBlockedPeers(), malicious)
+	assert.Error(restored.unban(ctx, malicious))
+
+	// unban is persisted
+	restored, _ = newTestReputation(t, ds, -25, time.Hour)
+	require.NoError(restored.start(ctx, nil, nil))
+	assert.Empty(restored.banned())
+}
+
+func TestPeerReputationBanExpiry(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, gater := newTestReputation(t, dssync.MutexWrap(datastore.NewMapDatastore()), -25, time.Hour)
+	require.NoError(r.start(ctx, nil, nil))
+
+	short, long := newTestPeerID(t), newTestPeerID(t)
+	require.NoError(r.ban(ctx, short, time.Minute, "test"))
+	require.NoError(r.ban(ctx, long, time.Hour, "test"))
+	require.Len(r.banned(), 2)
+
+	r.expireBans(ctx, time.Now().Add(2*time.Minute))
+	require.Len(r.banned(), 1)
+	assert.Equal(long, r.banned()[0].ID)
+	assert.NotContains(gater.ListBlockedPeers(), short)
+	assert.Contains(gater.ListBlockedPeers(), long)
+}
+
+func TestPeerReputationDecay(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, _ := newTestReputation(t, dssync.MutexWrap(datastore.NewMapDatastore()), 0, time.Hour)
+	require.NoError(r.start(ctx, nil, nil))
+	tracer := reputationTracer{ctx: ctx, reputation: r}
+
+	good, bad, neutral := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+	tracer.DeliverMessage(&pubsub.Message{ReceivedFrom: good})
+	tracer.RejectMessage(&pubsub.Message{ReceivedFrom: bad}, pubsub.RejectValidationFailed)
+	tracer.RejectMessage(&pubsub.Message{ReceivedFrom: neutral}, pubsub.RejectValidationIgnored)
+
+	// penalties and rewards fade over time
+	r.decayScores()
+	assert.InDelta(validMessageReward*reputationDecay, r.score(good), 1e-9)
+	assert.InDelta(-invalidMessagePenalty*reputationDecay, r.score(bad), 1e-9)
+
+	// peers with non-zero reputation are remembered after disconnection
+	tracer.RemovePeer(good)
+	tracer.RemovePeer(bad)
+	tracer.RemovePeer(neutral)
+	assert.Len(r.scores, 2)
+
+	// disconnected peers are forgotten once reputation decays to 0
+	for i := 0; i < 100 && len(r.scores) > 0; i++ {
+		r.decayScores()
+	}
+	assert.Empty(r.scores)
+}
+
+func TestPeerReputationProtectedAndDisabled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	protected := newTestPeerID(t)
+	r, _ := newTestReputation(t, dssync.MutexWrap(datastore.NewMapDatastore()), -25, time.Hour)
+	require.NoError(r.start(ctx, nil, []peer.AddrInfo{{ID: protected}}))
+
+	// automatic bans are disabled with threshold equal to 0
+	disabled, _ := newTestReputation(t, dssync.MutexWrap(datastore.NewMapDatastore()), 0, time.Hour)
+	require.NoError(disabled.start(ctx, nil, nil))
+	other := newTestPeerID(t)
+
+	for i := 0; i < 10; i++ {
+		r.reportInvalid(ctx, protected, pubsub.RejectValidationFailed)
+		disabled.reportInvalid(ctx, other, pubsub.RejectValidationFailed)
+	}
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(r.banned())
+	require.Empty(disabled.banned())
+	require.Less(r.score(protected), -25.0)
+}
@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/pnet"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// pskLength is the length of pre-shared key of private network.
+const pskLength = 32
+
+var errPrivateNetworkTransport = errors.New("private network supports only TCP and WebSocket transports")
+
+// hostOptions returns libp2p options configuring transports, private network and NAT traversal,
+// according to P2PConfig.
+func (c *Client) hostOptions() ([]libp2p.Option, error) {
+	listenAddrs, err := parseListenAddrs(c.conf.ListenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []libp2p.Option{
+		libp2p.ListenAddrs(listenAddrs...),
+		libp2p.Identity(c.privKey),
+		libp2p.ConnectionGater(c.gater),
+	}
+
+	if c.conf.PrivateNetworkKey != "" {
+		psk, err := parsePSK(c.conf.PrivateNetworkKey)
+		if err != nil {
+			return nil, err
+		}
+		// libp2p silently ignores listen addresses without matching transport
+		for _, maddr := range listenAddrs {
+			if isUDPAddr(maddr) {
+				return nil, fmt.Errorf("%w: %s", errPrivateNetworkTransport, maddr)
+			}
+		}
+		opts = append(opts, libp2p.PrivateNetwork(psk))
+	}
+
+	if c.conf.NATPortMap {
+		opts = append(opts, libp2p.NATPortMap())
+	}
+	if c.conf.HolePunching {
+		opts = append(opts, libp2p.EnableHolePunching())
+	}
+	if c.conf.RelayService {
+		opts = append(opts, libp2p.EnableRelayService())
+	}
+	if c.conf.StaticRelays != "" {
+		relays := c.parseAddrInfoList(c.conf.StaticRelays)
+		if len(relays) == 0 {
+			return nil, errors.New("no valid static relays")
+		}
+		opts = append(opts, libp2p.EnableAutoRelayWithStaticRelays(relays))
+	}
+
+	return opts, nil
+}
+
+// parseListenAddrs parses comma separated list of multiaddrs.
+func parseListenAddrs(addrs string) ([]multiaddr.Multiaddr, error) {
+	var listenAddrs []multiaddr.Multiaddr
+	for _, addr := range strings.Split(addrs, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		maddr, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid listen address %q: %w", addr, err)
+		}
+		listenAddrs = append(listenAddrs, maddr)
+	}
+	if len(listenAddrs) == 0 {
+		return nil, errors.New("no listen addresses")
+	}
+	return listenAddrs, nil
+}
+
+// parsePSK decodes hex encoded pre-shared key.
+func parsePSK(key string) (pnet.PSK, error) {
+	psk, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private network key: %w", err)
+	}
+	if len(psk) != pskLength {
+		return nil, fmt.Errorf("invalid private network key: expected %d bytes, got %d", pskLength, len(psk))
+	}
+	return psk, nil
+}
+
+// isUDPAddr checks if multiaddr uses UDP based transport (e.g. QUIC, WebTransport or WebRTC).
+func isUDPAddr(maddr multiaddr.Multiaddr) bool {
+	_, err := maddr.ValueForProtocol(multiaddr.P_UDP)
+	return err == nil
+}
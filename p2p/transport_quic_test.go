@@ -0,0 +1,43 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+func TestQUICTransport(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/udp/0/quic-v1"}, nil)
+	client := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/udp/0/quic-v1"}, nil)
+
+	require.NoError(client.DialPeers(ctx, []string{p2pAddr(t, server, multiaddr.P_QUIC_V1)}, false))
+	conns := client.host.Network().ConnsToPeer(server.host.ID())
+	require.NotEmpty(conns)
+	_, err := conns[0].RemoteMultiaddr().ValueForProtocol(multiaddr.P_QUIC_V1)
+	assert.NoError(t, err)
+}
+
+func TestWebTransportTransport(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/udp/0/quic-v1/webtransport"}
+	server := startTransportTestClient(ctx, t, conf, nil)
+	client := startTransportTestClient(ctx, t, conf, nil)
+
+	require.NoError(client.DialPeers(ctx, []string{p2pAddr(t, server, multiaddr.P_WEBTRANSPORT)}, false))
+	conns := client.host.Network().ConnsToPeer(server.host.ID())
+	require.NotEmpty(conns)
+	_, err := conns[0].RemoteMultiaddr().ValueForProtocol(multiaddr.P_WEBTRANSPORT)
+	assert.NoError(t, err)
+}
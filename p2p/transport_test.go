@@ -0,0 +1,139 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func startTransportTestClient(ctx context.Context, t *testing.T, conf config.P2PConfig, txValidator GossipValidator) *Client {
+	t.Helper()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	client, err := NewClient(conf, privKey, "TestTransports", dssync.MutexWrap(datastore.NewMapDatastore()), test.NewLogger(t), NopMetrics())
+	require.NoError(t, err)
+	client.SetTxValidator(txValidator)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+// p2pAddr returns listen address of client using given protocol, with peer ID.
+func p2pAddr(t *testing.T, c *Client, protocol int) string {
+	t.Helper()
+	for _, addr := range c.Addrs() {
+		if _, err := addr.ValueForProtocol(protocol); err == nil {
+			return addr.String() + "/p2p/" + c.host.ID().String()
+		}
+	}
+	t.Fatalf("no address with protocol %d", protocol)
+	return ""
+}
+
+func TestMultipleTransports(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := config.P2PConfig{
+		ListenAddress: "/ip4/127.0.0.1/tcp/0,/ip4/127.0.0.1/udp/0/quic-v1,/ip4/127.0.0.1/udp/0/quic-v1/webtransport",
+	}
+	server := startTransportTestClient(ctx, t, conf, nil)
+	client := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0,/ip4/127.0.0.1/udp/0/quic-v1"}, nil)
+
+	protocols := map[int]bool{}
+	for _, addr := range server.Addrs() {
+		for _, p := range addr.Protocols() {
+			protocols[p.Code] = true
+		}
+	}
+	assert.True(protocols[multiaddr.P_TCP])
+	assert.True(protocols[multiaddr.P_QUIC_V1])
+	assert.True(protocols[multiaddr.P_WEBTRANSPORT])
+
+	require.NoError(client.DialPeers(ctx, []string{p2pAddr(t, server, multiaddr.P_TCP)}, false))
+	assert.Equal(network.Connected, client.host.Network().Connectedness(server.host.ID()))
+}
+
+func TestPrivateNetwork(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newKey := func() string {
+		key := make([]byte, pskLength)
+		_, err := rand.Read(key)
+		require.NoError(err)
+		return hex.EncodeToString(key)
+	}
+	key := newKey()
+
+	server := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", PrivateNetworkKey: key}, nil)
+	member := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", PrivateNetworkKey: key}, nil)
+	outsider := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", PrivateNetworkKey: newKey()}, nil)
+	public := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"}, nil)
+
+	addr := p2pAddr(t, server, multiaddr.P_TCP)
+	require.NoError(member.DialPeers(ctx, []string{addr}, false))
+	assert.Equal(network.Connected, member.host.Network().Connectedness(server.host.ID()))
+
+	dialCtx, dialCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer dialCancel()
+	assert.Error(outsider.DialPeers(dialCtx, []string{addr}, false))
+	assert.Error(public.DialPeers(dialCtx, []string{addr}, false))
+}
+
+func TestHostOptions(t *testing.T) {
+	t.Parallel()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	validKey := hex.EncodeToString(make([]byte, pskLength))
+	relay := "/ip4/127.0.0.1/tcp/7676/p2p/12D3KooWM1NFkZozoatQi3JvFE57eBaX56mNgBA68Lk5MTPxBE4U"
+
+	cases := []struct {
+		name    string
+		conf    config.P2PConfig
+		wantErr bool
+	}{
+		{"tcp", config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"}, false},
+		{"invalid listen address", config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0,invalid"}, true},
+		{"empty listen address", config.P2PConfig{ListenAddress: ","}, true},
+		{"private network", config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", PrivateNetworkKey: validKey}, false},
+		{"private network with quic", config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/udp/0/quic-v1", PrivateNetworkKey: validKey}, true},
+		{"invalid private network key", config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", PrivateNetworkKey: "abcd"}, true},
+		{"nat traversal", config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", NATPortMap: true, HolePunching: true, RelayService: true, StaticRelays: relay}, false},
+		{"invalid static relays", config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0", StaticRelays: "invalid"}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client, err := NewClient(c.conf, privKey, "TestHostOptions", dssync.MutexWrap(datastore.NewMapDatastore()), test.NewLogger(t), NopMetrics())
+			require.NoError(t, err)
+			h, err := client.listen(context.Background())
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, h.Addrs())
+			assert.NoError(t, h.Close())
+		})
+	}
+}
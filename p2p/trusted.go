@@ -0,0 +1,57 @@
+package p2p
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+)
+
+const (
+	// trustedPeerTag is used to protect connections with trusted peers from connection manager trimming.
+	trustedPeerTag = "rollkit-trusted"
+
+	// trustedPeersReconnectInterval defines how often connectivity with trusted peers is checked.
+	trustedPeersReconnectInterval = 5 * time.Second
+)
+
+// TrustedPeerIDs returns IDs of trusted peers (e.g. sequencer), in order of configuration.
+func (c *Client) TrustedPeerIDs() []peer.ID {
+	ids := make([]peer.ID, 0, len(c.trustedPeers))
+	for _, p := range c.trustedPeers {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+// setupTrustedPeers protects connections with trusted peers from trimming, and stores their addresses permanently.
+func (c *Client) setupTrustedPeers() {
+	for _, p := range c.trustedPeers {
+		c.host.ConnManager().Protect(p.ID, trustedPeerTag)
+		c.host.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.PermanentAddrTTL)
+	}
+}
+
+// maintainTrustedPeers keeps connections with trusted peers, reconnecting whenever they are lost.
+func (c *Client) maintainTrustedPeers(ctx context.Context) {
+	if len(c.trustedPeers) == 0 {
+		return
+	}
+	ticker := time.NewTicker(trustedPeersReconnectInterval)
+	defer ticker.Stop()
+	for {
+		for _, p := range c.trustedPeers {
+			if c.host.Network().Connectedness(p.ID) != network.Connected {
+				c.logger.Debug("connecting to trusted peer", "peer", p.ID)
+				go c.tryConnect(ctx, p)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
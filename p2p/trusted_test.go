@@ -0,0 +1,52 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+)
+
+func TestTrustedPeers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sequencer := startTransportTestClient(ctx, t, config.P2PConfig{ListenAddress: "/ip4/127.0.0.1/tcp/0"}, nil)
+	node := startTransportTestClient(ctx, t, config.P2PConfig{
+		ListenAddress: "/ip4/127.0.0.1/tcp/0",
+		TrustedPeers:  p2pAddr(t, sequencer, multiaddr.P_TCP),
+		BanThreshold:  -1,
+		BanDuration:   time.Hour,
+	}, nil)
+
+	seqID := sequencer.host.ID()
+	assert.Equal([]peer.ID{seqID}, node.TrustedPeerIDs())
+	assert.Empty(sequencer.TrustedPeerIDs())
+	assert.True(node.host.ConnManager().IsProtected(seqID, trustedPeerTag))
+
+	// trusted peers are never banned
+	for i := 0; i < 10; i++ {
+		node.reputation.reportInvalid(ctx, seqID, "test")
+	}
+	assert.False(node.reputation.isBanned(seqID))
+
+	// connection is established on start, and restored when lost
+	connected := func() bool {
+		return node.host.Network().Connectedness(seqID) == network.Connected
+	}
+	require.Eventually(connected, 5*time.Second, 50*time.Millisecond)
+	require.NoError(sequencer.host.Network().ClosePeer(node.host.ID()))
+	require.Eventually(func() bool {
+		return !connected()
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(connected, 2*trustedPeersReconnectInterval, 100*time.Millisecond)
+}
@@ -0,0 +1,214 @@
+package p2p
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+const (
+	// txMessageSingle marks gossiped messages containing a single transaction.
+	txMessageSingle byte = 1
+	// txMessageBatch marks gossiped messages containing a batch of uvarint length prefixed transactions.
+	txMessageBatch byte = 2
+
+	// maxTxBatchBytes limits the size of transactions gossiped in a single batch.
+	// It's kept below default max message size of pubsub (1 MiB).
+	maxTxBatchBytes = 512 << 10
+
+	// seenTxCacheSize defines how many accepted transactions are remembered to skip duplicated validation.
+	seenTxCacheSize = 10000
+)
+
+var (
+	errUnsupportedTxMessage = errors.New("unsupported tx message type")
+	errMalformedTxBatch     = errors.New("malformed tx batch")
+)
+
+// encodeTx encodes single transaction as gossiped message: message type, and then the transaction.
+func encodeTx(tx []byte) []byte {
+	data := make([]byte, 0, 1+len(tx))
+	data = append(data, txMessageSingle)
+	return append(data, tx...)
+}
+
+// encodeTxBatch encodes transactions as batch message: message type, and then uvarint length
+// prefixed transactions.
+func encodeTxBatch(txs [][]byte) []byte {
+	size := 1
+	for _, tx := range txs {
+		size += binary.MaxVarintLen64 + len(tx)
+	}
+	data := make([]byte, 0, size)
+	data = append(data, txMessageBatch)
+	for _, tx := range txs {
+		data = binary.AppendUvarint(data, uint64(len(tx)))
+		data = append(data, tx...)
+	}
+	return data
+}
+
+// decodeTxMessage decodes transactions from gossiped message, encoded with encodeTx or encodeTxBatch.
+func decodeTxMessage(data []byte) ([][]byte, error) {
+	if len(data) == 0 {
+		return nil, errUnsupportedTxMessage
+	}
+	switch data[0] {
+	case txMessageSingle:
+		if len(data) == 1 {
+			return nil, errMalformedTxBatch
+		}
+		return [][]byte{data[1:]}, nil
+	case txMessageBatch:
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedTxMessage, data[0])
+	}
+	data = data[1:]
+
+	var txs [][]byte
+	for len(data) > 0 {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || length == 0 || length > uint64(len(data)-n) {
+			return nil, errMalformedTxBatch
+		}
+		data = data[n:]
+		txs = append(txs, data[:length])
+		data = data[length:]
+	}
+	if len(txs) == 0 {
+		return nil, errMalformedTxBatch
+	}
+	return txs, nil
+}
+
+// pendingTxBatch is a batch of transactions waiting to be gossiped.
+type pendingTxBatch struct {
+	txs  [][]byte
+	size int
+
+	// published is closed after batch is published; err holds the result
+	published chan struct{}
+	err       error
+}
+
+// txBatcher buffers gossiped transactions and publishes them in batches, every interval
+// or as soon as batch reaches maxTxBatchBytes.
+type txBatcher struct {
+	ctx      context.Context
+	interval time.Duration
+	publish  func(ctx context.Context, data []byte) error
+
+	mtx     sync.Mutex
+	pending *pendingTxBatch
+}
+
+func newTxBatcher(ctx context.Context, interval time.Duration, publish func(context.Context, []byte) error) *txBatcher {
+	return &txBatcher{
+		ctx:      ctx,
+		interval: interval,
+		publish:  publish,
+	}
+}
+
+// add adds transaction to pending batch and waits until the batch is published.
+func (b *txBatcher) add(ctx context.Context, tx []byte) error {
+	b.mtx.Lock()
+	if b.pending != nil && b.pending.size+len(tx) > maxTxBatchBytes {
+		b.flushLocked()
+	}
+	if b.pending == nil {
+		batch := &pendingTxBatch{published: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(b.interval, func() {
+			b.mtx.Lock()
+			defer b.mtx.Unlock()
+			if b.pending == batch {
+				b.flushLocked()
+			}
+		})
+	}
+	batch := b.pending
+	batch.txs = append(batch.txs, tx)
+	batch.size += len(tx)
+	if batch.size >= maxTxBatchBytes {
+		b.flushLocked()
+	}
+	b.mtx.Unlock()
+
+	select {
+	case <-batch.published:
+		return batch.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flushLocked publishes pending batch in background. It must be called with mtx locked.
+func (b *txBatcher) flushLocked() {
+	batch := b.pending
+	b.pending = nil
+	go func() {
+		batch.err = b.publish(b.ctx, encodeTxBatch(batch.txs))
+		close(batch.published)
+	}()
+}
+
+// txKey returns key of transaction used in cache of seen transactions.
+func txKey(tx []byte) [sha256.Size]byte {
+	return sha256.Sum256(tx)
+}
+
+// newSeenTxCache creates LRU cache of transactions that were already accepted by validator.
+func newSeenTxCache() *lru.Cache[[sha256.Size]byte, struct{}] {
+	cache, err := lru.New[[sha256.Size]byte, struct{}](seenTxCacheSize)
+	if err != nil {
+		// only possible with non-positive size
+		panic(err)
+	}
+	return cache
+}
+
+// validateTxs is a pubsub validator for transaction topic. It decodes transactions from gossiped
+// message and runs txValidator for every transaction that wasn't accepted before.
+// Message is accepted (and propagated) if any of its transactions is new and valid. Otherwise, message
+// is rejected if it can't be decoded or any transaction was rejected by txValidator, and ignored if not.
+// Transactions published by the node itself are always passed to txValidator, so they can be published
+// again, even if they were seen before.
+func (c *Client) validateTxs(m *GossipMessage) pubsub.ValidationResult {
+	txs, err := decodeTxMessage(m.Data)
+	if err != nil {
+		c.logger.Debug("failed to decode gossiped transactions", "peer", m.From, "error", err)
+		return pubsub.ValidationReject
+	}
+	fromSelf := c.host != nil && m.From == c.host.ID()
+	accepted, rejected := false, false
+	for _, tx := range txs {
+		key := txKey(tx)
+		if !fromSelf && c.seenTxs.Contains(key) {
+			c.metrics.DuplicateTxs.Add(1)
+			continue
+		}
+		switch c.txValidator(&GossipMessage{Data: tx, From: m.From}) {
+		case pubsub.ValidationAccept:
+			c.seenTxs.Add(key, struct{}{})
+			accepted = true
+		case pubsub.ValidationReject:
+			rejected = true
+		}
+	}
+	switch {
+	case accepted:
+		return pubsub.ValidationAccept
+	case rejected:
+		return pubsub.ValidationReject
+	default:
+		return pubsub.ValidationIgnore
+	}
+}
@@ -0,0 +1,140 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/config"
+	test "github.com/rollkit/rollkit/test/log"
+)
+
+func TestTxBatchEncoding(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	txs := [][]byte{[]byte("tx1"), make([]byte, 300), []byte("tx3")}
+	decoded, err := decodeTxMessage(encodeTxBatch(txs))
+	assert.NoError(err)
+	assert.Equal(txs, decoded)
+
+	decoded, err = decodeTxMessage(encodeTx([]byte("single tx")))
+	assert.NoError(err)
+	assert.Equal([][]byte{[]byte("single tx")}, decoded)
+
+	// single transaction that looks like a batch is not mistaken for one
+	batchLike := encodeTxBatch([][]byte{[]byte("tx")})
+	decoded, err = decodeTxMessage(encodeTx(batchLike))
+	assert.NoError(err)
+	assert.Equal([][]byte{batchLike}, decoded)
+
+	// messages without known type are not accepted
+	for _, data := range [][]byte{nil, []byte("raw tx"), {txMessageBatch + 1, 1, 0}} {
+		_, err = decodeTxMessage(data)
+		assert.ErrorIs(err, errUnsupportedTxMessage)
+	}
+
+	encoded := encodeTxBatch(txs)
+	for _, data := range [][]byte{
+		{txMessageSingle},
+		encodeTxBatch(nil),
+		encoded[:len(encoded)-1],
+		append(encodeTxBatch(nil), 0),
+	} {
+		_, err = decodeTxMessage(data)
+		assert.ErrorIs(err, errMalformedTxBatch)
+	}
+}
+
+func TestTxBatcher(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mtx sync.Mutex
+	var published [][][]byte
+	batcher := newTxBatcher(ctx, 50*time.Millisecond, func(_ context.Context, data []byte) error {
+		txs, err := decodeTxMessage(data)
+		assert.NoError(err)
+		mtx.Lock()
+		defer mtx.Unlock()
+		published = append(published, txs)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(batcher.add(ctx, []byte{byte(i)}))
+		}()
+	}
+	wg.Wait()
+	assert.Len(published, 1)
+	assert.Len(published[0], 10)
+
+	// batch is flushed without waiting for interval once it reaches size limit
+	published = nil
+	start := time.Now()
+	assert.NoError(batcher.add(ctx, make([]byte, maxTxBatchBytes)))
+	assert.Less(time.Since(start), 50*time.Millisecond)
+	assert.Len(published, 1)
+
+	// publishing errors are returned to all callers
+	batcher.publish = func(context.Context, []byte) error {
+		return errors.New("publish failed")
+	}
+	assert.Error(batcher.add(ctx, []byte("tx")))
+}
+
+func TestValidateTxsDuplicates(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	metrics := NopMetrics()
+	duplicates := generic.NewCounter("duplicate_txs")
+	metrics.DuplicateTxs = duplicates
+	client, err := NewClient(config.P2PConfig{}, privKey, "TestValidateTxsDuplicates", dssync.MutexWrap(datastore.NewMapDatastore()), test.NewLogger(t), metrics)
+	require.NoError(err)
+
+	checked := map[string]int{}
+	client.SetTxValidator(func(m *GossipMessage) pubsub.ValidationResult {
+		checked[string(m.Data)]++
+		if string(m.Data) == "invalid" {
+			return pubsub.ValidationIgnore
+		}
+		return pubsub.ValidationAccept
+	})
+
+	assert.Equal(pubsub.ValidationAccept, client.validateTxs(&GossipMessage{Data: encodeTxBatch([][]byte{[]byte("tx1"), []byte("tx2")})}))
+	// messages without new transactions are not propagated
+	assert.Equal(pubsub.ValidationIgnore, client.validateTxs(&GossipMessage{Data: encodeTx([]byte("tx1"))}))
+	assert.Equal(pubsub.ValidationAccept, client.validateTxs(&GossipMessage{Data: encodeTxBatch([][]byte{[]byte("tx2"), []byte("tx3")})}))
+	assert.Equal(pubsub.ValidationIgnore, client.validateTxs(&GossipMessage{Data: encodeTxBatch([][]byte{[]byte("tx3"), []byte("invalid")})}))
+	// batch is accepted if any of transactions is new and valid
+	assert.Equal(pubsub.ValidationAccept, client.validateTxs(&GossipMessage{Data: encodeTxBatch([][]byte{[]byte("invalid"), []byte("tx4")})}))
+	// invalid transactions are not cached
+	assert.Equal(pubsub.ValidationIgnore, client.validateTxs(&GossipMessage{Data: encodeTx([]byte("invalid"))}))
+	// malformed messages are rejected
+	assert.Equal(pubsub.ValidationReject, client.validateTxs(&GossipMessage{Data: []byte("raw tx")}))
+	assert.Equal(pubsub.ValidationReject, client.validateTxs(&GossipMessage{Data: []byte{txMessageBatch}}))
+
+	assert.Equal(map[string]int{"tx1": 1, "tx2": 1, "tx3": 1, "tx4": 1, "invalid": 3}, checked)
+	assert.Equal(3.0, duplicates.Value())
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+
+	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/rpc/json"
+)
+
+// AdminServer handles admin-only JSON-RPC requests, used to manage peers of the node at runtime.
+//
+// AdminServer should listen on a separate, trusted address (e.g. loopback interface), as it
+// exposes methods changing state of the node.
+type AdminServer struct {
+	*service.BaseService
+
+	address string
+	pm      json.PeerManager
+
+	server http.Server
+}
+
+// NewAdminServer creates new instance of AdminServer listening on given address.
+func NewAdminServer(node node.Node, address string, logger log.Logger) (*AdminServer, error) {
+	pm, ok := node.GetClient().(json.PeerManager)
+	if !ok {
+		return nil, fmt.Errorf("admin RPC is not supported by %T", node.GetClient())
+	}
+	srv := &AdminServer{
+		address: address,
+		pm:      pm,
+	}
+	srv.BaseService = service.NewBaseService(logger, "AdminRPC", srv)
+	return srv, nil
+}
+
+// OnStart is called when AdminServer is started (see service.BaseService for details).
+func (s *AdminServer) OnStart() error {
+	listener, err := listen(s.address)
+	if err != nil {
+		return err
+	}
+
+	handler, err := json.GetAdminHTTPHandler(s.pm, s.Logger)
+	if err != nil {
+		return err
+	}
+
+	s.server = http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: time.Second * 2,
+	}
+	go func() {
+		s.Logger.Info("serving admin HTTP", "listen address", listener.Addr())
+		err := s.server.Serve(listener)
+		if !errors.Is(err, http.ErrServerClosed) {
+			s.Logger.Error("error while serving admin HTTP", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// OnStop is called when AdminServer is stopped (see service.BaseService for details).
+func (s *AdminServer) OnStop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.Logger.Error("error while shutting down admin RPC server", "error", err)
+	}
+}
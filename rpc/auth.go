@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"errors"
+	"strings"
+
+	rollconf "github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/rpc/json"
+)
+
+// AccessControlFromConfig creates JSON-RPC access control from node RPC configuration.
+func AccessControlFromConfig(conf rollconf.RPCConfig) (json.AccessControl, error) {
+	ac := json.AccessControl{
+		AllowedMethods: splitList(conf.AllowedMethods, ","),
+		DeniedMethods:  splitList(conf.DeniedMethods, ","),
+		PublicMethods:  splitList(conf.PublicMethods, ","),
+	}
+	if conf.JWTSecret != "" {
+		ac.JWTSecret = []byte(conf.JWTSecret)
+	}
+	for _, entry := range splitList(conf.AuthTokens, ",") {
+		token, methods, _ := strings.Cut(entry, ":")
+		if token == "" {
+			return json.AccessControl{}, errors.New("empty RPC auth token")
+		}
+		if ac.Tokens == nil {
+			ac.Tokens = make(map[string][]string)
+		}
+		ac.Tokens[token] = splitList(methods, "|")
+	}
+	return ac, nil
+}
+
+// splitList splits s by sep, trimming spaces and skipping empty elements.
+func splitList(s string, sep string) []string {
+	var list []string
+	for _, e := range strings.Split(s, sep) {
+		if e = strings.TrimSpace(e); e != "" {
+			list = append(list, e)
+		}
+	}
+	return list
+}
@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	"google.golang.org/grpc"
+
+	"github.com/rollkit/rollkit/node"
+	rpcgrpc "github.com/rollkit/rollkit/rpc/grpc"
+)
+
+// GRPCServer handles gRPC requests, exposing typed and streaming equivalent of the JSON-RPC API.
+type GRPCServer struct {
+	*service.BaseService
+
+	address string
+	client  rpcclient.Client
+
+	opts   []grpc.ServerOption
+	server *grpc.Server
+}
+
+// NewGRPCServer creates new instance of GRPCServer listening on given address.
+func NewGRPCServer(node node.Node, address string, logger log.Logger, opts ...grpc.ServerOption) *GRPCServer {
+	srv := &GRPCServer{
+		address: address,
+		client:  node.GetClient(),
+		opts:    opts,
+	}
+	srv.BaseService = service.NewBaseService(logger, "gRPC", srv)
+	return srv
+}
+
+// OnStart is called when GRPCServer is started (see service.BaseService for details).
+func (s *GRPCServer) OnStart() error {
+	listener, err := listen(s.address)
+	if err != nil {
+		return err
+	}
+
+	s.server = rpcgrpc.NewServer(s.client, s.Logger, s.opts...)
+	go func() {
+		s.Logger.Info("serving gRPC", "listen address", listener.Addr())
+		if err := s.server.Serve(listener); err != nil {
+			s.Logger.Error("error while serving gRPC", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// OnStop is called when GRPCServer is stopped (see service.BaseService for details).
+func (s *GRPCServer) OnStop() {
+	s.server.GracefulStop()
+}
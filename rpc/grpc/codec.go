@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// gogoMessage is implemented by messages generated with gogoproto.
+type gogoMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// codec marshals messages generated with gogoproto (including CometBFT types), which are not supported
+// by default gRPC codec. Encoding is standard protobuf, so clients can use any protobuf implementation.
+type codec struct{}
+
+var _ encoding.Codec = codec{}
+
+// Codec returns gRPC codec that should be used by Go clients using generated rollkit types,
+// e.g. grpc.WithDefaultCallOptions(grpc.ForceCodec(Codec())).
+func Codec() encoding.Codec {
+	return codec{}
+}
+
+// Marshal returns the wire format of v.
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(gogoMessage)
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal, message is %T, want gogoproto message", v)
+	}
+	return m.Marshal()
+}
+
+// Unmarshal parses the wire format into v.
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(gogoMessage)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal, message is %T, want gogoproto message", v)
+	}
+	return m.Unmarshal(data)
+}
+
+// Name returns name of the codec. Encoding is compatible with default codec, so the same name is used.
+func (codec) Name() string {
+	return "proto"
+}
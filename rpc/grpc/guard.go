@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/rollkit/rollkit/rpc/json"
+)
+
+// jsonMethods maps gRPC methods to equivalent JSON-RPC methods, so access control and rate limits
+// configured for JSON-RPC apply to gRPC calls as well.
+var jsonMethods = map[string]string{
+	"/rollkit.RPC/Status":             "status",
+	"/rollkit.RPC/GetBlock":           "block",
+	"/rollkit.RPC/GetHeader":          "header",
+	"/rollkit.RPC/GetTx":              "tx",
+	"/rollkit.RPC/GetDAInclusion":     "rollkit_da_status",
+	"/rollkit.RPC/SubscribeNewBlocks": "subscribe",
+}
+
+// WithCallGuard returns server options authorizing and rate limiting every call with given guard.
+func WithCallGuard(guard *json.CallGuard) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := checkCall(ctx, guard, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := checkCall(ss.Context(), guard, info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// checkCall verifies that caller is allowed to call given gRPC method, and converts rejection to gRPC status.
+func checkCall(ctx context.Context, guard *json.CallGuard, fullMethod string) error {
+	method, ok := jsonMethods[fullMethod]
+	if !ok {
+		method = fullMethod
+	}
+	var authorization, remoteAddr string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	err := guard.Check(authorization, remoteAddr, method)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, json.ErrUnauthorized):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, json.ErrMethodNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, json.ErrRateLimited):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
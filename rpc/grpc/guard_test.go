@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rollkit/rollkit/rpc/json"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+func TestCallGuard(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	client := &mocks.Client{}
+	client.On("Status", mock.Anything).Return(&ctypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{DefaultNodeID: "node"},
+	}, nil)
+	client.On("Subscribe", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((<-chan ctypes.ResultEvent)(make(chan ctypes.ResultEvent)), nil)
+	client.On("Unsubscribe", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	guard := json.NewCallGuard(json.AccessControl{
+		PublicMethods: []string{"status"},
+		Tokens:        map[string][]string{"secret": nil},
+	}, json.RateLimit{CostPerSecond: 0.1, Burst: 10})
+	rpc := startTestServer(t, client, WithCallGuard(guard)...)
+
+	anonymous := context.Background()
+	authenticated := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	invalidToken := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+
+	// public method is available without authentication
+	_, err := rpc.Status(anonymous, &pb.StatusRequest{})
+	require.NoError(err)
+
+	_, err = rpc.GetBlock(anonymous, &pb.GetBlockRequest{Height: 1})
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = rpc.Status(invalidToken, &pb.StatusRequest{})
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+
+	// streams are guarded as well
+	stream, err := rpc.SubscribeNewBlocks(anonymous, &pb.SubscribeNewBlocksRequest{})
+	require.NoError(err)
+	_, err = stream.Recv()
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+
+	// rejected calls don't consume quota; anonymous client uses the rest of it, authenticated client has its own
+	for i := 0; i < 9; i++ {
+		_, err = rpc.Status(anonymous, &pb.StatusRequest{})
+		require.NoError(err)
+	}
+	_, err = rpc.Status(anonymous, &pb.StatusRequest{})
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+	_, err = rpc.Status(authenticated, &pb.StatusRequest{})
+	require.NoError(err)
+}
+
+func TestCallGuardAllowedMethods(t *testing.T) {
+	client := &mocks.Client{}
+	guard := json.NewCallGuard(json.AccessControl{AllowedMethods: []string{"status"}}, json.RateLimit{})
+	rpc := startTestServer(t, client, WithCallGuard(guard)...)
+
+	_, err := rpc.GetTx(context.Background(), &pb.GetTxRequest{Hash: []byte{1}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
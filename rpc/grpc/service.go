@@ -0,0 +1,228 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/third_party/log"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// newBlocksBufferSize is the capacity of subscription channel of a single SubscribeNewBlocks stream.
+const newBlocksBufferSize = 100
+
+// NewServer returns gRPC server exposing RPC service backed by given client.
+func NewServer(client rpcclient.Client, logger log.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(append([]grpc.ServerOption{grpc.ForceServerCodec(codec{})}, opts...)...)
+	pb.RegisterRPCServer(srv, newService(client, logger))
+	return srv
+}
+
+type service struct {
+	client rpcclient.Client
+	logger log.Logger
+
+	// subscriptions is used to generate unique subscriber IDs
+	subscriptions atomic.Uint64
+}
+
+var _ pb.RPCServer = &service{}
+
+func newService(client rpcclient.Client, logger log.Logger) *service {
+	return &service{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Status returns current status of the node.
+func (s *service) Status(ctx context.Context, _ *pb.StatusRequest) (*pb.StatusResponse, error) {
+	res, err := s.client.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StatusResponse{
+		NodeId:              string(res.NodeInfo.DefaultNodeID),
+		Network:             res.NodeInfo.Network,
+		Version:             res.NodeInfo.Version,
+		LatestBlockHash:     res.SyncInfo.LatestBlockHash,
+		LatestAppHash:       res.SyncInfo.LatestAppHash,
+		LatestBlockHeight:   res.SyncInfo.LatestBlockHeight,
+		LatestBlockTime:     res.SyncInfo.LatestBlockTime,
+		EarliestBlockHash:   res.SyncInfo.EarliestBlockHash,
+		EarliestAppHash:     res.SyncInfo.EarliestAppHash,
+		EarliestBlockHeight: res.SyncInfo.EarliestBlockHeight,
+		EarliestBlockTime:   res.SyncInfo.EarliestBlockTime,
+		CatchingUp:          res.SyncInfo.CatchingUp,
+	}, nil
+}
+
+// GetBlock returns block with given height or hash.
+func (s *service) GetBlock(ctx context.Context, req *pb.GetBlockRequest) (*pb.GetBlockResponse, error) {
+	var (
+		res *ctypes.ResultBlock
+		err error
+	)
+	if len(req.Hash) > 0 {
+		res, err = s.client.BlockByHash(ctx, req.Hash)
+	} else {
+		res, err = s.client.Block(ctx, heightPtr(req.Height))
+	}
+	if err != nil {
+		return nil, err
+	}
+	blockID, block, err := blockToProto(res.BlockID, res.Block)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetBlockResponse{BlockId: blockID, Block: block}, nil
+}
+
+// GetHeader returns header of block with given height or hash.
+func (s *service) GetHeader(ctx context.Context, req *pb.GetHeaderRequest) (*pb.GetHeaderResponse, error) {
+	var (
+		res *ctypes.ResultHeader
+		err error
+	)
+	if len(req.Hash) > 0 {
+		res, err = s.client.HeaderByHash(ctx, req.Hash)
+	} else {
+		res, err = s.client.Header(ctx, heightPtr(req.Height))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res.Header == nil {
+		return nil, status.Error(codes.NotFound, "header not found")
+	}
+	header := res.Header.ToProto()
+	return &pb.GetHeaderResponse{Header: header}, nil
+}
+
+// GetTx returns transaction with given hash, with execution result.
+func (s *service) GetTx(ctx context.Context, req *pb.GetTxRequest) (*pb.GetTxResponse, error) {
+	if len(req.Hash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty transaction hash")
+	}
+	res, err := s.client.Tx(ctx, req.Hash, req.Prove)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetTxResponse{
+		Hash:     res.Hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: &res.TxResult,
+		Tx:       res.Tx,
+	}
+	if req.Prove {
+		proof := res.Proof.ToProto()
+		resp.Proof = &proof
+	}
+	return resp, nil
+}
+
+// daStatusProvider is implemented by clients of nodes tracking inclusion of blocks in DA layer.
+type daStatusProvider interface {
+	DAStatus(ctx context.Context) (*node.ResultDAStatus, error)
+}
+
+// GetDAInclusion returns whether block with given height is included in DA layer.
+func (s *service) GetDAInclusion(ctx context.Context, req *pb.GetDAInclusionRequest) (*pb.GetDAInclusionResponse, error) {
+	if req.Height <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "height must be greater than 0")
+	}
+	p, ok := s.client.(daStatusProvider)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "DA inclusion is not supported by %T", s.client)
+	}
+	res, err := p.DAStatus(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to get DA included height: %v", err)
+	}
+	// DA included height is 0 until the first block is included in DA layer
+	daIncludedHeight := int64(res.DAIncludedHeight)
+	return &pb.GetDAInclusionResponse{
+		Height:           req.Height,
+		Included:         daIncludedHeight > 0 && req.Height <= daIncludedHeight,
+		DaIncludedHeight: daIncludedHeight,
+	}, nil
+}
+
+// SubscribeNewBlocks streams blocks as they are produced (or synced) by the node.
+func (s *service) SubscribeNewBlocks(_ *pb.SubscribeNewBlocksRequest, stream pb.RPC_SubscribeNewBlocksServer) error {
+	ctx := stream.Context()
+	subscriber := fmt.Sprintf("grpc-%d", s.subscriptions.Add(1))
+	if p, ok := peer.FromContext(ctx); ok {
+		subscriber = fmt.Sprintf("%s-%s", subscriber, p.Addr)
+	}
+	query := cmtypes.EventQueryNewBlock.String()
+
+	events, err := s.client.Subscribe(ctx, subscriber, query, newBlocksBufferSize)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to subscribe: %v", err)
+	}
+	defer func() {
+		// stream context is already done, so a new one is required
+		if err := s.client.Unsubscribe(context.Background(), subscriber, query); err != nil {
+			s.logger.Error("failed to unsubscribe", "subscriber", subscriber, "error", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Aborted, "subscription was cancelled")
+			}
+			if terminated, ok := event.Data.(node.EventDataSubscriptionTerminated); ok {
+				return status.Errorf(codes.Aborted, "subscription was cancelled: %s (last height: %d)", terminated.Reason, terminated.LastHeight)
+			}
+			data, ok := event.Data.(cmtypes.EventDataNewBlock)
+			if !ok {
+				s.logger.Error("unexpected event data", "type", fmt.Sprintf("%T", event.Data))
+				continue
+			}
+			blockID, block, err := blockToProto(data.BlockID, data.Block)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(&pb.SubscribeNewBlocksResponse{BlockId: blockID, Block: block}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// heightPtr converts height from request to height accepted by rpcclient.Client (nil means latest).
+func heightPtr(height int64) *int64 {
+	if height == 0 {
+		return nil
+	}
+	return &height
+}
+
+func blockToProto(blockID cmtypes.BlockID, block *cmtypes.Block) (*cmproto.BlockID, *cmproto.Block, error) {
+	if block == nil {
+		return nil, nil, status.Error(codes.NotFound, "block not found")
+	}
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert block: %w", err)
+	}
+	pbBlockID := blockID.ToProto()
+	return &pbBlockID, pbBlock, nil
+}
@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rollkit/rollkit/node"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+func startTestServer(t *testing.T, client rpcclient.Client, opts ...grpc.ServerOption) pb.RPCClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	srv := NewServer(client, log.TestingLogger(), opts...)
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(Codec())),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return pb.NewRPCClient(conn)
+}
+
+func testBlock(height int64) *ctypes.ResultBlock {
+	block := cmtypes.MakeBlock(height, []cmtypes.Tx{[]byte("tx1"), []byte("tx2")}, &cmtypes.Commit{}, nil)
+	block.ChainID = "test"
+	block.Time = time.Now().UTC()
+	// header without validators hash has no hash
+	block.ValidatorsHash = make([]byte, 32)
+	return &ctypes.ResultBlock{
+		BlockID: cmtypes.BlockID{Hash: block.Hash()},
+		Block:   block,
+	}
+}
+
+// daStatusClient is a mock client reporting DA included height.
+type daStatusClient struct {
+	*mocks.Client
+	daIncludedHeight uint64
+}
+
+func (c *daStatusClient) DAStatus(context.Context) (*node.ResultDAStatus, error) {
+	return &node.ResultDAStatus{DAIncludedHeight: c.daIncludedHeight}, nil
+}
+
+func TestQueries(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	client := &mocks.Client{}
+	rpc := startTestServer(t, client)
+
+	now := time.Now().UTC()
+	client.On("Status", mock.Anything).Return(&ctypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{DefaultNodeID: "node", Network: "test"},
+		SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10, LatestBlockTime: now},
+	}, nil)
+	statusResp, err := rpc.Status(ctx, &pb.StatusRequest{})
+	require.NoError(err)
+	assert.Equal("node", statusResp.NodeId)
+	assert.Equal(int64(10), statusResp.LatestBlockHeight)
+	assert.True(now.Equal(statusResp.LatestBlockTime))
+
+	block := testBlock(5)
+	client.On("Block", mock.Anything, mock.MatchedBy(func(h *int64) bool { return h != nil && *h == 5 })).Return(block, nil)
+	blockResp, err := rpc.GetBlock(ctx, &pb.GetBlockRequest{Height: 5})
+	require.NoError(err)
+	assert.Equal(int64(5), blockResp.Block.Header.Height)
+	assert.Len(blockResp.Block.Data.Txs, 2)
+	assert.Equal([]byte(block.Block.Hash()), blockResp.BlockId.Hash)
+
+	client.On("BlockByHash", mock.Anything, []byte(block.Block.Hash())).Return(block, nil)
+	blockResp, err = rpc.GetBlock(ctx, &pb.GetBlockRequest{Hash: block.Block.Hash()})
+	require.NoError(err)
+	assert.Equal(int64(5), blockResp.Block.Header.Height)
+
+	// latest header is requested with nil height
+	client.On("Header", mock.Anything, (*int64)(nil)).Return(&ctypes.ResultHeader{Header: &block.Block.Header}, nil)
+	headerResp, err := rpc.GetHeader(ctx, &pb.GetHeaderRequest{})
+	require.NoError(err)
+	assert.Equal(int64(5), headerResp.Header.Height)
+
+	tx := cmtypes.Tx("tx1")
+	client.On("Tx", mock.Anything, []byte(tx.Hash()), false).Return(&ctypes.ResultTx{
+		Hash:     tx.Hash(),
+		Height:   5,
+		Tx:       tx,
+		TxResult: abci.ExecTxResult{Code: 1, Log: "failed"},
+	}, nil)
+	txResp, err := rpc.GetTx(ctx, &pb.GetTxRequest{Hash: tx.Hash()})
+	require.NoError(err)
+	assert.Equal(int64(5), txResp.Height)
+	assert.Equal([]byte(tx), txResp.Tx)
+	assert.Equal(uint32(1), txResp.TxResult.Code)
+	assert.Nil(txResp.Proof)
+
+	_, err = rpc.GetTx(ctx, &pb.GetTxRequest{})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	// DA inclusion requires client tracking DA included height
+	_, err = rpc.GetDAInclusion(ctx, &pb.GetDAInclusionRequest{Height: 3})
+	assert.Equal(codes.Unimplemented, status.Code(err))
+}
+
+func TestGetDAInclusion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	client := &daStatusClient{Client: &mocks.Client{}}
+	rpc := startTestServer(t, client)
+
+	// nothing is included in DA layer yet
+	daResp, err := rpc.GetDAInclusion(ctx, &pb.GetDAInclusionRequest{Height: 1})
+	require.NoError(err)
+	assert.False(daResp.Included)
+	assert.Equal(int64(0), daResp.DaIncludedHeight)
+
+	client.daIncludedHeight = 3
+	daResp, err = rpc.GetDAInclusion(ctx, &pb.GetDAInclusionRequest{Height: 3})
+	require.NoError(err)
+	assert.True(daResp.Included)
+	assert.Equal(int64(3), daResp.DaIncludedHeight)
+	daResp, err = rpc.GetDAInclusion(ctx, &pb.GetDAInclusionRequest{Height: 4})
+	require.NoError(err)
+	assert.False(daResp.Included)
+
+	_, err = rpc.GetDAInclusion(ctx, &pb.GetDAInclusionRequest{})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestSubscribeNewBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &mocks.Client{}
+	rpc := startTestServer(t, client)
+
+	events := make(chan ctypes.ResultEvent, 2)
+	query := cmtypes.EventQueryNewBlock.String()
+	client.On("Subscribe", mock.Anything, mock.Anything, query, newBlocksBufferSize).Return((<-chan ctypes.ResultEvent)(events), nil)
+	unsubscribed := make(chan struct{})
+	client.On("Unsubscribe", mock.Anything, mock.Anything, query).Return(nil).Run(func(mock.Arguments) {
+		close(unsubscribed)
+	})
+
+	for _, height := range []int64{1, 2} {
+		block := testBlock(height)
+		events <- ctypes.ResultEvent{Query: query, Data: cmtypes.EventDataNewBlock{Block: block.Block, BlockID: block.BlockID}}
+	}
+
+	stream, err := rpc.SubscribeNewBlocks(ctx, &pb.SubscribeNewBlocksRequest{})
+	require.NoError(err)
+	for _, height := range []int64{1, 2} {
+		resp, err := stream.Recv()
+		require.NoError(err)
+		assert.Equal(height, resp.Block.Header.Height)
+	}
+
+	// subscription is cancelled when client goes away
+	cancel()
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("subscriber was not unsubscribed")
+	}
+}
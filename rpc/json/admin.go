@@ -0,0 +1,90 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+// PeerManager is implemented by clients of nodes allowing to manage peers at runtime.
+type PeerManager interface {
+	// DialPeers connects to peers given as multiaddrs with peer ID.
+	DialPeers(ctx context.Context, peers []string, persistent bool) error
+	// BlockPeer blocks connections with given peer.
+	BlockPeer(ctx context.Context, peer string, persistent bool) error
+	// UnblockPeer lifts the block of given peer.
+	UnblockPeer(ctx context.Context, peer string) error
+	// BlockedPeers returns IDs of blocked peers.
+	BlockedPeers() []string
+}
+
+// GetAdminHTTPHandler returns handler configured to serve admin-only RPC methods.
+//
+// Admin methods change state of the node, so the handler should be exposed only on a separate,
+// trusted listener.
+func GetAdminHTTPHandler(pm PeerManager, logger log.Logger) (http.Handler, error) {
+	return newHandler(newAdminService(pm, logger), json2.NewCodec(), logger), nil
+}
+
+type adminService struct {
+	pm     PeerManager
+	logger log.Logger
+}
+
+func newAdminService(pm PeerManager, logger log.Logger) *service {
+	a := adminService{
+		pm:     pm,
+		logger: logger,
+	}
+	return &service{
+		logger: logger,
+		methods: map[string]*method{
+			"dial_peers":   newMethod(a.DialPeers),
+			"block_peer":   newMethod(a.BlockPeer),
+			"unblock_peer": newMethod(a.UnblockPeer),
+			"list_blocked": newMethod(a.ListBlocked),
+		},
+	}
+}
+
+func (a *adminService) DialPeers(req *http.Request, args *dialPeersArgs) (*ctypes.ResultDialPeers, error) {
+	if len(args.Peers) == 0 {
+		return nil, errors.New("no peers provided")
+	}
+	a.logger.Info("dialing peers", "peers", args.Peers, "persistent", isSet(args.Persistent))
+	if err := a.pm.DialPeers(req.Context(), args.Peers, isSet(args.Persistent)); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultDialPeers{Log: "Dialed peers successfully"}, nil
+}
+
+func (a *adminService) BlockPeer(req *http.Request, args *blockPeerArgs) (*emptyResult, error) {
+	if err := a.pm.BlockPeer(req.Context(), args.Peer, isSet(args.Persistent)); err != nil {
+		return nil, err
+	}
+	return &emptyResult{}, nil
+}
+
+func (a *adminService) UnblockPeer(req *http.Request, args *unblockPeerArgs) (*emptyResult, error) {
+	if err := a.pm.UnblockPeer(req.Context(), args.Peer); err != nil {
+		return nil, err
+	}
+	return &emptyResult{}, nil
+}
+
+func (a *adminService) ListBlocked(req *http.Request, args *listBlockedArgs) (*listBlockedResult, error) {
+	result := &listBlockedResult{Peers: a.pm.BlockedPeers()}
+	if c, ok := a.pm.(bannedPeersLister); ok {
+		result.BannedPeers = c.BannedPeers()
+	}
+	return result, nil
+}
+
+func isSet(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,103 @@
+package json
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/gorilla/rpc/v2/json2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPeerManager struct {
+	dialed     []string
+	persistent bool
+	blocked    []string
+}
+
+func (m *testPeerManager) DialPeers(_ context.Context, peers []string, persistent bool) error {
+	m.dialed = append(m.dialed, peers...)
+	m.persistent = persistent
+	return nil
+}
+
+func (m *testPeerManager) BlockPeer(_ context.Context, peer string, persistent bool) error {
+	m.blocked = append(m.blocked, peer)
+	m.persistent = persistent
+	return nil
+}
+
+func (m *testPeerManager) UnblockPeer(_ context.Context, peer string) error {
+	i := slices.Index(m.blocked, peer)
+	if i < 0 {
+		return errors.New("peer is not blocked")
+	}
+	m.blocked = slices.Delete(m.blocked, i, i+1)
+	return nil
+}
+
+func (m *testPeerManager) BlockedPeers() []string {
+	return m.blocked
+}
+
+func TestAdminHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	pm := &testPeerManager{}
+	handler, err := GetAdminHTTPHandler(pm, log.TestingLogger())
+	require.NoError(err)
+
+	call := func(method string, args interface{}) response {
+		jsonReq, err := json2.EncodeClientRequest(method, args)
+		require.NoError(err)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonReq)))
+		var jsonResp response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+		return jsonResp
+	}
+
+	persistent := true
+	resp := call("dial_peers", &dialPeersArgs{Peers: []string{"peer1", "peer2"}, Persistent: &persistent})
+	assert.Nil(resp.Error)
+	assert.Equal([]string{"peer1", "peer2"}, pm.dialed)
+	assert.True(pm.persistent)
+
+	resp = call("dial_peers", &dialPeersArgs{})
+	assert.NotNil(resp.Error)
+
+	resp = call("block_peer", &blockPeerArgs{Peer: "peer1"})
+	assert.Nil(resp.Error)
+	assert.False(pm.persistent)
+
+	// REST style request with comma separated list
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dial_peers?peers=peer3,peer4", nil))
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal([]string{"peer1", "peer2", "peer3", "peer4"}, pm.dialed)
+
+	resp = call("list_blocked", &listBlockedArgs{})
+	assert.Nil(resp.Error)
+	var blocked listBlockedResult
+	require.NoError(json.Unmarshal(resp.Result, &blocked))
+	assert.Equal([]string{"peer1"}, blocked.Peers)
+
+	resp = call("unblock_peer", &unblockPeerArgs{Peer: "peer1"})
+	assert.Nil(resp.Error)
+	assert.Empty(pm.blocked)
+
+	resp = call("unblock_peer", &unblockPeerArgs{Peer: "peer1"})
+	assert.NotNil(resp.Error)
+
+	// public methods are not available on admin handler
+	resp = call("status", &statusArgs{})
+	assert.NotNil(resp.Error)
+}
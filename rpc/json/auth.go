@@ -0,0 +1,185 @@
+package json
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrUnauthorized is returned when authentication is required, but request has no valid bearer token.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrMethodNotAllowed is returned when method is not allowed for the listener or the caller.
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+// AccessControl configures authentication and method-level authorization of RPC requests.
+//
+// Authentication is enabled if Tokens or JWTSecret are set. Callers authenticate with
+// "Authorization: Bearer <token>" header; unauthenticated callers can use only PublicMethods.
+type AccessControl struct {
+	// AllowedMethods limits methods served by the listener. All methods are allowed if empty.
+	AllowedMethods []string
+	// DeniedMethods are never served by the listener, even to authenticated callers.
+	DeniedMethods []string
+	// PublicMethods can be called without authentication.
+	PublicMethods []string
+	// Tokens maps static bearer tokens to methods allowed for them. Empty list allows all methods.
+	Tokens map[string][]string
+	// JWTSecret is the HMAC secret used to verify JWT bearer tokens. Methods allowed for a JWT can be
+	// limited with "methods" claim.
+	JWTSecret []byte
+}
+
+// WithAccessControl enables authentication and method-level authorization of requests.
+func WithAccessControl(ac AccessControl) HandlerOption {
+	return func(h *handler) {
+		h.access = newAccessController(ac)
+	}
+}
+
+type methodSet map[string]struct{}
+
+func newMethodSet(methods []string) methodSet {
+	if len(methods) == 0 {
+		return nil
+	}
+	set := make(methodSet, len(methods))
+	for _, m := range methods {
+		set[m] = struct{}{}
+	}
+	return set
+}
+
+func (s methodSet) has(method string) bool {
+	_, ok := s[method]
+	return ok
+}
+
+// jwtClaims are claims of JWT bearer tokens.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Methods []string `json:"methods,omitempty"`
+}
+
+// principal is the caller of RPC methods.
+type principal struct {
+	authenticated bool
+	// id identifies authenticated caller (e.g. for rate limiting)
+	id string
+	// methods allowed for the caller; nil means all methods
+	methods methodSet
+}
+
+type principalKey struct{}
+
+// principalFromContext returns caller stored in context, or anonymous caller.
+func principalFromContext(ctx context.Context) *principal {
+	if p, ok := ctx.Value(principalKey{}).(*principal); ok {
+		return p
+	}
+	return &principal{}
+}
+
+type accessController struct {
+	allowed methodSet
+	denied  methodSet
+	public  methodSet
+
+	tokens    map[string]methodSet
+	jwtSecret []byte
+}
+
+func newAccessController(ac AccessControl) *accessController {
+	a := &accessController{
+		allowed:   newMethodSet(ac.AllowedMethods),
+		denied:    newMethodSet(ac.DeniedMethods),
+		public:    newMethodSet(ac.PublicMethods),
+		tokens:    make(map[string]methodSet, len(ac.Tokens)),
+		jwtSecret: ac.JWTSecret,
+	}
+	for token, methods := range ac.Tokens {
+		a.tokens[token] = newMethodSet(methods)
+	}
+	return a
+}
+
+func (a *accessController) authEnabled() bool {
+	return len(a.tokens) > 0 || len(a.jwtSecret) > 0
+}
+
+// authenticate returns caller identified by bearer token of the request. Requests without token are anonymous.
+// If authentication is disabled, all requests are anonymous.
+func (a *accessController) authenticate(r *http.Request) (*principal, error) {
+	return a.authenticateHeader(r.Header.Get("Authorization"))
+}
+
+// authenticateHeader returns caller identified by value of Authorization header (see authenticate).
+func (a *accessController) authenticateHeader(header string) (*principal, error) {
+	if a == nil || !a.authEnabled() || header == "" {
+		return &principal{}, nil
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return nil, fmt.Errorf("%w: expected bearer token", ErrUnauthorized)
+	}
+
+	for t, methods := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return &principal{authenticated: true, id: t, methods: methods}, nil
+		}
+	}
+
+	if len(a.jwtSecret) > 0 {
+		var claims jwtClaims
+		_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+			return a.jwtSecret, nil
+		}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+		}
+		return &principal{authenticated: true, id: jwtPrincipalID(token, &claims), methods: newMethodSet(claims.Methods)}, nil
+	}
+
+	return nil, fmt.Errorf("%w: invalid token", ErrUnauthorized)
+}
+
+// jwtPrincipalID identifies caller authenticated with JWT by subject claim, or by the token itself
+// if subject is not set.
+func jwtPrincipalID(token string, claims *jwtClaims) string {
+	if claims.Subject != "" {
+		return "jwt:" + claims.Subject
+	}
+	hash := sha256.Sum256([]byte(token))
+	return "jwt-hash:" + hex.EncodeToString(hash[:])
+}
+
+// authorize checks if caller is allowed to call given method.
+func (a *accessController) authorize(p *principal, method string) error {
+	if a == nil {
+		return nil
+	}
+	if (a.allowed != nil && !a.allowed.has(method)) || a.denied.has(method) {
+		return fmt.Errorf("%w: %s", ErrMethodNotAllowed, method)
+	}
+	if !a.authEnabled() {
+		return nil
+	}
+	if !p.authenticated {
+		if a.public.has(method) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s requires authentication", ErrUnauthorized, method)
+	}
+	if p.methods != nil && !p.methods.has(method) {
+		return fmt.Errorf("%w: %s", ErrMethodNotAllowed, method)
+	}
+	return nil
+}
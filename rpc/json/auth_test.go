@@ -0,0 +1,138 @@
+package json
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/rpc/v2/json2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessControl(t *testing.T) {
+	require := require.New(t)
+
+	secret := []byte("secret")
+	signJWT := func(methods []string, expiresAt time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)},
+			Methods:          methods,
+		})
+		signed, err := token.SignedString(secret)
+		require.NoError(err)
+		return signed
+	}
+
+	_, local := getRPC(t, "TestAccessControl")
+	handler, err := GetHTTPHandler(local, log.TestingLogger(), WithAccessControl(AccessControl{
+		DeniedMethods: []string{"broadcast_tx_sync"},
+		PublicMethods: []string{"health"},
+		Tokens: map[string][]string{
+			"admin":  nil,
+			"reader": {"health", "genesis"},
+		},
+		JWTSecret: secret,
+	}))
+	require.NoError(err)
+
+	cases := []struct {
+		name   string
+		token  string
+		method string
+		// expected HTTP status code and JSON-RPC error code (0 means success)
+		status int
+		code   json2.ErrorCode
+	}{
+		{"public method without token", "", "health", http.StatusOK, 0},
+		{"private method without token", "", "genesis", http.StatusOK, json2.E_SERVER},
+		{"admin token", "admin", "genesis", http.StatusOK, 0},
+		{"limited token, allowed method", "reader", "genesis", http.StatusOK, 0},
+		{"limited token, other method", "reader", "net_info", http.StatusOK, json2.E_SERVER},
+		{"denied method", "admin", "broadcast_tx_sync", http.StatusOK, json2.E_SERVER},
+		{"invalid token", "invalid", "health", http.StatusUnauthorized, json2.E_SERVER},
+		{"JWT", signJWT(nil, time.Now().Add(time.Hour)), "genesis", http.StatusOK, 0},
+		{"JWT with methods claim", signJWT([]string{"health"}, time.Now().Add(time.Hour)), "genesis", http.StatusOK, json2.E_SERVER},
+		{"expired JWT", signJWT(nil, time.Now().Add(-time.Hour)), "health", http.StatusUnauthorized, json2.E_SERVER},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":1,"method":"` + c.method + `","params":{}}`
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			if c.token != "" {
+				req.Header.Set("Authorization", "Bearer "+c.token)
+			}
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			assert.Equal(t, c.status, resp.Code)
+
+			var jsonResp response
+			require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+			if c.code == 0 {
+				assert.Nil(t, jsonResp.Error)
+			} else {
+				require.NotNil(jsonResp.Error)
+				assert.Equal(t, c.code, jsonResp.Error.Code)
+			}
+		})
+	}
+
+	t.Run("REST", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/genesis", nil))
+		var jsonResp response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+		require.NotNil(jsonResp.Error)
+		assert.Contains(t, jsonResp.Error.Data, ErrUnauthorized.Error())
+
+		req := httptest.NewRequest(http.MethodGet, "/genesis", nil)
+		req.Header.Set("Authorization", "Bearer reader")
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		var authorizedResp response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &authorizedResp))
+		assert.Nil(t, authorizedResp.Error)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[
+			{"jsonrpc":"2.0","id":1,"method":"health","params":{}},
+			{"jsonrpc":"2.0","id":2,"method":"genesis","params":{}}
+		]`))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		var responses []response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &responses))
+		require.Len(responses, 2)
+		assert.Nil(t, responses[0].Error)
+		require.NotNil(responses[1].Error)
+		assert.Equal(t, json2.E_SERVER, responses[1].Error.Code)
+	})
+}
+
+func TestAccessControlListener(t *testing.T) {
+	a := newAccessController(AccessControl{AllowedMethods: []string{"health", "status"}, DeniedMethods: []string{"status"}})
+	anonymous := &principal{}
+
+	// without tokens, authentication is disabled and every request is anonymous
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer whatever")
+	p, err := a.authenticate(req)
+	require.NoError(t, err)
+	assert.False(t, p.authenticated)
+
+	assert.NoError(t, a.authorize(anonymous, "health"))
+	assert.ErrorIs(t, a.authorize(anonymous, "status"), ErrMethodNotAllowed)
+	assert.ErrorIs(t, a.authorize(anonymous, "genesis"), ErrMethodNotAllowed)
+
+	// nil controller allows everything
+	var none *accessController
+	assert.NoError(t, none.authorize(anonymous, "genesis"))
+}
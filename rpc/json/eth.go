@@ -0,0 +1,333 @@
+package json
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/third_party/log"
+)
+
+// ethEmptyUncleHash is the keccak256 hash of RLP encoded empty list, used as sha3Uncles of all blocks.
+const ethEmptyUncleHash = "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"
+
+// WithEthAPI serves subset of Ethereum JSON-RPC API (eth_* methods) at /eth path, so Ethereum tooling
+// (e.g. block explorers) can display rollup blocks. Access control and rate limits of the handler apply
+// to Ethereum methods as well.
+func WithEthAPI() HandlerOption {
+	return func(h *handler) {
+		h.ethAPI = true
+	}
+}
+
+func newEthService(c rpcclient.Client, l log.Logger) *service {
+	e := ethService{
+		client: c,
+		logger: l,
+	}
+	return &service{
+		client: c,
+		logger: l,
+		methods: map[string]*method{
+			"eth_chainId":            newMethod(e.ChainID),
+			"eth_blockNumber":        newMethod(e.BlockNumber),
+			"eth_getBlockByNumber":   newMethod(e.GetBlockByNumber),
+			"eth_getBlockByHash":     newMethod(e.GetBlockByHash),
+			"eth_sendRawTransaction": newMethod(e.SendRawTransaction),
+		},
+	}
+}
+
+// ethService maps Ethereum JSON-RPC methods onto blocks and transactions of the rollup.
+//
+// Rollup blocks don't have Ethereum specific data (e.g. gas, uncles, receipts), so such fields are
+// zeroed. Transactions are opaque, so only hash and raw bytes (as input) are returned.
+type ethService struct {
+	client rpcclient.Client
+	logger log.Logger
+}
+
+// ChainID returns chain ID as a number. Numeric rollup chain IDs are returned as is; other chain IDs
+// are mapped to the first 4 bytes of their SHA-256 hash.
+func (e *ethService) ChainID(req *http.Request, _ *ethChainIDArgs) (*ethQuantity, error) {
+	status, err := e.client.Status(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	id := ethChainID(status.NodeInfo.Network)
+	return &id, nil
+}
+
+// BlockNumber returns height of the latest block.
+func (e *ethService) BlockNumber(req *http.Request, _ *ethBlockNumberArgs) (*ethQuantity, error) {
+	status, err := e.client.Status(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	height := ethQuantity(status.SyncInfo.LatestBlockHeight)
+	return &height, nil
+}
+
+// GetBlockByNumber returns block with given number or tag; null if block doesn't exist.
+func (e *ethService) GetBlockByNumber(req *http.Request, args *ethGetBlockByNumberArgs) (*ethBlock, error) {
+	height, err := args.Block.height()
+	if err != nil {
+		return nil, err
+	}
+	res, err := e.client.Block(req.Context(), height)
+	if err != nil {
+		// ethereum clients expect null for unknown blocks
+		e.logger.Debug("failed to get block", "height", args.Block, "error", err)
+		return nil, nil
+	}
+	return newEthBlock(res, args.FullTx), nil
+}
+
+// GetBlockByHash returns block with given hash; null if block doesn't exist.
+func (e *ethService) GetBlockByHash(req *http.Request, args *ethGetBlockByHashArgs) (*ethBlock, error) {
+	res, err := e.client.BlockByHash(req.Context(), args.Hash)
+	if err != nil {
+		e.logger.Debug("failed to get block", "hash", args.Hash, "error", err)
+		return nil, nil
+	}
+	return newEthBlock(res, args.FullTx), nil
+}
+
+// SendRawTransaction passes raw transaction to the mempool and returns its hash.
+func (e *ethService) SendRawTransaction(req *http.Request, args *ethSendRawTransactionArgs) (*ethData, error) {
+	if len(args.Data) == 0 {
+		return nil, errors.New("empty transaction")
+	}
+	res, err := e.client.BroadcastTxSync(req.Context(), cmtypes.Tx(args.Data))
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != abci.CodeTypeOK {
+		return nil, fmt.Errorf("transaction rejected (code %d): %s", res.Code, res.Log)
+	}
+	hash := ethData(res.Hash)
+	return &hash, nil
+}
+
+// ethChainID converts rollup chain ID to numeric Ethereum chain ID.
+func ethChainID(chainID string) ethQuantity {
+	if id, err := strconv.ParseUint(chainID, 10, 64); err == nil {
+		return ethQuantity(id)
+	}
+	hash := sha256.Sum256([]byte(chainID))
+	return ethQuantity(binary.BigEndian.Uint32(hash[:4]))
+}
+
+type ethChainIDArgs struct{}
+
+type ethBlockNumberArgs struct{}
+
+type ethGetBlockByNumberArgs struct {
+	Block  ethBlockTag `json:"block"`
+	FullTx bool        `json:"full"`
+}
+
+// UnmarshalJSON parses positional ([block, full]) or named parameters.
+func (a *ethGetBlockByNumberArgs) UnmarshalJSON(b []byte) error {
+	type named ethGetBlockByNumberArgs
+	return unmarshalEthParams(b, (*named)(a), &a.Block, &a.FullTx)
+}
+
+type ethGetBlockByHashArgs struct {
+	Hash   ethData `json:"hash"`
+	FullTx bool    `json:"full"`
+}
+
+// UnmarshalJSON parses positional ([hash, full]) or named parameters.
+func (a *ethGetBlockByHashArgs) UnmarshalJSON(b []byte) error {
+	type named ethGetBlockByHashArgs
+	return unmarshalEthParams(b, (*named)(a), &a.Hash, &a.FullTx)
+}
+
+type ethSendRawTransactionArgs struct {
+	Data ethData `json:"data"`
+}
+
+// UnmarshalJSON parses positional ([data]) or named parameters.
+func (a *ethSendRawTransactionArgs) UnmarshalJSON(b []byte) error {
+	type named ethSendRawTransactionArgs
+	return unmarshalEthParams(b, (*named)(a), &a.Data)
+}
+
+// unmarshalEthParams parses params given as JSON array into positional arguments, or JSON object into named
+// arguments. Trailing positional arguments are optional.
+func unmarshalEthParams(b []byte, named interface{}, positional ...interface{}) error {
+	if trimmed := bytes.TrimSpace(b); len(trimmed) == 0 || trimmed[0] != '[' {
+		return json.Unmarshal(b, named)
+	}
+	var params []json.RawMessage
+	if err := json.Unmarshal(b, &params); err != nil {
+		return err
+	}
+	if len(params) > len(positional) {
+		return fmt.Errorf("too many params: expected at most %d, got %d", len(positional), len(params))
+	}
+	for i, p := range params {
+		if err := json.Unmarshal(p, positional[i]); err != nil {
+			return fmt.Errorf("invalid param %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ethBlockTag is a hex encoded block number, or one of tags: "latest", "pending", "earliest", "safe", "finalized".
+type ethBlockTag string
+
+// height returns block height accepted by rpcclient.Client (nil means latest block).
+func (t ethBlockTag) height() (*int64, error) {
+	var h int64
+	switch t {
+	case "", "latest", "pending":
+		return nil, nil
+	case "earliest":
+		h = int64(EarliestBlockNumber)
+	case "safe", "finalized":
+		// blocks included in DA layer are final
+		h = int64(IncludedBlockNumber)
+	default:
+		n, err := strconv.ParseUint(strings.TrimPrefix(string(t), "0x"), 16, 63)
+		if err != nil || !strings.HasPrefix(string(t), "0x") {
+			return nil, fmt.Errorf("invalid block number %q", t)
+		}
+		h = int64(n)
+	}
+	return &h, nil
+}
+
+// ethQuantity is an integer encoded as 0x prefixed hex string.
+type ethQuantity uint64
+
+// MarshalJSON encodes quantity as hex string.
+func (q ethQuantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal("0x" + strconv.FormatUint(uint64(q), 16))
+}
+
+// ethData is a byte slice encoded as 0x prefixed hex string.
+type ethData []byte
+
+// MarshalJSON encodes data as hex string.
+func (d ethData) MarshalJSON() ([]byte, error) {
+	return json.Marshal("0x" + hex.EncodeToString(d))
+}
+
+// UnmarshalJSON decodes data from 0x prefixed hex string.
+func (d *ethData) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return errors.New("hex string without 0x prefix")
+	}
+	decoded, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// ethBlock is a block in Ethereum JSON-RPC format.
+type ethBlock struct {
+	Number           ethQuantity   `json:"number"`
+	Hash             ethData       `json:"hash"`
+	ParentHash       ethData       `json:"parentHash"`
+	Nonce            ethData       `json:"nonce"`
+	Sha3Uncles       string        `json:"sha3Uncles"`
+	LogsBloom        ethData       `json:"logsBloom"`
+	TransactionsRoot ethData       `json:"transactionsRoot"`
+	StateRoot        ethData       `json:"stateRoot"`
+	ReceiptsRoot     ethData       `json:"receiptsRoot"`
+	Miner            ethData       `json:"miner"`
+	Difficulty       ethQuantity   `json:"difficulty"`
+	TotalDifficulty  ethQuantity   `json:"totalDifficulty"`
+	ExtraData        ethData       `json:"extraData"`
+	Size             ethQuantity   `json:"size"`
+	GasLimit         ethQuantity   `json:"gasLimit"`
+	GasUsed          ethQuantity   `json:"gasUsed"`
+	Timestamp        ethQuantity   `json:"timestamp"`
+	MixHash          ethData       `json:"mixHash"`
+	Transactions     []interface{} `json:"transactions"`
+	Uncles           []ethData     `json:"uncles"`
+}
+
+// MarshalJSON encodes block with standard library, as transactions are either hashes or objects.
+func (b ethBlock) MarshalJSON() ([]byte, error) {
+	type plain ethBlock
+	return json.Marshal(plain(b))
+}
+
+// ethTransaction is a transaction in Ethereum JSON-RPC format. Raw transaction is returned as input.
+type ethTransaction struct {
+	Hash             ethData     `json:"hash"`
+	BlockHash        ethData     `json:"blockHash"`
+	BlockNumber      ethQuantity `json:"blockNumber"`
+	TransactionIndex ethQuantity `json:"transactionIndex"`
+	From             *ethData    `json:"from"`
+	To               *ethData    `json:"to"`
+	Input            ethData     `json:"input"`
+	Value            ethQuantity `json:"value"`
+	Gas              ethQuantity `json:"gas"`
+	GasPrice         ethQuantity `json:"gasPrice"`
+	Nonce            ethQuantity `json:"nonce"`
+}
+
+func newEthBlock(res *ctypes.ResultBlock, fullTx bool) *ethBlock {
+	if res == nil || res.Block == nil {
+		return nil
+	}
+	block := res.Block
+	hash := res.BlockID.Hash
+	if len(hash) == 0 {
+		hash = block.Hash()
+	}
+	b := &ethBlock{
+		Number:           ethQuantity(block.Height),
+		Hash:             ethData(hash),
+		ParentHash:       ethData(block.LastBlockID.Hash),
+		Nonce:            make(ethData, 8),
+		Sha3Uncles:       ethEmptyUncleHash,
+		LogsBloom:        make(ethData, 256),
+		TransactionsRoot: ethData(block.DataHash),
+		StateRoot:        ethData(block.AppHash),
+		ReceiptsRoot:     ethData(block.LastResultsHash),
+		Miner:            ethData(block.ProposerAddress),
+		ExtraData:        ethData{},
+		Size:             ethQuantity(block.Size()),
+		Timestamp:        ethQuantity(block.Time.Unix()),
+		MixHash:          make(ethData, 32),
+		Transactions:     make([]interface{}, 0, len(block.Txs)),
+		Uncles:           []ethData{},
+	}
+	for i, tx := range block.Txs {
+		if !fullTx {
+			b.Transactions = append(b.Transactions, ethData(tx.Hash()))
+			continue
+		}
+		b.Transactions = append(b.Transactions, ethTransaction{
+			Hash:             ethData(tx.Hash()),
+			BlockHash:        b.Hash,
+			BlockNumber:      b.Number,
+			TransactionIndex: ethQuantity(i),
+			Input:            ethData(tx),
+		})
+	}
+	return b
+}
@@ -0,0 +1,138 @@
+package json
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthAPI(t *testing.T) {
+	require := require.New(t)
+
+	client := &mocks.Client{}
+	handler, err := GetHTTPHandler(client, log.TestingLogger(), WithEthAPI())
+	require.NoError(err)
+
+	call := func(method string, params string) (json.RawMessage, *response) {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":` + params + `}`
+		req := httptest.NewRequest(http.MethodPost, "/eth", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		require.Equal(http.StatusOK, resp.Code)
+		var jsonResp response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+		return jsonResp.Result, &jsonResp
+	}
+
+	client.On("Status", mock.Anything).Return(&ctypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{Network: "42"},
+		SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 436},
+	}, nil)
+	result, _ := call("eth_chainId", "[]")
+	assert.JSONEq(t, `"0x2a"`, string(result))
+	result, _ = call("eth_blockNumber", "[]")
+	assert.JSONEq(t, `"0x1b4"`, string(result))
+
+	block := cmtypes.MakeBlock(436, []cmtypes.Tx{[]byte("tx1")}, &cmtypes.Commit{}, nil)
+	block.Time = time.Unix(1700000000, 0)
+	block.ValidatorsHash = make([]byte, 32)
+	blockRes := &ctypes.ResultBlock{BlockID: cmtypes.BlockID{Hash: block.Hash()}, Block: block}
+	client.On("Block", mock.Anything, mock.MatchedBy(func(h *int64) bool { return h != nil && *h == 436 })).Return(blockRes, nil)
+	client.On("Block", mock.Anything, mock.MatchedBy(func(h *int64) bool { return h != nil && *h == 437 })).Return(nil, assert.AnError)
+	client.On("BlockByHash", mock.Anything, []byte(block.Hash())).Return(blockRes, nil)
+
+	var ethBlockResp struct {
+		Number       string            `json:"number"`
+		Hash         string            `json:"hash"`
+		Timestamp    string            `json:"timestamp"`
+		Transactions []json.RawMessage `json:"transactions"`
+	}
+	result, _ = call("eth_getBlockByNumber", `["0x1b4", false]`)
+	require.NoError(json.Unmarshal(result, &ethBlockResp))
+	assert.Equal(t, "0x1b4", ethBlockResp.Number)
+	assert.Equal(t, "0x"+strings.ToLower(block.Hash().String()), ethBlockResp.Hash)
+	assert.Equal(t, "0x6553f100", ethBlockResp.Timestamp)
+	require.Len(ethBlockResp.Transactions, 1)
+	assert.JSONEq(t, `"0x`+hex.EncodeToString(cmtypes.Tx("tx1").Hash())+`"`, string(ethBlockResp.Transactions[0]))
+
+	// full transactions, block requested by hash
+	result, _ = call("eth_getBlockByHash", `["0x`+block.Hash().String()+`", true]`)
+	require.NoError(json.Unmarshal(result, &ethBlockResp))
+	var tx ethTransactionResp
+	require.NoError(json.Unmarshal(ethBlockResp.Transactions[0], &tx))
+	assert.Equal(t, "0x"+"747831", tx.Input)
+	assert.Equal(t, "0x1b4", tx.BlockNumber)
+
+	// unknown block is null
+	result, jsonResp := call("eth_getBlockByNumber", `["0x1b5", false]`)
+	assert.Nil(t, jsonResp.Error)
+	assert.JSONEq(t, "null", string(result))
+
+	_, jsonResp = call("eth_getBlockByNumber", `["436", false]`)
+	assert.NotNil(t, jsonResp.Error)
+
+	tx1 := cmtypes.Tx("tx1")
+	client.On("BroadcastTxSync", mock.Anything, tx1).Return(&ctypes.ResultBroadcastTx{Code: abci.CodeTypeOK, Hash: tx1.Hash()}, nil)
+	result, _ = call("eth_sendRawTransaction", `["0x747831"]`)
+	assert.JSONEq(t, `"0x`+hex.EncodeToString(tx1.Hash())+`"`, string(result))
+
+	// Ethereum methods are not served at root path
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	var rootResp response
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &rootResp))
+	assert.NotNil(t, rootResp.Error)
+}
+
+type ethTransactionResp struct {
+	Input       string `json:"input"`
+	BlockNumber string `json:"blockNumber"`
+}
+
+func TestEthBlockTag(t *testing.T) {
+	cases := []struct {
+		tag      ethBlockTag
+		expected *int64
+		err      bool
+	}{
+		{"latest", nil, false},
+		{"pending", nil, false},
+		{"earliest", ptr(int64(EarliestBlockNumber)), false},
+		{"finalized", ptr(int64(IncludedBlockNumber)), false},
+		{"0x10", ptr(int64(16)), false},
+		{"10", nil, true},
+		{"0xzz", nil, true},
+	}
+	for _, c := range cases {
+		h, err := c.tag.height()
+		if c.err {
+			assert.Error(t, err, c.tag)
+			continue
+		}
+		assert.NoError(t, err, c.tag)
+		assert.Equal(t, c.expected, h, c.tag)
+	}
+
+	assert.Equal(t, ethQuantity(42), ethChainID("42"))
+	assert.Equal(t, ethChainID("rollup"), ethChainID("rollup"))
+	assert.NotEqual(t, ethChainID("rollup"), ethChainID("other-rollup"))
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
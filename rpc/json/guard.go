@@ -0,0 +1,32 @@
+package json
+
+// CallGuard applies access control and rate limits of JSON-RPC handler to calls served over other
+// transports (e.g. gRPC). Calls are identified by equivalent JSON-RPC method names.
+type CallGuard struct {
+	access  *accessController
+	limiter *clientRateLimiter
+}
+
+// NewCallGuard creates CallGuard enforcing given access control and rate limits (see WithAccessControl
+// and WithRateLimit).
+func NewCallGuard(ac AccessControl, rl RateLimit) *CallGuard {
+	return &CallGuard{
+		access:  newAccessController(ac),
+		limiter: newClientRateLimiter(rl),
+	}
+}
+
+// Check authenticates caller with value of Authorization header and verifies that it's allowed to call
+// given method. remoteAddr identifies anonymous callers for rate limiting.
+//
+// Returned error wraps ErrUnauthorized, ErrMethodNotAllowed or ErrRateLimited.
+func (g *CallGuard) Check(authorization string, remoteAddr string, method string) error {
+	p, err := g.access.authenticateHeader(authorization)
+	if err != nil {
+		return err
+	}
+	if err := g.access.authorize(p, method); err != nil {
+		return err
+	}
+	return g.limiter.allowClient(clientKey(p, remoteAddr), method)
+}
@@ -1,16 +1,20 @@
 package json
 
 import (
+	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 
-	"github.com/cometbft/cometbft/libs/bytes"
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmjson "github.com/cometbft/cometbft/libs/json"
 
 	"github.com/gorilla/rpc/v2"
@@ -24,27 +28,79 @@ type handler struct {
 	mux    *http.ServeMux
 	codec  rpc.Codec
 	logger log.Logger
+
+	// maxBatchSize is the maximum number of requests in a batch; 0 means no limit.
+	maxBatchSize int
+	// maxBodyBytes is the maximum size of request body in bytes; 0 means no limit.
+	maxBodyBytes int64
+	// access controls authentication and authorization of requests; nil allows everything
+	access *accessController
+	// limiter limits cost of requests per client; nil allows everything
+	limiter *clientRateLimiter
+	metrics *Metrics
+	// ethAPI enables Ethereum JSON-RPC adapter (see WithEthAPI)
+	ethAPI bool
+}
+
+// HandlerOption configures RPC handler.
+type HandlerOption func(*handler)
+
+// WithMaxBatchSize limits number of requests in a single JSON-RPC batch. 0 means no limit.
+func WithMaxBatchSize(size int) HandlerOption {
+	return func(h *handler) {
+		h.maxBatchSize = size
+	}
 }
 
-func newHandler(s *service, codec rpc.Codec, logger log.Logger) *handler {
+// WithMaxBodyBytes limits size of a single HTTP request body in bytes. 0 means no limit.
+func WithMaxBodyBytes(size int64) HandlerOption {
+	return func(h *handler) {
+		h.maxBodyBytes = size
+	}
+}
+
+func newHandler(s *service, codec rpc.Codec, logger log.Logger, opts ...HandlerOption) *handler {
 	mux := http.NewServeMux()
 	h := &handler{
-		srv:    s,
-		mux:    mux,
-		codec:  codec,
-		logger: logger,
+		srv:     s,
+		mux:     mux,
+		codec:   codec,
+		logger:  logger,
+		metrics: NopMetrics(),
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
 
 	mux.HandleFunc("/", h.serveJSONRPC)
 	mux.HandleFunc("/websocket", h.wsHandler)
+	if openAPI, err := h.newOpenAPIHandler(); err != nil {
+		logger.Error("failed to generate OpenAPI document", "error", err)
+	} else {
+		mux.HandleFunc(openAPIPath, openAPI)
+	}
+	if h.ethAPI {
+		// Ethereum methods are served by a copy of handler, sharing access control and rate limits
+		eth := *h
+		eth.srv = newEthService(s.client, logger)
+		mux.HandleFunc("/eth", eth.serveJSONRPC)
+	}
 	for name, method := range s.methods {
 		logger.Debug("registering method", "name", name)
-		mux.HandleFunc("/"+name, h.newHandler(method))
+		mux.HandleFunc("/"+name, h.newHandler(name, method))
 	}
 
 	return h
 }
 func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.access != nil {
+		p, err := h.access.authenticate(r)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusUnauthorized, &json2.Error{Code: json2.E_SERVER, Message: err.Error()})
+			return
+		}
+		r = r.WithContext(context.WithValue(r.Context(), principalKey{}, p))
+	}
 	h.mux.ServeHTTP(w, r)
 }
 
@@ -53,9 +109,93 @@ func (h *handler) serveJSONRPC(w http.ResponseWriter, r *http.Request) {
 	h.serveJSONRPCforWS(w, r, nil)
 }
 
-// serveJSONRPC serves HTTP request
-// implementation is highly inspired by Gorilla RPC v2 (but simplified a lot)
+// serveJSONRPCforWS serves HTTP request (or WebSocket message), containing single request or a batch of requests.
 func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsConn *wsConn) {
+	if h.maxBodyBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxBodyBytes)
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, &json2.Error{
+				Code:    json2.E_INVALID_REQ,
+				Message: fmt.Sprintf("request body exceeds maximum of %d bytes", maxBytesErr.Limit),
+			})
+			return
+		}
+		h.writeErrorResponse(w, http.StatusOK, &json2.Error{Code: json2.E_PARSE, Message: err.Error()})
+		return
+	}
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		h.serveBatch(w, r, wsConn, trimmed)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	h.serveRequest(w, r, wsConn)
+}
+
+// serveBatch serves JSON-RPC batch. Requests are processed in order, and responses are returned in a
+// single array. Errors are reported per request; notifications (requests without ID) have no response.
+func (h *handler) serveBatch(w http.ResponseWriter, r *http.Request, wsConn *wsConn, body []byte) {
+	var requests []json.RawMessage
+	if err := json.Unmarshal(body, &requests); err != nil {
+		h.writeErrorResponse(w, http.StatusOK, &json2.Error{Code: json2.E_PARSE, Message: err.Error()})
+		return
+	}
+	if len(requests) == 0 {
+		h.writeErrorResponse(w, http.StatusOK, &json2.Error{Code: json2.E_INVALID_REQ, Message: "empty batch"})
+		return
+	}
+	if h.maxBatchSize > 0 && len(requests) > h.maxBatchSize {
+		h.writeErrorResponse(w, http.StatusOK, &json2.Error{
+			Code:    json2.E_INVALID_REQ,
+			Message: fmt.Sprintf("batch size %d exceeds maximum of %d requests", len(requests), h.maxBatchSize),
+		})
+		return
+	}
+
+	responses := make([]json.RawMessage, 0, len(requests))
+	for _, request := range requests {
+		req := r.Clone(r.Context())
+		req.Body = io.NopCloser(bytes.NewReader(request))
+		buf := new(bytes.Buffer)
+		h.serveRequest(newResponseWriter(buf), req, wsConn)
+		if resp := bytes.TrimSpace(buf.Bytes()); len(resp) > 0 {
+			responses = append(responses, resp)
+		}
+	}
+	// batch of notifications has no response at all
+	if len(responses) == 0 {
+		return
+	}
+
+	w.Header().Set("x-content-type-options", "nosniff")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(responses); err != nil {
+		h.logger.Error("failed to encode RPC batch response", "error", err)
+	}
+}
+
+// writeErrorResponse writes error response for a request (or batch) that can't be processed at all.
+func (h *handler) writeErrorResponse(w http.ResponseWriter, statusCode int, rpcErr *json2.Error) {
+	w.Header().Set("x-content-type-options", "nosniff")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	resp := response{
+		Version: "2.0",
+		Error:   rpcErr,
+		ID:      json.RawMessage("null"),
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("failed to encode RPC response", "error", err)
+	}
+}
+
+// serveRequest serves single JSON-RPC request
+// implementation is highly inspired by Gorilla RPC v2 (but simplified a lot)
+func (h *handler) serveRequest(w http.ResponseWriter, r *http.Request, wsConn *wsConn) {
 	// Create a new codec request.
 	codecReq := h.codec.NewRequest(r)
 	if wsConn != nil {
@@ -74,7 +214,16 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 	}
 	methodSpec, ok := h.srv.methods[method]
 	if !ok {
-		codecReq.WriteError(w, int(json2.E_NO_METHOD), err)
+		codecReq.WriteError(w, int(json2.E_NO_METHOD), &json2.Error{Code: json2.E_NO_METHOD, Message: fmt.Sprintf("method %q not found", method)})
+		return
+	}
+	if err := h.access.authorize(principalFromContext(r.Context()), method); err != nil {
+		codecReq.WriteError(w, int(json2.E_SERVER), &json2.Error{Code: json2.E_SERVER, Message: err.Error()})
+		return
+	}
+	if err := h.checkRateLimit(r, method); err != nil {
+		writeRateLimited(w)
+		codecReq.WriteError(w, int(json2.E_SERVER), &json2.Error{Code: json2.E_SERVER, Message: err.Error()})
 		return
 	}
 
@@ -121,8 +270,17 @@ func (h *handler) serveJSONRPCforWS(w http.ResponseWriter, r *http.Request, wsCo
 	}
 }
 
-func (h *handler) newHandler(methodSpec *method) func(http.ResponseWriter, *http.Request) {
+func (h *handler) newHandler(name string, methodSpec *method) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.access.authorize(principalFromContext(r.Context()), name); err != nil {
+			h.encodeAndWriteResponse(w, nil, err, int(json2.E_SERVER))
+			return
+		}
+		if err := h.checkRateLimit(r, name); err != nil {
+			writeRateLimited(w)
+			h.encodeAndWriteResponse(w, nil, err, int(json2.E_SERVER))
+			return
+		}
 		args := reflect.New(methodSpec.argsType)
 		values, err := url.ParseQuery(r.URL.RawQuery)
 		if err != nil {
@@ -151,8 +309,11 @@ func (h *handler) newHandler(methodSpec *method) func(http.ResponseWriter, *http
 				args.Elem().Field(i).SetString(rawVal)
 			case reflect.Slice:
 				// []byte is a reflect.Slice of reflect.Uint8's
-				if field.Type.Elem().Kind() == reflect.Uint8 {
+				switch field.Type.Elem().Kind() {
+				case reflect.Uint8:
 					err = setByteSliceParam(rawVal, &args, i)
+				case reflect.String:
+					setStringSliceParam(rawVal, &args, i)
 				}
 			default:
 				err = errors.New("unknown type")
@@ -245,12 +406,12 @@ func setPointerParam(rawVal string, args *reflect.Value, i int) error {
 			return err
 		}
 		field.Set(reflect.ValueOf(&val))
-	case reflect.TypeOf((*bytes.HexBytes)(nil)):
+	case reflect.TypeOf((*cmbytes.HexBytes)(nil)):
 		hexBytes, err := hex.DecodeString(rawVal)
 		if err != nil {
 			return err
 		}
-		hb := bytes.HexBytes(hexBytes)
+		hb := cmbytes.HexBytes(hexBytes)
 		field.Set(reflect.ValueOf(&hb))
 	default:
 		return fmt.Errorf("unsupported pointer type: %v", field.Type())
@@ -284,3 +445,11 @@ func setByteSliceParam(rawVal string, args *reflect.Value, i int) error {
 	args.Elem().Field(i).SetBytes(b)
 	return nil
 }
+
+// setStringSliceParam parses comma separated list of values.
+func setStringSliceParam(rawVal string, args *reflect.Value, i int) {
+	if rawVal == "" {
+		return
+	}
+	args.Elem().Field(i).Set(reflect.ValueOf(strings.Split(rawVal, ",")))
+}
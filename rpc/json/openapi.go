@@ -0,0 +1,334 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	cmbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/version"
+)
+
+// openAPIPath is the path of OpenAPI document describing RPC methods served by the handler.
+const openAPIPath = "/openapi.json"
+
+// schemaRefPrefix is the prefix of references to schemas in OpenAPI document.
+const schemaRefPrefix = "#/components/schemas/"
+
+// jsonSchema is a JSON Schema (draft 2020-12, as used by OpenAPI 3.1).
+type jsonSchema map[string]interface{}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	hexBytesType      = reflect.TypeOf(cmbytes.HexBytes{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+	intStringSchema  = jsonSchema{"type": "string", "pattern": "^-?[0-9]+$"}
+	uintStringSchema = jsonSchema{"type": "string", "pattern": "^[0-9]+$"}
+
+	// customSchemas are schemas of types with custom JSON encoding.
+	customSchemas = map[reflect.Type]jsonSchema{
+		timeType:     {"type": "string", "format": "date-time"},
+		hexBytesType: {"type": "string", "pattern": "^[0-9A-F]*$"},
+		// StrInt64 and StrInt accept both numbers and strings containing numbers
+		reflect.TypeOf(StrInt64(0)): {"type": []string{"integer", "string"}, "pattern": "^-?[0-9]+$"},
+		reflect.TypeOf(StrInt(0)):   {"type": []string{"integer", "string"}, "pattern": "^-?[0-9]+$"},
+		reflect.TypeOf(BlockNumber(0)): {"oneOf": []jsonSchema{
+			{"type": []string{"integer", "string"}, "pattern": "^-?[0-9]+$"},
+			{"type": "string", "enum": []string{"latest", "earliest", "included"}},
+		}},
+	}
+)
+
+// schemaGenerator generates JSON Schemas of Go types, as encoded by the RPC server. Named struct types are
+// generated once, and referenced from other schemas.
+type schemaGenerator struct {
+	defs  map[string]jsonSchema
+	names map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		defs:  make(map[string]jsonSchema),
+		names: make(map[reflect.Type]string),
+	}
+}
+
+// schema returns schema of values of type t encoded with cmjson (amino compatible JSON): 64-bit integers are
+// encoded as strings, byte slices as base64 strings, interfaces as {"type": ..., "value": ...} objects.
+func (g *schemaGenerator) schema(t reflect.Type) jsonSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if s, ok := customSchemas[t]; ok {
+		return s
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return jsonSchema{"description": fmt.Sprintf("custom JSON encoding of %s", t)}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Int, reflect.Int64:
+		return intStringSchema
+	case reflect.Uint, reflect.Uint64:
+		return uintStringSchema
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uintptr:
+		return jsonSchema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return jsonSchema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return jsonSchema{"type": "string", "contentEncoding": "base64"}
+		}
+		return jsonSchema{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return jsonSchema{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Interface:
+		// only concrete types registered with cmjson can be encoded
+		return jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"type":  jsonSchema{"type": "string"},
+				"value": jsonSchema{},
+			},
+			"required": []string{"type", "value"},
+		}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return jsonSchema{"$ref": schemaRefPrefix + g.define(t)}
+	}
+	return jsonSchema{}
+}
+
+// define generates schema of named struct type (if not generated yet), and returns its name.
+func (g *schemaGenerator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.String()
+	if _, taken := g.defs[name]; taken {
+		// types with the same name from different packages
+		name = strings.ReplaceAll(t.PkgPath(), "/", ".") + "." + t.Name()
+	}
+	g.names[t] = name
+	// placeholder for recursive types
+	g.defs[name] = jsonSchema{}
+	g.defs[name] = g.structSchema(t)
+	return name
+}
+
+// structSchema returns schema of struct. Embedded structs are encoded as fields named after their types.
+func (g *schemaGenerator) structSchema(t reflect.Type) jsonSchema {
+	properties := jsonSchema{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		properties[name] = g.schema(field.Type)
+		if !omitEmpty && field.Type.Kind() != reflect.Pointer {
+			required = append(required, name)
+		}
+	}
+	s := jsonSchema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// jsonFieldName returns name of struct field in JSON, and whether it's omitted if empty. Unexported and
+// hidden fields are skipped.
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(opts, "omitempty"), true
+}
+
+// paramSchema returns schema of query parameter of GET (URI) request, as parsed by handler.
+func paramSchema(t reflect.Type) jsonSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(BlockNumber(0)):
+		return customSchemas[t]
+	case t.Kind() == reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case t.Kind() == reflect.Int || t.Kind() == reflect.Int64:
+		return jsonSchema{"type": "integer"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return jsonSchema{"type": "string", "pattern": "^[0-9a-fA-F]*$"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		// comma separated list
+		return jsonSchema{"type": "array", "items": jsonSchema{"type": "string"}}
+	}
+	return jsonSchema{"type": "string"}
+}
+
+// openAPIDocument generates OpenAPI 3.1 document describing given methods.
+//
+// Every method is described twice: as GET request with query parameters (e.g. /block?height=1), and as
+// a variant of JSON-RPC request accepted by POST /. Schemas of params and results of every method are
+// available as "<method>.params" and "<method>.result" components, so they can be used as JSON Schemas.
+func openAPIDocument(methods map[string]*method) jsonSchema {
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	g := newSchemaGenerator()
+	paths := jsonSchema{}
+	requests := make([]jsonSchema, 0, len(names))
+	for _, name := range names {
+		m := methods[name]
+		paramsName := name + ".params"
+		resultName := name + ".result"
+		g.defs[paramsName] = g.structSchema(m.argsType)
+		g.defs[resultName] = g.schema(m.returnType)
+
+		var parameters []jsonSchema
+		for i := 0; i < m.argsType.NumField(); i++ {
+			field := m.argsType.Field(i)
+			param, _, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+			parameters = append(parameters, jsonSchema{
+				"name":     param,
+				"in":       "query",
+				"required": field.Type.Kind() != reflect.Pointer,
+				"schema":   paramSchema(field.Type),
+			})
+		}
+		operation := jsonSchema{
+			"operationId": name,
+			"responses": jsonSchema{
+				"200": jsonSchema{
+					"description": "JSON-RPC response",
+					"content": jsonSchema{
+						"application/json": jsonSchema{"schema": responseSchema(resultName)},
+					},
+				},
+			},
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+		if m.ws {
+			operation["description"] = "Events are delivered only to WebSocket connections (/websocket)."
+		}
+		paths["/"+name] = jsonSchema{"get": operation}
+
+		requests = append(requests, jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"jsonrpc": jsonSchema{"const": "2.0"},
+				"id":      jsonSchema{},
+				"method":  jsonSchema{"const": name},
+				"params":  jsonSchema{"$ref": schemaRefPrefix + paramsName},
+			},
+			"required": []string{"jsonrpc", "method"},
+		})
+	}
+
+	requestSchema := jsonSchema{"oneOf": requests}
+	paths["/"] = jsonSchema{"post": jsonSchema{
+		"operationId": "jsonrpc",
+		"requestBody": jsonSchema{
+			"required": true,
+			"content": jsonSchema{
+				"application/json": jsonSchema{"schema": jsonSchema{
+					"oneOf": []jsonSchema{
+						requestSchema,
+						{"type": "array", "items": requestSchema},
+					},
+				}},
+			},
+		},
+		"responses": jsonSchema{
+			"200": jsonSchema{
+				"description": "JSON-RPC response (or array of responses for batch requests)",
+				"content": jsonSchema{
+					"application/json": jsonSchema{"schema": jsonSchema{}},
+				},
+			},
+		},
+	}}
+
+	return jsonSchema{
+		"openapi":           "3.1.0",
+		"jsonSchemaDialect": "https://json-schema.org/draft/2020-12/schema",
+		"info": jsonSchema{
+			"title":       "Rollkit RPC",
+			"description": "CometBFT compatible RPC served by Rollkit nodes.",
+			"version":     version.TMCoreSemVer,
+		},
+		"paths":      paths,
+		"components": jsonSchema{"schemas": g.defs},
+	}
+}
+
+// responseSchema returns schema of JSON-RPC response with result described by given component.
+func responseSchema(resultName string) jsonSchema {
+	return jsonSchema{
+		"type": "object",
+		"properties": jsonSchema{
+			"jsonrpc": jsonSchema{"const": "2.0"},
+			"id":      jsonSchema{},
+			"result":  jsonSchema{"$ref": schemaRefPrefix + resultName},
+			"error": jsonSchema{
+				"type": "object",
+				"properties": jsonSchema{
+					"code":    jsonSchema{"type": "integer"},
+					"message": jsonSchema{"type": "string"},
+					"data":    jsonSchema{},
+				},
+			},
+		},
+	}
+}
+
+// newOpenAPIHandler returns handler serving OpenAPI document of methods served by the listener. Document is
+// generated once, and is available without authentication.
+func (h *handler) newOpenAPIHandler() (http.HandlerFunc, error) {
+	methods := make(map[string]*method, len(h.srv.methods))
+	for name, m := range h.srv.methods {
+		if h.access != nil && ((h.access.allowed != nil && !h.access.allowed.has(name)) || h.access.denied.has(name)) {
+			continue
+		}
+		methods[name] = m
+	}
+	doc, err := json.MarshalIndent(openAPIDocument(methods), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := w.Write(doc); err != nil {
+			h.logger.Error("failed to write OpenAPI document", "error", err)
+		}
+	}, nil
+}
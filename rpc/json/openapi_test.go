@@ -0,0 +1,87 @@
+package json
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateOpenAPI regenerates OpenAPI document used to detect changes of the API.
+var updateOpenAPI = flag.Bool("update-openapi", false, "update testdata/openapi.json")
+
+func getOpenAPI(t *testing.T, opts ...HandlerOption) []byte {
+	t.Helper()
+	handler, err := GetHTTPHandler(&mocks.Client{}, log.TestingLogger(), opts...)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, openAPIPath, nil)
+	rsp := httptest.NewRecorder()
+	handler.ServeHTTP(rsp, req)
+	require.Equal(t, http.StatusOK, rsp.Code)
+	return rsp.Body.Bytes()
+}
+
+func TestOpenAPI(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	raw := getOpenAPI(t)
+	var doc struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(json.Unmarshal(raw, &doc))
+	assert.Equal("3.1.0", doc.OpenAPI)
+
+	for name := range newService(&mocks.Client{}, log.TestingLogger()).methods {
+		assert.Contains(doc.Paths, "/"+name)
+		assert.Contains(doc.Components.Schemas, name+".params")
+		assert.Contains(doc.Components.Schemas, name+".result")
+	}
+	assert.Contains(doc.Paths["/"], "post")
+	assert.Contains(doc.Components.Schemas, "coretypes.ResultBlock")
+
+	// all references are resolvable
+	for _, ref := range strings.Split(string(raw), `"$ref": "`)[1:] {
+		name := strings.TrimPrefix(ref[:strings.IndexByte(ref, '"')], schemaRefPrefix)
+		assert.Contains(doc.Components.Schemas, name)
+	}
+
+	// changes of the API (e.g. breaking changes of args or results) are detected by comparison with the
+	// committed document; run tests with -update-openapi flag to accept the changes
+	golden := filepath.Join("testdata", "openapi.json")
+	if *updateOpenAPI {
+		require.NoError(os.MkdirAll("testdata", 0o750))
+		require.NoError(os.WriteFile(golden, raw, 0o600))
+	}
+	expected, err := os.ReadFile(golden) //nolint:gosec
+	require.NoError(err)
+	assert.JSONEq(string(expected), string(raw), "RPC API changed; run go test ./rpc/json -run TestOpenAPI -update-openapi")
+}
+
+func TestOpenAPIAccessControl(t *testing.T) {
+	var doc struct {
+		Paths map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(getOpenAPI(t, WithAccessControl(AccessControl{
+		DeniedMethods: []string{"broadcast_tx_sync"},
+		Tokens:        map[string][]string{"token": nil},
+	})), &doc))
+
+	// methods never served by the listener are not documented
+	assert.NotContains(t, doc.Paths, "/broadcast_tx_sync")
+	assert.Contains(t, doc.Paths, "/broadcast_tx_async")
+}
@@ -0,0 +1,170 @@
+package json
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitersCacheSize defines how many token buckets are tracked by rate limiter.
+// Buckets of least recently active clients are evicted first.
+const rateLimitersCacheSize = 10000
+
+// ErrRateLimited is returned when client exceeds its request quota.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// defaultMethodCosts are costs of methods that are more expensive than a simple lookup.
+// Methods not listed cost 1.
+var defaultMethodCosts = map[string]int{
+	"tx_search":           10,
+	"block_search":        10,
+	"subscribe":           10,
+	"blockchain":          5,
+	"block_results":       2,
+	"genesis":             2,
+	"genesis_chunked":     2,
+	"unconfirmed_txs":     2,
+	"broadcast_tx_commit": 5,
+	"abci_query":          2,
+}
+
+// RateLimit configures per-client rate limiting of RPC requests.
+//
+// Clients are identified by bearer token if authenticated (see WithAccessControl; JWT callers by `sub` claim, if set),
+// or by IP address otherwise.
+// Every request consumes tokens from the client's bucket according to the cost of the method.
+type RateLimit struct {
+	// CostPerSecond is the number of cost units per second available to a single client.
+	CostPerSecond float64
+	// Burst is the maximum number of cost units consumed by a client at once. It defaults to
+	// CostPerSecond (rounded up), but it's never lower than the cost of the most expensive method.
+	Burst int
+	// MethodCosts overrides default costs of methods.
+	MethodCosts map[string]int
+}
+
+// WithRateLimit enables per-client rate limiting of requests. Rate limiting is disabled if
+// CostPerSecond is not positive.
+func WithRateLimit(rl RateLimit) HandlerOption {
+	return func(h *handler) {
+		h.limiter = newClientRateLimiter(rl)
+	}
+}
+
+// WithMetrics sets metrics reported by the handler.
+func WithMetrics(metrics *Metrics) HandlerOption {
+	return func(h *handler) {
+		h.metrics = metrics
+	}
+}
+
+// clientRateLimiter limits cost of requests per client, using token buckets.
+// nil clientRateLimiter allows everything.
+type clientRateLimiter struct {
+	limit rate.Limit
+	burst int
+	costs map[string]int
+
+	limiters *lru.Cache[string, *rate.Limiter]
+}
+
+func newClientRateLimiter(rl RateLimit) *clientRateLimiter {
+	if rl.CostPerSecond <= 0 {
+		return nil
+	}
+	costs := make(map[string]int, len(defaultMethodCosts)+len(rl.MethodCosts))
+	for m, c := range defaultMethodCosts {
+		costs[m] = c
+	}
+	for m, c := range rl.MethodCosts {
+		costs[m] = c
+	}
+	burst := rl.Burst
+	if burst <= 0 {
+		burst = int(math.Ceil(rl.CostPerSecond))
+	}
+	// every method has to be callable with full bucket
+	for _, c := range costs {
+		burst = max(burst, c)
+	}
+	limiters, err := lru.New[string, *rate.Limiter](rateLimitersCacheSize)
+	if err != nil {
+		// only possible with non-positive size
+		panic(err)
+	}
+	return &clientRateLimiter{
+		limit:    rate.Limit(rl.CostPerSecond),
+		burst:    burst,
+		costs:    costs,
+		limiters: limiters,
+	}
+}
+
+// cost returns number of cost units consumed by a call of given method.
+func (l *clientRateLimiter) cost(method string) int {
+	if c, ok := l.costs[method]; ok {
+		return c
+	}
+	return 1
+}
+
+// allow reports whether client sending the request can call given method. Cost of the method is
+// consumed only if call is allowed.
+func (l *clientRateLimiter) allow(r *http.Request, method string) error {
+	return l.allowClient(clientKey(principalFromContext(r.Context()), r.RemoteAddr), method)
+}
+
+// allowClient reports whether client identified by key can call given method (see allow).
+func (l *clientRateLimiter) allowClient(key string, method string) error {
+	if l == nil {
+		return nil
+	}
+	limiter, ok := l.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		// other goroutine might have added the limiter in the meantime
+		if prev, found, _ := l.limiters.PeekOrAdd(key, limiter); found {
+			limiter = prev
+		}
+	}
+	if !limiter.AllowN(time.Now(), l.cost(method)) {
+		return fmt.Errorf("%w: %s", ErrRateLimited, method)
+	}
+	return nil
+}
+
+// clientKey identifies client sending the request: authenticated callers by token, others by IP address.
+func clientKey(p *principal, remoteAddr string) string {
+	if p.authenticated {
+		return "token:" + p.id
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "ip:" + host
+}
+
+// checkRateLimit consumes cost of the method from quota of the client. If quota is exceeded, rejection
+// is reported in metrics and returned as error.
+func (h *handler) checkRateLimit(r *http.Request, method string) error {
+	err := h.limiter.allow(r, method)
+	if err != nil {
+		h.metrics.RateLimitedRequests.With("method", method).Add(1)
+	}
+	return err
+}
+
+// writeRateLimited prepares HTTP response of rate limited request. It's no-op for batched and
+// WebSocket requests, as their status code is ignored.
+func writeRateLimited(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Retry-After", "1")
+	w.WriteHeader(http.StatusTooManyRequests)
+}
@@ -0,0 +1,127 @@
+package json
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/go-kit/kit/metrics"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/rpc/v2/json2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCounter counts values added with any labels.
+type testCounter struct {
+	value atomic.Int64
+}
+
+func (c *testCounter) With(...string) metrics.Counter { return c }
+func (c *testCounter) Add(delta float64)              { c.value.Add(int64(delta)) }
+
+func TestRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	rejected := &testCounter{}
+	_, local := getRPC(t, "TestRateLimit")
+	handler, err := GetHTTPHandler(local, log.TestingLogger(),
+		WithAccessControl(AccessControl{Tokens: map[string][]string{"token": nil}, PublicMethods: []string{"health", "tx_search"}}),
+		// negligible refill rate, so only burst is available during the test
+		WithRateLimit(RateLimit{CostPerSecond: 0.001, Burst: 10, MethodCosts: map[string]int{"blockchain": 3}}),
+		WithMetrics(&Metrics{RateLimitedRequests: rejected}),
+	)
+	require.NoError(err)
+
+	call := func(remoteAddr, token, method string) *httptest.ResponseRecorder {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":{}}`
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusOK, call("10.0.0.1:1234", "", "health").Code)
+	}
+	// quota is per IP address, port doesn't matter
+	resp := call("10.0.0.1:4321", "", "health")
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "1", resp.Header().Get("Retry-After"))
+	var jsonResp response
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	require.NotNil(jsonResp.Error)
+	assert.Equal(t, json2.E_SERVER, jsonResp.Error.Code)
+	assert.Contains(t, jsonResp.Error.Message, ErrRateLimited.Error())
+
+	// authenticated client has its own quota
+	assert.Equal(t, http.StatusOK, call("10.0.0.1:1234", "token", "health").Code)
+
+	// search consumes the whole quota
+	assert.Equal(t, http.StatusOK, call("10.0.0.2:1234", "", "tx_search").Code)
+	assert.Equal(t, http.StatusTooManyRequests, call("10.0.0.2:1234", "", "health").Code)
+
+	// REST endpoints are limited as well
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	restResp := httptest.NewRecorder()
+	handler.ServeHTTP(restResp, req)
+	assert.Equal(t, http.StatusTooManyRequests, restResp.Code)
+
+	assert.Equal(t, int64(3), rejected.value.Load())
+}
+
+func TestRateLimitJWT(t *testing.T) {
+	require := require.New(t)
+
+	secret := []byte("secret")
+	signJWT := func(subject string, expiresAt time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: subject, ExpiresAt: jwt.NewNumericDate(expiresAt)},
+		})
+		signed, err := token.SignedString(secret)
+		require.NoError(err)
+		return signed
+	}
+	a := newAccessController(AccessControl{JWTSecret: secret})
+	// search consumes the whole quota
+	l := newClientRateLimiter(RateLimit{CostPerSecond: 0.001})
+	call := func(token string) error {
+		p, err := a.authenticateHeader("Bearer " + token)
+		require.NoError(err)
+		return l.allowClient(clientKey(p, "10.0.0.1:1234"), "tx_search")
+	}
+
+	// every subject has its own quota, regardless of the token used
+	expiresAt := time.Now().Add(time.Hour)
+	require.NoError(call(signJWT("alice", expiresAt)))
+	require.ErrorIs(call(signJWT("alice", expiresAt.Add(time.Minute))), ErrRateLimited)
+	require.NoError(call(signJWT("bob", expiresAt)))
+
+	// tokens without subject are limited separately
+	anonymous := signJWT("", expiresAt)
+	require.NoError(call(anonymous))
+	require.ErrorIs(call(anonymous), ErrRateLimited)
+	require.NoError(call(signJWT("", expiresAt.Add(time.Minute))))
+}
+
+func TestClientRateLimiterCosts(t *testing.T) {
+	assert.Nil(t, newClientRateLimiter(RateLimit{}))
+
+	l := newClientRateLimiter(RateLimit{CostPerSecond: 1, MethodCosts: map[string]int{"status": 3, "tx_search": 20}})
+	assert.Equal(t, 1, l.cost("health"))
+	assert.Equal(t, 3, l.cost("status"))
+	assert.Equal(t, 10, l.cost("block_search"))
+	assert.Equal(t, 20, l.cost("tx_search"))
+	// burst allows to call the most expensive method
+	assert.Equal(t, 20, l.burst)
+}
@@ -0,0 +1,79 @@
+package json
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rollkit/rollkit/node"
+)
+
+// RollkitInfoProvider is implemented by clients of nodes exposing rollkit specific state (e.g. DA submission progress).
+type RollkitInfoProvider interface {
+	// PendingHeaders returns number of headers waiting for submission to DA layer.
+	PendingHeaders(ctx context.Context) (*node.ResultPendingHeaders, error)
+	// DAStatus returns DA included height and current DA height being scanned.
+	DAStatus(ctx context.Context) (*node.ResultDAStatus, error)
+	// SyncStatus returns heights of the block store and sync service stores.
+	SyncStatus(ctx context.Context) (*node.ResultSyncStatus, error)
+	// NodeMode returns the role of the node in the rollup.
+	NodeMode(ctx context.Context) (*node.ResultNodeMode, error)
+	// PendingEvidence returns evidence of misbehavior waiting for inclusion in a block.
+	PendingEvidence(ctx context.Context) (*node.ResultPendingEvidence, error)
+}
+
+type rollkitPendingHeadersArgs struct{}
+type rollkitDAStatusArgs struct{}
+type rollkitSyncStatusArgs struct{}
+type rollkitNodeModeArgs struct{}
+type rollkitPendingEvidenceArgs struct{}
+
+func (s *service) rollkitInfo() (RollkitInfoProvider, error) {
+	p, ok := s.client.(RollkitInfoProvider)
+	if !ok {
+		return nil, fmt.Errorf("rollkit methods are not supported by %T", s.client)
+	}
+	return p, nil
+}
+
+func (s *service) RollkitPendingHeaders(req *http.Request, _ *rollkitPendingHeadersArgs) (*node.ResultPendingHeaders, error) {
+	p, err := s.rollkitInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.PendingHeaders(req.Context())
+}
+
+func (s *service) RollkitDAStatus(req *http.Request, _ *rollkitDAStatusArgs) (*node.ResultDAStatus, error) {
+	p, err := s.rollkitInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.DAStatus(req.Context())
+}
+
+func (s *service) RollkitSyncStatus(req *http.Request, _ *rollkitSyncStatusArgs) (*node.ResultSyncStatus, error) {
+	p, err := s.rollkitInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.SyncStatus(req.Context())
+}
+
+func (s *service) RollkitNodeMode(req *http.Request, _ *rollkitNodeModeArgs) (*node.ResultNodeMode, error) {
+	p, err := s.rollkitInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.NodeMode(req.Context())
+}
+
+func (s *service) RollkitPendingEvidence(req *http.Request, _ *rollkitPendingEvidenceArgs) (*node.ResultPendingEvidence, error) {
+	p, err := s.rollkitInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.PendingEvidence(req.Context())
+}
+
+var _ RollkitInfoProvider = (*node.FullClient)(nil)
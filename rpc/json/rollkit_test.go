@@ -0,0 +1,54 @@
+package json
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollkitMethods(t *testing.T) {
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestRollkitMethods")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	call := func(handler http.Handler, method string) response {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"`+method+`","params":{}}`))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		var jsonResp response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+		return jsonResp
+	}
+
+	for _, method := range []string{"rollkit_pending_headers", "rollkit_da_status", "rollkit_sync_status", "rollkit_pending_evidence"} {
+		resp := call(handler, method)
+		assert.Nil(t, resp.Error, method)
+		assert.NotEmpty(t, resp.Result, method)
+	}
+
+	resp := call(handler, "rollkit_node_mode")
+	require.Nil(resp.Error)
+	var mode struct {
+		Aggregator bool `json:"aggregator"`
+		Proposer   bool `json:"proposer"`
+	}
+	require.NoError(json.Unmarshal(resp.Result, &mode))
+	assert.True(t, mode.Aggregator)
+	assert.True(t, mode.Proposer)
+
+	// clients without rollkit state don't support the methods
+	handler, err = GetHTTPHandler(&mocks.Client{}, log.TestingLogger())
+	require.NoError(err)
+	resp = call(handler, "rollkit_node_mode")
+	require.NotNil(resp.Error)
+	assert.Contains(t, resp.Error.Message, "not supported")
+}
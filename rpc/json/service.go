@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -16,12 +17,13 @@ import (
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
 
+	"github.com/rollkit/rollkit/node"
 	"github.com/rollkit/rollkit/third_party/log"
 )
 
 // GetHTTPHandler returns handler configured to serve Tendermint-compatible RPC.
-func GetHTTPHandler(l rpcclient.Client, logger log.Logger) (http.Handler, error) {
-	return newHandler(newService(l, logger), json2.NewCodec(), logger), nil
+func GetHTTPHandler(l rpcclient.Client, logger log.Logger, opts ...HandlerOption) (http.Handler, error) {
+	return newHandler(newService(l, logger), json2.NewCodec(), logger, opts...), nil
 }
 
 type method struct {
@@ -85,6 +87,13 @@ func newService(c rpcclient.Client, l log.Logger) *service {
 		"abci_query":           newMethod(s.ABCIQuery),
 		"abci_info":            newMethod(s.ABCIInfo),
 		"broadcast_evidence":   newMethod(s.BroadcastEvidence),
+
+		// rollkit specific methods
+		"rollkit_pending_headers":  newMethod(s.RollkitPendingHeaders),
+		"rollkit_da_status":        newMethod(s.RollkitDAStatus),
+		"rollkit_sync_status":      newMethod(s.RollkitSyncStatus),
+		"rollkit_node_mode":        newMethod(s.RollkitNodeMode),
+		"rollkit_pending_evidence": newMethod(s.RollkitPendingEvidence),
 	}
 	return &s
 }
@@ -104,33 +113,51 @@ func (s *service) Subscribe(req *http.Request, args *subscribeArgs, wsConn *wsCo
 	ctx, cancel := context.WithTimeout(req.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := s.client.Subscribe(ctx, addr, query, subBufferSize)
+	var sub <-chan ctypes.ResultEvent
+	var err error
+	if args.FromHeight != nil {
+		rs, ok := s.client.(resumableSubscriber)
+		if !ok {
+			return nil, errors.New("resumable subscriptions are not supported")
+		}
+		sub, err = rs.SubscribeFromHeight(ctx, addr, query, int64(*args.FromHeight), subBufferSize)
+	} else {
+		sub, err = s.client.Subscribe(ctx, addr, query, subBufferSize)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to subscribe: %w", err)
 	}
 
-	go func() {
-		var codecReq rpc.CodecRequest
-		if wsConn != nil {
-			codecReq = wsConn.codecReq
-		} else {
-			codecReq = json2.NewCodec().NewRequest(req)
-		}
+	// codec request of WebSocket connection changes with every request, so it's captured here
+	var codecReq rpc.CodecRequest
+	if wsConn != nil {
+		codecReq = wsConn.codecReq
+	} else {
+		codecReq = json2.NewCodec().NewRequest(req)
+	}
 
+	go func() {
 		for msg := range sub {
-			var raw json.RawMessage
-			raw, err = cmjson.Marshal(msg.Data)
 			btz := new(bytes.Buffer)
 			w := newResponseWriter(btz)
-			if err != nil {
-				codecReq.WriteError(w, http.StatusInternalServerError, err)
-				return
+			if terminated, ok := msg.Data.(node.EventDataSubscriptionTerminated); ok {
+				// subscriber can resume from terminated.LastHeight+1
+				codecReq.WriteError(w, int(json2.E_SERVER), &json2.Error{
+					Code:    json2.E_SERVER,
+					Message: terminated.Reason,
+					Data:    terminated,
+				})
+			} else {
+				raw, err := cmjson.Marshal(msg.Data)
+				if err != nil {
+					codecReq.WriteError(w, http.StatusInternalServerError, err)
+					return
+				}
+				codecReq.WriteResponse(w, json.RawMessage(raw))
 			}
-			codecReq.WriteResponse(w, raw)
 
-			data := btz.Bytes()
-			if wsConn != nil {
-				wsConn.queue <- data
+			if wsConn != nil && !wsConn.send(btz.Bytes()) {
+				return
 			}
 		}
 	}()
@@ -171,8 +198,21 @@ func (s *service) Status(req *http.Request, args *statusArgs) (*ctypes.ResultSta
 	return s.client.Status(req.Context())
 }
 
-func (s *service) NetInfo(req *http.Request, args *netInfoArgs) (*ctypes.ResultNetInfo, error) {
-	return s.client.NetInfo(req.Context())
+func (s *service) NetInfo(req *http.Request, args *netInfoArgs) (*netInfoResult, error) {
+	res, err := s.client.NetInfo(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	result := &netInfoResult{
+		Listening: res.Listening,
+		Listeners: res.Listeners,
+		NPeers:    res.NPeers,
+		Peers:     res.Peers,
+	}
+	if c, ok := s.client.(bannedPeersLister); ok {
+		result.BannedPeers = c.BannedPeers()
+	}
+	return result, nil
 }
 
 func (s *service) BlockchainInfo(req *http.Request, args *blockchainInfoArgs) (*ctypes.ResultBlockchainInfo, error) {
@@ -15,11 +15,14 @@ import (
 	"time"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/p2p"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmtypes "github.com/cometbft/cometbft/types"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -154,6 +157,94 @@ func TestStringyRequest(t *testing.T) {
 	assert.Equal(respJSON, resp.Body.String())
 }
 
+func TestBatchRequest(t *testing.T) {
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestBatchRequest")
+	handler, err := GetHTTPHandler(local, log.TestingLogger(), WithMaxBatchSize(3))
+	require.NoError(err)
+
+	serve := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("mixed results", func(t *testing.T) {
+		resp := serve(`[
+			{"jsonrpc":"2.0","id":1,"method":"health","params":{}},
+			{"jsonrpc":"2.0","id":2,"method":"no_such_method","params":{}},
+			{"jsonrpc":"2.0","method":"health","params":{}}
+		]`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var responses []response
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &responses))
+		// notification doesn't have a response
+		require.Len(responses, 2)
+		assert.Equal(t, json.RawMessage("1"), responses[0].ID)
+		assert.Nil(t, responses[0].Error)
+		assert.Equal(t, json.RawMessage("2"), responses[1].ID)
+		require.NotNil(responses[1].Error)
+		assert.Equal(t, json2.E_NO_METHOD, responses[1].Error.Code)
+	})
+
+	batchErrorCases := []struct {
+		name string
+		body string
+		code json2.ErrorCode
+	}{
+		{"empty batch", `[]`, json2.E_INVALID_REQ},
+		{"too large", `[{"jsonrpc":"2.0","id":1,"method":"health","params":{}},{"jsonrpc":"2.0","id":2,"method":"health","params":{}},{"jsonrpc":"2.0","id":3,"method":"health","params":{}},{"jsonrpc":"2.0","id":4,"method":"health","params":{}}]`, json2.E_INVALID_REQ},
+		{"malformed", `[{"jsonrpc":"2.0",`, json2.E_PARSE},
+	}
+	for _, c := range batchErrorCases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := serve(c.body)
+			var jsonResp response
+			require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+			require.NotNil(jsonResp.Error)
+			assert.Equal(t, c.code, jsonResp.Error.Code)
+			assert.Equal(t, json.RawMessage("null"), jsonResp.ID)
+		})
+	}
+
+	t.Run("notifications only", func(t *testing.T) {
+		resp := serve(`[{"jsonrpc":"2.0","method":"health","params":{}}]`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, resp.Body.String())
+	})
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestMaxBodyBytes")
+	handler, err := GetHTTPHandler(local, log.TestingLogger(), WithMaxBodyBytes(100))
+	require.NoError(err)
+
+	serve := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := serve(`{"jsonrpc":"2.0","id":1,"method":"health","params":{}}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var jsonResp response
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	assert.Nil(t, jsonResp.Error)
+
+	resp = serve(`{"jsonrpc":"2.0","id":1,"method":"health","params":{"padding":"` + strings.Repeat("x", 100) + `"}}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	jsonResp = response{}
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	require.NotNil(jsonResp.Error)
+	assert.Equal(t, json2.E_INVALID_REQ, jsonResp.Error.Code)
+}
+
 func TestSubscription(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -403,6 +494,40 @@ func TestRESTSerialization(t *testing.T) {
 	}
 }
 
+func TestBroadcastEvidence(t *testing.T) {
+	require := require.New(t)
+
+	chainID := "TestBroadcastEvidence"
+	header, privKey, err := types.GetRandomSignedHeader(chainID)
+	require.NoError(err)
+	conflicting, err := types.GetConflictingSignedHeader(header, privKey)
+	require.NoError(err)
+	ev, err := types.NewDoubleSignEvidence(header, conflicting)
+	require.NoError(err)
+
+	client := &mocks.Client{}
+	client.On("BroadcastEvidence", mock.Anything, mock.MatchedBy(func(e cmtypes.Evidence) bool {
+		return bytes.Equal(e.Hash(), ev.Hash())
+	})).Return(&coretypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil)
+	handler, err := GetHTTPHandler(client, log.TestingLogger())
+	require.NoError(err)
+
+	evJSON, err := cmjson.Marshal(cmtypes.Evidence(ev))
+	require.NoError(err)
+	body := `{"jsonrpc":"2.0","id":1,"method":"broadcast_evidence","params":{"evidence":` + string(evJSON) + `}}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	var jsonResp response
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &jsonResp))
+	require.Nil(jsonResp.Error)
+	var result coretypes.ResultBroadcastEvidence
+	require.NoError(cmjson.Unmarshal(jsonResp.Result, &result))
+	require.Equal(ev.Hash(), result.Hash)
+	client.AssertExpectations(t)
+}
+
 func TestBlockNumber_UnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1,18 +1,25 @@
 package json
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/cometbft/cometbft/libs/bytes"
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cometbft/cometbft/types"
 	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/rollkit/rollkit/p2p"
 )
 
 type subscribeArgs struct {
 	Query *string `json:"query"`
+	// FromHeight enables replay of NewBlock and Tx events starting at given height.
+	FromHeight *StrInt64 `json:"from_height"`
 }
 
 type unsubscribeArgs struct {
@@ -28,6 +35,26 @@ type statusArgs struct {
 }
 type netInfoArgs struct {
 }
+
+// netInfoResult extends ctypes.ResultNetInfo with peers banned for misbehavior.
+type netInfoResult struct {
+	Listening   bool          `json:"listening"`
+	Listeners   []string      `json:"listeners"`
+	NPeers      int           `json:"n_peers"`
+	Peers       []ctypes.Peer `json:"peers"`
+	BannedPeers []p2p.PeerBan `json:"banned_peers"`
+}
+
+// bannedPeersLister is implemented by clients of nodes tracking reputation of peers.
+type bannedPeersLister interface {
+	BannedPeers() []p2p.PeerBan
+}
+
+// resumableSubscriber is implemented by clients able to replay events of stored blocks.
+type resumableSubscriber interface {
+	SubscribeFromHeight(ctx context.Context, subscriber, query string, fromHeight int64, outCapacity ...int) (<-chan ctypes.ResultEvent, error)
+}
+
 type blockchainInfoArgs struct {
 	MinHeight *StrInt64 `json:"minHeight"`
 	MaxHeight *StrInt64 `json:"maxHeight"`
@@ -138,6 +165,43 @@ type broadcastEvidenceArgs struct {
 	Evidence types.Evidence `json:"evidence"`
 }
 
+// UnmarshalJSON decodes evidence encoded as amino JSON ({"type": ..., "value": ...}).
+func (a *broadcastEvidenceArgs) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Evidence json.RawMessage `json:"evidence"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw.Evidence) == 0 {
+		return nil
+	}
+	return cmjson.Unmarshal(raw.Evidence, &a.Evidence)
+}
+
+// admin API
+
+type dialPeersArgs struct {
+	Peers      []string `json:"peers"`
+	Persistent *bool    `json:"persistent"`
+}
+
+type blockPeerArgs struct {
+	Peer       string `json:"peer"`
+	Persistent *bool  `json:"persistent"`
+}
+
+type unblockPeerArgs struct {
+	Peer string `json:"peer"`
+}
+
+type listBlockedArgs struct{}
+
+type listBlockedResult struct {
+	Peers       []string      `json:"peers"`
+	BannedPeers []p2p.PeerBan `json:"banned_peers"`
+}
+
 type emptyResult struct{}
 
 // JSON-deserialization specific types
@@ -2,6 +2,7 @@ package json
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 
@@ -16,11 +17,29 @@ type wsConn struct {
 	conn     *websocket.Conn
 	codecReq rpc.CodecRequest
 	queue    chan []byte
-	logger   log.Logger
+	// done is closed when connection is closed
+	done   chan struct{}
+	logger log.Logger
+}
+
+// send queues message to be written to the connection. It returns false if connection is already closed.
+func (wsc *wsConn) send(msg []byte) bool {
+	select {
+	case wsc.queue <- msg:
+		return true
+	case <-wsc.done:
+		return false
+	}
 }
 
 func (wsc *wsConn) sendLoop() {
-	for msg := range wsc.queue {
+	for {
+		var msg []byte
+		select {
+		case msg = <-wsc.queue:
+		case <-wsc.done:
+			return
+		}
 		writer, err := wsc.conn.NextWriter(websocket.TextMessage)
 		if err != nil {
 			wsc.logger.Error("failed to create writer", "error", err)
@@ -50,6 +69,8 @@ func (h *handler) wsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	remoteAddr := wsc.RemoteAddr().String()
+	// caller authenticated during upgrade is authorized for every message
+	ctx := context.WithValue(context.Background(), principalKey{}, principalFromContext(r.Context()))
 	defer func() {
 		err := wsc.Close()
 		if err != nil {
@@ -60,9 +81,19 @@ func (h *handler) wsHandler(w http.ResponseWriter, r *http.Request) {
 	ws := &wsConn{
 		conn:   wsc,
 		queue:  make(chan []byte),
+		done:   make(chan struct{}),
 		logger: h.logger,
 	}
 	go ws.sendLoop()
+	defer func() {
+		close(ws.done)
+		// subscriptions of closed connection would never be consumed
+		if h.srv.client != nil {
+			if err := h.srv.client.UnsubscribeAll(context.Background(), remoteAddr); err != nil {
+				h.logger.Debug("failed to unsubscribe closed connection", "remote", remoteAddr, "error", err)
+			}
+		}
+	}()
 
 	for {
 		mt, r, err := wsc.NextReader()
@@ -76,16 +107,16 @@ func (h *handler) wsHandler(w http.ResponseWriter, r *http.Request) {
 			h.logger.Debug("expected text message")
 			continue
 		}
-		req, err := http.NewRequest(http.MethodGet, "", r)
-		req.RemoteAddr = remoteAddr
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "", r)
 		if err != nil {
 			h.logger.Error("failed to create request", "error", err)
 			continue
 		}
+		req.RemoteAddr = remoteAddr
 
 		writer := new(bytes.Buffer)
 		h.serveJSONRPCforWS(newResponseWriter(writer), req, ws)
-		ws.queue <- writer.Bytes()
+		ws.send(writer.Bytes())
 	}
 
 }
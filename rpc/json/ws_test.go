@@ -2,6 +2,7 @@ package json
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -13,11 +14,16 @@ import (
 	"github.com/go-kit/kit/transport/http/jsonrpc"
 
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	cmtypes "github.com/cometbft/cometbft/types"
 	"github.com/gorilla/rpc/v2/json2"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/node"
 )
 
 func TestWebSockets(t *testing.T) {
@@ -89,3 +95,118 @@ func TestWebSockets(t *testing.T) {
 	require.NoError(json.Unmarshal(rsp.Body.Bytes(), &jsonResp))
 	assert.Nil(jsonResp.Error)
 }
+
+func TestWebSocketBatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, local := getRPC(t, "TestWebSocketBatch")
+	handler, err := GetHTTPHandler(local, log.TestingLogger())
+	require.NoError(err)
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(srv.URL, "http://", "ws://", 1)+"/websocket", nil)
+	require.NoError(err)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	err = conn.WriteMessage(websocket.TextMessage, []byte(`[
+{"jsonrpc": "2.0", "method": "health", "id": 1, "params": {}},
+{"jsonrpc": "2.0", "method": "block", "id": 2, "params": {"height": "-1"}}
+]`))
+	require.NoError(err)
+
+	require.NoError(conn.SetReadDeadline(time.Now().Add(1 * time.Second)))
+	typ, msg, err := conn.ReadMessage()
+	require.NoError(err)
+	assert.Equal(websocket.TextMessage, typ)
+
+	var responses []response
+	require.NoError(json.Unmarshal(msg, &responses))
+	require.Len(responses, 2)
+	assert.Equal(json.RawMessage("1"), responses[0].ID)
+	assert.Nil(responses[0].Error)
+	assert.Equal(json.RawMessage("2"), responses[1].ID)
+	assert.NotNil(responses[1].Error)
+}
+
+// resumableClient is a mocked client supporting resumable subscriptions.
+type resumableClient struct {
+	*mocks.Client
+	events chan ctypes.ResultEvent
+}
+
+func (c *resumableClient) SubscribeFromHeight(_ context.Context, _, _ string, fromHeight int64, _ ...int) (<-chan ctypes.ResultEvent, error) {
+	c.events <- ctypes.ResultEvent{Data: node.EventDataSubscriptionTerminated{Reason: "overflow", LastHeight: fromHeight}}
+	close(c.events)
+	return c.events, nil
+}
+
+func TestWebSocketSubscriptionTerminated(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	client := &resumableClient{Client: &mocks.Client{}, events: make(chan ctypes.ResultEvent, 1)}
+	unsubscribed := make(chan struct{})
+	client.On("UnsubscribeAll", mock.Anything, mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		close(unsubscribed)
+	})
+	handler, err := GetHTTPHandler(client, log.TestingLogger())
+	require.NoError(err)
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(srv.URL, "http://", "ws://", 1)+"/websocket", nil)
+	require.NoError(err)
+
+	err = conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc": "2.0", "method": "subscribe", "id": 1, "params": {"query": "tm.event='Tx'", "from_height": "5"}}`))
+	require.NoError(err)
+
+	var responses []response
+	for i := 0; i < 2; i++ {
+		require.NoError(conn.SetReadDeadline(time.Now().Add(time.Second)))
+		_, msg, err := conn.ReadMessage()
+		require.NoError(err)
+		var resp response
+		require.NoError(json.Unmarshal(msg, &resp))
+		responses = append(responses, resp)
+	}
+	// termination may be delivered before subscription result
+	var terminated *json2.Error
+	for _, resp := range responses {
+		if resp.Error != nil {
+			terminated = resp.Error
+		}
+	}
+	require.NotNil(terminated)
+	assert.Equal(json2.E_SERVER, terminated.Code)
+	assert.Equal("overflow", terminated.Message)
+	assert.Equal(map[string]interface{}{"reason": "overflow", "last_height": float64(5)}, terminated.Data)
+
+	// subscriptions are cancelled when connection is closed
+	require.NoError(conn.Close())
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("subscriber was not unsubscribed")
+	}
+}
+
+func TestSubscribeFromHeightNotSupported(t *testing.T) {
+	require := require.New(t)
+
+	handler, err := GetHTTPHandler(&mocks.Client{}, log.TestingLogger())
+	require.NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc": "2.0", "method": "subscribe", "id": 1, "params": {"query": "tm.event='Tx'", "from_height": "5"}}`))
+	rsp := httptest.NewRecorder()
+	handler.ServeHTTP(rsp, req)
+	var resp response
+	require.NoError(json.Unmarshal(rsp.Body.Bytes(), &resp))
+	require.NotNil(resp.Error)
+	require.Contains(resp.Error.Message, "not supported")
+}
@@ -0,0 +1,33 @@
+package rpc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	rollconf "github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/rpc/json"
+)
+
+// RateLimitFromConfig creates JSON-RPC rate limit configuration from node RPC configuration.
+func RateLimitFromConfig(conf rollconf.RPCConfig) (json.RateLimit, error) {
+	rl := json.RateLimit{
+		CostPerSecond: conf.RateLimit,
+		Burst:         conf.RateLimitBurst,
+	}
+	for _, entry := range splitList(conf.MethodCosts, ",") {
+		method, costStr, ok := strings.Cut(entry, ":")
+		if !ok || method == "" {
+			return json.RateLimit{}, fmt.Errorf("invalid RPC method cost %q, expected method:cost", entry)
+		}
+		cost, err := strconv.Atoi(costStr)
+		if err != nil || cost <= 0 {
+			return json.RateLimit{}, fmt.Errorf("invalid cost of RPC method %s: %q", method, costStr)
+		}
+		if rl.MethodCosts == nil {
+			rl.MethodCosts = make(map[string]int)
+		}
+		rl.MethodCosts[method] = cost
+	}
+	return rl, nil
+}
@@ -25,15 +25,19 @@ type Server struct {
 
 	config *config.RPCConfig
 	client rpcclient.Client
+	opts   []json.HandlerOption
 
 	server http.Server
 }
 
 // NewServer creates new instance of Server with given configuration.
-func NewServer(node node.Node, config *config.RPCConfig, logger log.Logger) *Server {
+//
+// Options are applied to JSON-RPC handler, e.g. to enable access control (see json.WithAccessControl).
+func NewServer(node node.Node, config *config.RPCConfig, logger log.Logger, opts ...json.HandlerOption) *Server {
 	srv := &Server{
 		config: config,
 		client: node.GetClient(),
+		opts:   opts,
 	}
 	srv.BaseService = service.NewBaseService(logger, "RPC", srv)
 	return srv
@@ -65,14 +69,7 @@ func (s *Server) startRPC() error {
 		s.Logger.Info("Listen address not specified - RPC will not be exposed")
 		return nil
 	}
-	parts := strings.SplitN(s.config.ListenAddress, "://", 2)
-	if len(parts) != 2 {
-		return errors.New("invalid RPC listen address: expecting tcp://host:port")
-	}
-	proto := parts[0]
-	addr := parts[1]
-
-	listener, err := net.Listen(proto, addr)
+	listener, err := listen(s.config.ListenAddress)
 	if err != nil {
 		return err
 	}
@@ -82,7 +79,11 @@ func (s *Server) startRPC() error {
 		listener = netutil.LimitListener(listener, s.config.MaxOpenConnections)
 	}
 
-	handler, err := json.GetHTTPHandler(s.client, s.Logger)
+	opts := append([]json.HandlerOption{
+		json.WithMaxBatchSize(s.config.MaxRequestBatchSize),
+		json.WithMaxBodyBytes(s.config.MaxBodyBytes),
+	}, s.opts...)
+	handler, err := json.GetHTTPHandler(s.client, s.Logger, opts...)
 	if err != nil {
 		return err
 	}
@@ -122,3 +123,12 @@ func (s *Server) serve(listener net.Listener, handler http.Handler) error {
 	}
 	return s.server.Serve(listener)
 }
+
+// listen creates listener for address in form of proto://host:port.
+func listen(address string) (net.Listener, error) {
+	parts := strings.SplitN(address, "://", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid RPC listen address: expecting tcp://host:port")
+	}
+	return net.Listen(parts[0], parts[1])
+}
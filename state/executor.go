@@ -25,6 +25,16 @@ var ErrEmptyValSetGenerated = errors.New("applying the validator changes would r
 // ErrAddingValidatorToBased is returned when trying to add a validator to an empty validator set.
 var ErrAddingValidatorToBased = errors.New("cannot add validators to empty validator set")
 
+// EvidencePool provides evidence to include in blocks, and verifies evidence included in blocks.
+type EvidencePool interface {
+	// PendingEvidence returns evidence to include in the next block, up to maxBytes, and its size.
+	PendingEvidence(maxBytes int64) ([]cmtypes.Evidence, int64)
+	// CheckEvidence verifies evidence included in a block applied on top of given state.
+	CheckEvidence(state types.State, evidence cmtypes.EvidenceList) error
+	// Update marks evidence included in committed block as committed.
+	Update(state types.State, evidence cmtypes.EvidenceList)
+}
+
 // BlockExecutor creates and applies blocks and maintains state.
 type BlockExecutor struct {
 	proposerAddress []byte
@@ -32,6 +42,7 @@ type BlockExecutor struct {
 	proxyApp        proxy.AppConnConsensus
 	mempool         mempool.Mempool
 	mempoolReaper   *mempool.CListMempoolReaper
+	evpool          EvidencePool
 	maxBytes        uint64
 
 	eventBus *cmtypes.EventBus
@@ -41,14 +52,15 @@ type BlockExecutor struct {
 	metrics *Metrics
 }
 
-// NewBlockExecutor creates new instance of BlockExecutor.
-func NewBlockExecutor(proposerAddress []byte, chainID string, mempool mempool.Mempool, mempoolReaper *mempool.CListMempoolReaper, proxyApp proxy.AppConnConsensus, eventBus *cmtypes.EventBus, maxBytes uint64, logger log.Logger, metrics *Metrics) *BlockExecutor {
+// NewBlockExecutor creates new instance of BlockExecutor. If evpool is nil, blocks are created without evidence.
+func NewBlockExecutor(proposerAddress []byte, chainID string, mempool mempool.Mempool, mempoolReaper *mempool.CListMempoolReaper, evpool EvidencePool, proxyApp proxy.AppConnConsensus, eventBus *cmtypes.EventBus, maxBytes uint64, logger log.Logger, metrics *Metrics) *BlockExecutor {
 	return &BlockExecutor{
 		proposerAddress: proposerAddress,
 		chainID:         chainID,
 		proxyApp:        proxyApp,
 		mempool:         mempool,
 		mempoolReaper:   mempoolReaper,
+		evpool:          evpool,
 		eventBus:        eventBus,
 		maxBytes:        maxBytes,
 		logger:          logger,
@@ -106,6 +118,18 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastSignature *types.Signatur
 		maxBytes = int64(e.maxBytes) //nolint:gosec
 	}
 
+	var evidence []cmtypes.Evidence
+	if e.evpool != nil {
+		// evidence is included before transactions, and counts towards block size
+		maxEvidenceBytes := maxBytes
+		if params := state.ConsensusParams.Evidence; params != nil && params.MaxBytes >= 0 && params.MaxBytes < maxBytes {
+			maxEvidenceBytes = params.MaxBytes
+		}
+		var evSize int64
+		evidence, evSize = e.evpool.PendingEvidence(maxEvidenceBytes)
+		maxBytes -= evSize
+	}
+
 	header := &types.SignedHeader{
 		Header: types.Header{
 			Version: types.Version{
@@ -128,8 +152,7 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastSignature *types.Signatur
 	data := &types.Data{
 		Txs: toRollkitTxs(txs),
 		// IntermediateStateRoots: types.IntermediateStateRoots{RawRootsList: nil},
-		// Note: Temporarily remove Evidence #896
-		// Evidence:               types.EvidenceData{Evidence: nil},
+		Evidence: types.EvidenceData{Evidence: evidence},
 	}
 
 	rpp, err := e.proxyApp.PrepareProposal(
@@ -138,7 +161,7 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastSignature *types.Signatur
 			MaxTxBytes:         maxBytes,
 			Txs:                txs.ToSliceOfBytes(),
 			LocalLastCommit:    lastExtendedCommit,
-			Misbehavior:        []abci.Misbehavior{},
+			Misbehavior:        cmtypes.EvidenceList(data.Evidence.Evidence).ToABCI(),
 			Height:             int64(header.Height()), //nolint:gosec
 			Time:               header.Time(),          //TODO: replace with sequencer timestamp
 			NextValidatorsHash: state.Validators.Hash(),
@@ -193,7 +216,7 @@ func (e *BlockExecutor) ProcessProposal(
 				},
 			},
 		},
-		Misbehavior:        []abci.Misbehavior{},
+		Misbehavior:        cmtypes.EvidenceList(data.Evidence.Evidence).ToABCI(),
 		ProposerAddress:    e.proposerAddress,
 		NextValidatorsHash: state.Validators.Hash(),
 	})
@@ -265,7 +288,7 @@ func (e *BlockExecutor) ExtendVote(ctx context.Context, header *types.SignedHead
 				BlockIdFlag: cmproto.BlockIDFlagCommit,
 			}},
 		},
-		Misbehavior:        nil,
+		Misbehavior:        cmtypes.EvidenceList(data.Evidence.Evidence).ToABCI(),
 		NextValidatorsHash: header.ValidatorHash,
 		ProposerAddress:    header.ProposerAddress,
 	})
@@ -381,6 +404,9 @@ func (e *BlockExecutor) commit(ctx context.Context, state types.State, header *t
 	if err != nil {
 		return nil, 0, err
 	}
+	if e.evpool != nil {
+		e.evpool.Update(state, data.Evidence.Evidence)
+	}
 
 	return resp.AppHash, uint64(commitResp.RetainHeight), err //nolint:gosec
 }
@@ -414,6 +440,15 @@ func (e *BlockExecutor) Validate(state types.State, header *types.SignedHeader,
 		return errors.New("LastResultsHash mismatch")
 	}
 
+	if len(data.Evidence.Evidence) > 0 {
+		if e.evpool == nil {
+			return errors.New("block contains evidence, but evidence pool is not available")
+		}
+		if err := e.evpool.CheckEvidence(state, data.Evidence.Evidence); err != nil {
+			return fmt.Errorf("invalid evidence: %w", err)
+		}
+	}
+
 	return nil
 }
 
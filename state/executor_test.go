@@ -56,7 +56,7 @@ func doTestCreateBlock(t *testing.T) {
 	fmt.Println("Made NID")
 	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client, proxy.NopMetrics()), 0)
 	fmt.Println("Made a NewTxMempool")
-	executor := NewBlockExecutor([]byte("test address"), "doTestCreateBlock", mpool, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 100, logger, NopMetrics())
+	executor := NewBlockExecutor([]byte("test address"), "doTestCreateBlock", mpool, nil, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 100, logger, NopMetrics())
 	fmt.Println("Made a New Block Executor")
 
 	state := types.State{}
@@ -121,6 +121,70 @@ func TestCreateBlockWithFraudProofsDisabled(t *testing.T) {
 	doTestCreateBlock(t)
 }
 
+type mockEvidencePool struct {
+	evidence []cmtypes.Evidence
+	maxBytes int64
+}
+
+func (p *mockEvidencePool) PendingEvidence(maxBytes int64) ([]cmtypes.Evidence, int64) {
+	p.maxBytes = maxBytes
+	var size int64
+	for _, ev := range p.evidence {
+		size += int64(len(ev.Bytes()))
+	}
+	if size > maxBytes {
+		return nil, 0
+	}
+	return p.evidence, size
+}
+
+func (p *mockEvidencePool) CheckEvidence(types.State, cmtypes.EvidenceList) error { return nil }
+
+func (p *mockEvidencePool) Update(types.State, cmtypes.EvidenceList) {}
+
+func TestCreateBlockWithEvidence(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "TestCreateBlockWithEvidence"
+	header, privKey, err := types.GetRandomSignedHeader(chainID)
+	require.NoError(err)
+	conflicting, err := types.GetConflictingSignedHeader(header, privKey)
+	require.NoError(err)
+	ev, err := types.NewDoubleSignEvidence(header, conflicting)
+	require.NoError(err)
+	evpool := &mockEvidencePool{evidence: []cmtypes.Evidence{ev}}
+
+	app := &mocks.Application{}
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(prepareProposalResponse)
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempool.NewCListMempool(cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client, proxy.NopMetrics()), 0)
+	executor := NewBlockExecutor([]byte("test address"), chainID, mpool, nil, evpool, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), nil, 10000, log.TestingLogger(), NopMetrics())
+
+	state := types.State{Validators: header.Validators}
+	state.ConsensusParams.Block = &cmproto.BlockParams{MaxBytes: 10000, MaxGas: 100000}
+	state.ConsensusParams.Evidence = &cmproto.EvidenceParams{MaxBytes: 5000}
+
+	_, data, err := executor.CreateBlock(1, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{}, time.Now())
+	require.NoError(err)
+	assert.Equal(int64(5000), evpool.maxBytes)
+	require.Len(data.Evidence.Evidence, 1)
+	assert.Equal(ev.Hash(), data.Evidence.Evidence[0].Hash())
+
+	// evidence is passed to the app as misbehavior, and counts towards max tx bytes
+	req := app.Calls[len(app.Calls)-1].Arguments.Get(1).(*abci.RequestPrepareProposal)
+	require.Len(req.Misbehavior, 1)
+	assert.Equal(abci.MisbehaviorType_DUPLICATE_VOTE, req.Misbehavior[0].Type)
+	assert.Equal(int64(10000-len(ev.Bytes())), req.MaxTxBytes)
+
+	// evidence exceeding evidence.max_bytes is not included
+	state.ConsensusParams.Evidence.MaxBytes = 10
+	_, data, err = executor.CreateBlock(2, &types.Signature{}, abci.ExtendedCommitInfo{}, []byte{}, state, cmtypes.Txs{}, time.Now())
+	require.NoError(err)
+	assert.Empty(data.Evidence.Evidence)
+}
+
 func doTestApplyBlock(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -202,7 +266,7 @@ func doTestApplyBlock(t *testing.T) {
 	state.ConsensusParams.Block.MaxBytes = 100
 	state.ConsensusParams.Block.MaxGas = 100000
 
-	executor := NewBlockExecutor(vKey.PubKey().Address().Bytes(), chainID, mpool, mpoolReaper, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), eventBus, 100, logger, NopMetrics())
+	executor := NewBlockExecutor(vKey.PubKey().Address().Bytes(), chainID, mpool, mpoolReaper, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), eventBus, 100, logger, NopMetrics())
 
 	tx := []byte{1, 2, 3, 4}
 	err = mpool.CheckTx(tx, func(r *abci.ResponseCheckTx) {}, mempool.TxInfo{})
@@ -311,7 +375,7 @@ func TestUpdateStateConsensusParams(t *testing.T) {
 	require.NoError(t, mpoolReaper.StartReaper(context.Background()))
 	eventBus := cmtypes.NewEventBus()
 	require.NoError(t, eventBus.Start())
-	executor := NewBlockExecutor([]byte("test address"), chainID, mpool, mpoolReaper, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), eventBus, 100, logger, NopMetrics())
+	executor := NewBlockExecutor([]byte("test address"), chainID, mpool, mpoolReaper, nil, proxy.NewAppConnConsensus(client, proxy.NopMetrics()), eventBus, 100, logger, NopMetrics())
 
 	state := types.State{
 		ConsensusParams: cmproto.ConsensusParams{
@@ -0,0 +1,484 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtypes "github.com/cometbft/cometbft/types"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// Archive format
+//
+// An archive starts with an 8 byte magic string followed by the format version (uint32, big endian).
+// The rest of the archive is a sequence of chunks:
+//
+//	kind (1 byte) | payload length (uint32, big endian) | payload | sha256(payload) (32 bytes)
+//
+// Block chunks contain a number of consecutive blocks. Every block is encoded as a sequence of
+// length-prefixed (uvarint) fields: header, data, signature, block responses, extended commit and
+// the protobuf encoded state after applying the block.
+// Optional fields (block responses, extended commit, state) are empty if not available.
+// The state chunk contains the protobuf encoded state after applying the last block in the archive
+// and is always the last chunk.
+
+const (
+	// ArchiveVersion is the version of the archive format written by Export.
+	ArchiveVersion uint32 = 1
+
+	// DefaultArchiveChunkSize is the default number of blocks stored in a single archive chunk.
+	DefaultArchiveChunkSize = 100
+
+	archiveMagic = "RKARCHIV"
+
+	// maxArchiveChunkLength protects from allocating huge buffers when reading corrupted archives.
+	maxArchiveChunkLength = 1 << 30
+
+	chunkKindBlocks byte = 1
+	chunkKindState  byte = 2
+)
+
+// importedHeightKey is the metadata key under which Import saves the height of the last imported block.
+const importedHeightKey = "imported-height"
+
+var (
+	// ErrInvalidArchive is returned when archive can't be decoded.
+	ErrInvalidArchive = errors.New("invalid archive")
+
+	// ErrArchiveChecksumMismatch is returned when checksum of archive chunk doesn't match its content.
+	ErrArchiveChecksumMismatch = errors.New("archive chunk checksum mismatch")
+
+	// ErrUnsupportedArchiveVersion is returned when archive was written with unknown version of the format.
+	ErrUnsupportedArchiveVersion = errors.New("unsupported archive version")
+)
+
+// ArchiveBlock contains all the data stored for a single block.
+type ArchiveBlock struct {
+	Header         *types.SignedHeader
+	Data           *types.Data
+	Signature      types.Signature
+	Responses      *abci.ResponseFinalizeBlock
+	ExtendedCommit *abci.ExtendedCommitInfo
+	State          *types.State
+}
+
+// Export writes blocks from given height range (inclusive), along with the state after applying the
+// last block, to w. Blocks are grouped in chunks of chunkSize blocks.
+func Export(ctx context.Context, s Store, w io.Writer, from, to uint64, chunkSize int) error {
+	if from == 0 || from > to {
+		return fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	if to > s.Height() {
+		return fmt.Errorf("height %d is greater than store height %d", to, s.Height())
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultArchiveChunkSize
+	}
+
+	state, err := s.GetStateAtHeight(ctx, to)
+	if errors.Is(err, ds.ErrNotFound) && to == s.Height() {
+		state, err = s.GetState(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load state at height %d: %w", to, err)
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(archiveMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, ArchiveVersion); err != nil {
+		return err
+	}
+
+	var chunk bytes.Buffer
+	count := 0
+	for height := from; height <= to; height++ {
+		block, err := loadArchiveBlock(ctx, s, height)
+		if err != nil {
+			return err
+		}
+		if err := encodeArchiveBlock(&chunk, block); err != nil {
+			return err
+		}
+		count++
+		if count == chunkSize || height == to {
+			payload := binary.AppendUvarint(nil, uint64(count)) //nolint:gosec
+			payload = append(payload, chunk.Bytes()...)
+			if err := writeArchiveChunk(bw, chunkKindBlocks, payload); err != nil {
+				return err
+			}
+			chunk.Reset()
+			count = 0
+		}
+	}
+
+	pbState, err := state.ToProto()
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	stateBlob, err := pbState.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := writeArchiveChunk(bw, chunkKindState, stateBlob); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// Import reads archive written by Export from r and saves its content in the store.
+//
+// Every block is validated with types.Validate and verified against the previous header. If the
+// store is not empty, archive has to start at the next height after the last block in the store.
+// Otherwise, the first block has to be the initial block of the chain described by genesis.
+// States stored along with blocks are saved, so that they're available with GetStateAtHeight.
+// Import returns the height of the last imported block.
+//
+// Imported blocks are not executed, so the ABCI app has to be restored separately, from a snapshot
+// taken at the height of the last imported block. The height is saved in the store (see GetImportedHeight),
+// so that the node can verify the app before it continues from the imported state.
+func Import(ctx context.Context, s Store, r io.Reader, genesis *cmtypes.GenesisDoc) (uint64, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != archiveMagic {
+		return 0, fmt.Errorf("%w: missing archive header", ErrInvalidArchive)
+	}
+	var version uint32
+	if err := binary.Read(br, binary.BigEndian, &version); err != nil {
+		return 0, fmt.Errorf("%w: missing archive version", ErrInvalidArchive)
+	}
+	if version != ArchiveVersion {
+		return 0, fmt.Errorf("%w: %d", ErrUnsupportedArchiveVersion, version)
+	}
+
+	var trusted *types.SignedHeader
+	if height := s.Height(); height > 0 {
+		header, _, err := s.GetBlockData(ctx, height)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load last block from store: %w", err)
+		}
+		trusted = header
+	}
+
+	var lastHeight uint64
+	for {
+		kind, payload, err := readArchiveChunk(br)
+		if errors.Is(err, io.EOF) {
+			return lastHeight, fmt.Errorf("%w: missing state", ErrInvalidArchive)
+		}
+		if err != nil {
+			return lastHeight, err
+		}
+
+		switch kind {
+		case chunkKindBlocks:
+			blocks, err := decodeArchiveBlocks(payload)
+			if err != nil {
+				return lastHeight, err
+			}
+			for _, block := range blocks {
+				if err := verifyArchiveBlock(trusted, block); err != nil {
+					return lastHeight, fmt.Errorf("invalid block at height %d: %w", block.Header.Height(), err)
+				}
+				if trusted == nil {
+					if err := verifyGenesisBlock(genesis, block); err != nil {
+						return lastHeight, fmt.Errorf("invalid block at height %d: %w", block.Header.Height(), err)
+					}
+				}
+				if err := saveArchiveBlock(ctx, s, block); err != nil {
+					return lastHeight, err
+				}
+				trusted = block.Header
+				lastHeight = block.Header.Height()
+			}
+		case chunkKindState:
+			var pbState pb.State
+			if err := pbState.Unmarshal(payload); err != nil {
+				return lastHeight, fmt.Errorf("%w: failed to unmarshal state: %w", ErrInvalidArchive, err)
+			}
+			var state types.State
+			if err := state.FromProto(&pbState); err != nil {
+				return lastHeight, fmt.Errorf("%w: failed to unmarshal state: %w", ErrInvalidArchive, err)
+			}
+			if state.LastBlockHeight != lastHeight {
+				return lastHeight, fmt.Errorf("%w: state height %d doesn't match last block height %d", ErrInvalidArchive, state.LastBlockHeight, lastHeight)
+			}
+			if err := s.UpdateState(ctx, state); err != nil {
+				return lastHeight, fmt.Errorf("failed to save state: %w", err)
+			}
+			if err := s.SetMetadata(ctx, importedHeightKey, encodeHeight(lastHeight)); err != nil {
+				return lastHeight, fmt.Errorf("failed to save imported height: %w", err)
+			}
+			return lastHeight, nil
+		default:
+			return lastHeight, fmt.Errorf("%w: unknown chunk kind %d", ErrInvalidArchive, kind)
+		}
+	}
+}
+
+// GetImportedHeight returns the height of the last block imported with Import. It returns false if no blocks
+// were imported into the store.
+func GetImportedHeight(ctx context.Context, s Store) (uint64, bool, error) {
+	value, err := s.GetMetadata(ctx, importedHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	height, err := decodeHeight(value)
+	if err != nil {
+		return 0, false, err
+	}
+	return height, true, nil
+}
+
+func loadArchiveBlock(ctx context.Context, s Store, height uint64) (*ArchiveBlock, error) {
+	header, data, err := s.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block at height %d: %w", height, err)
+	}
+	signature, err := s.GetSignature(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signature at height %d: %w", height, err)
+	}
+	responses, err := s.GetBlockResponses(ctx, height)
+	if errors.Is(err, ds.ErrNotFound) {
+		responses = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load block responses at height %d: %w", height, err)
+	}
+	extendedCommit, err := s.GetExtendedCommit(ctx, height)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load extended commit at height %d: %w", height, err)
+	}
+	var state *types.State
+	if st, err := s.GetStateAtHeight(ctx, height); err == nil {
+		state = &st
+	} else if !errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load state at height %d: %w", height, err)
+	}
+	return &ArchiveBlock{
+		Header:         header,
+		Data:           data,
+		Signature:      *signature,
+		Responses:      responses,
+		ExtendedCommit: extendedCommit,
+		State:          state,
+	}, nil
+}
+
+func saveArchiveBlock(ctx context.Context, s Store, block *ArchiveBlock) error {
+	height := block.Header.Height()
+	if err := s.SaveBlockData(ctx, block.Header, block.Data, &block.Signature); err != nil {
+		return fmt.Errorf("failed to save block at height %d: %w", height, err)
+	}
+	if block.Responses != nil {
+		if err := s.SaveBlockResponses(ctx, height, block.Responses); err != nil {
+			return fmt.Errorf("failed to save block responses at height %d: %w", height, err)
+		}
+	}
+	if block.ExtendedCommit != nil {
+		if err := s.SaveExtendedCommit(ctx, height, block.ExtendedCommit); err != nil {
+			return fmt.Errorf("failed to save extended commit at height %d: %w", height, err)
+		}
+	}
+	if block.State != nil {
+		if err := s.UpdateState(ctx, *block.State); err != nil {
+			return fmt.Errorf("failed to save state at height %d: %w", height, err)
+		}
+	}
+	s.SetHeight(ctx, height)
+	return nil
+}
+
+func verifyArchiveBlock(trusted *types.SignedHeader, block *ArchiveBlock) error {
+	if err := block.Header.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := types.Validate(block.Header, block.Data); err != nil {
+		return err
+	}
+	if trusted == nil {
+		return nil
+	}
+	if block.Header.Height() != trusted.Height()+1 {
+		return fmt.Errorf("expected height %d", trusted.Height()+1)
+	}
+	return trusted.Verify(block.Header)
+}
+
+// verifyGenesisBlock checks that block is the initial block of the chain, proposed by the genesis sequencer.
+func verifyGenesisBlock(genesis *cmtypes.GenesisDoc, block *ArchiveBlock) error {
+	if genesis == nil {
+		return errors.New("genesis is required to import into an empty store")
+	}
+	if len(genesis.Validators) == 0 {
+		return errors.New("genesis has no validators")
+	}
+	if block.Header.ChainID() != genesis.ChainID {
+		return fmt.Errorf("expected chain ID %s, got %s", genesis.ChainID, block.Header.ChainID())
+	}
+	if block.Header.Height() != uint64(genesis.InitialHeight) { //nolint:gosec
+		return fmt.Errorf("expected initial height %d", genesis.InitialHeight)
+	}
+	sequencer := genesis.Validators[0]
+	if !bytes.Equal(block.Header.ProposerAddress, sequencer.Address.Bytes()) {
+		return fmt.Errorf("proposer %X is not the genesis sequencer %X", block.Header.ProposerAddress, sequencer.Address.Bytes())
+	}
+	if !bytes.Equal(block.Header.Validators.Validators[0].PubKey.Bytes(), sequencer.PubKey.Bytes()) {
+		return errors.New("validator set doesn't match genesis")
+	}
+	return nil
+}
+
+func encodeArchiveBlock(buf *bytes.Buffer, block *ArchiveBlock) error {
+	headerBlob, err := block.Header.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal header: %w", err)
+	}
+	dataBlob, err := block.Data.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	var responsesBlob, extendedCommitBlob, stateBlob []byte
+	if block.Responses != nil {
+		if responsesBlob, err = block.Responses.Marshal(); err != nil {
+			return fmt.Errorf("failed to marshal block responses: %w", err)
+		}
+	}
+	if block.ExtendedCommit != nil {
+		if extendedCommitBlob, err = block.ExtendedCommit.Marshal(); err != nil {
+			return fmt.Errorf("failed to marshal extended commit: %w", err)
+		}
+	}
+	if block.State != nil {
+		pbState, err := block.State.ToProto()
+		if err != nil {
+			return fmt.Errorf("failed to marshal state: %w", err)
+		}
+		if stateBlob, err = pbState.Marshal(); err != nil {
+			return fmt.Errorf("failed to marshal state: %w", err)
+		}
+	}
+	for _, field := range [][]byte{headerBlob, dataBlob, block.Signature, responsesBlob, extendedCommitBlob, stateBlob} {
+		buf.Write(binary.AppendUvarint(nil, uint64(len(field))))
+		buf.Write(field)
+	}
+	return nil
+}
+
+func decodeArchiveBlocks(payload []byte) ([]*ArchiveBlock, error) {
+	r := bytes.NewReader(payload)
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read block count: %w", ErrInvalidArchive, err)
+	}
+	blocks := make([]*ArchiveBlock, 0, min(count, DefaultArchiveChunkSize))
+	for i := uint64(0); i < count; i++ {
+		fields := make([][]byte, 6)
+		for j := range fields {
+			length, err := binary.ReadUvarint(r)
+			if err != nil || length > uint64(r.Len()) {
+				return nil, fmt.Errorf("%w: truncated block", ErrInvalidArchive)
+			}
+			fields[j] = make([]byte, length)
+			if _, err := io.ReadFull(r, fields[j]); err != nil {
+				return nil, fmt.Errorf("%w: truncated block", ErrInvalidArchive)
+			}
+		}
+
+		block := &ArchiveBlock{
+			Header:    new(types.SignedHeader),
+			Data:      new(types.Data),
+			Signature: fields[2],
+		}
+		if err := block.Header.UnmarshalBinary(fields[0]); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal header: %w", ErrInvalidArchive, err)
+		}
+		if err := block.Data.UnmarshalBinary(fields[1]); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal data: %w", ErrInvalidArchive, err)
+		}
+		if len(fields[3]) > 0 {
+			block.Responses = new(abci.ResponseFinalizeBlock)
+			if err := block.Responses.Unmarshal(fields[3]); err != nil {
+				return nil, fmt.Errorf("%w: failed to unmarshal block responses: %w", ErrInvalidArchive, err)
+			}
+		}
+		if len(fields[4]) > 0 {
+			block.ExtendedCommit = new(abci.ExtendedCommitInfo)
+			if err := block.ExtendedCommit.Unmarshal(fields[4]); err != nil {
+				return nil, fmt.Errorf("%w: failed to unmarshal extended commit: %w", ErrInvalidArchive, err)
+			}
+		}
+		if len(fields[5]) > 0 {
+			var pbState pb.State
+			if err := pbState.Unmarshal(fields[5]); err != nil {
+				return nil, fmt.Errorf("%w: failed to unmarshal state: %w", ErrInvalidArchive, err)
+			}
+			block.State = new(types.State)
+			if err := block.State.FromProto(&pbState); err != nil {
+				return nil, fmt.Errorf("%w: failed to unmarshal state: %w", ErrInvalidArchive, err)
+			}
+			if block.State.LastBlockHeight != block.Header.Height() {
+				return nil, fmt.Errorf("%w: state height %d doesn't match block height %d", ErrInvalidArchive, block.State.LastBlockHeight, block.Header.Height())
+			}
+		}
+		blocks = append(blocks, block)
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: unexpected data after blocks", ErrInvalidArchive)
+	}
+	return blocks, nil
+}
+
+func writeArchiveChunk(w io.Writer, kind byte, payload []byte) error {
+	header := make([]byte, 5)
+	header[0] = kind
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload))) //nolint:gosec
+	checksum := sha256.Sum256(payload)
+	for _, b := range [][]byte{header, payload, checksum[:]} {
+		if _, err := w.Write(b); err != nil {
+			return fmt.Errorf("failed to write archive chunk: %w", err)
+		}
+	}
+	return nil
+}
+
+func readArchiveChunk(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, fmt.Errorf("%w: truncated chunk header", ErrInvalidArchive)
+	}
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > maxArchiveChunkLength {
+		return 0, nil, fmt.Errorf("%w: chunk too big (%d bytes)", ErrInvalidArchive, length)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, fmt.Errorf("%w: truncated chunk", ErrInvalidArchive)
+	}
+	var checksum [sha256.Size]byte
+	if _, err := io.ReadFull(r, checksum[:]); err != nil {
+		return 0, nil, fmt.Errorf("%w: truncated chunk checksum", ErrInvalidArchive)
+	}
+	if sha256.Sum256(payload) != checksum {
+		return 0, nil, ErrArchiveChecksumMismatch
+	}
+	return header[0], payload, nil
+}
@@ -0,0 +1,218 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func newArchiveTestStore(t *testing.T, chainID string, n uint64) (Store, *cmtypes.GenesisDoc) {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	privKey := ed25519.GenPrivKey()
+	genesis := &cmtypes.GenesisDoc{
+		ChainID:       chainID,
+		InitialHeight: 1,
+		Validators: []cmtypes.GenesisValidator{{
+			Address: privKey.PubKey().Address(),
+			PubKey:  privKey.PubKey(),
+			Power:   1,
+		}},
+	}
+
+	header, data, _ := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, NTxs: 2, PrivKey: privKey}, chainID)
+	for height := uint64(1); height <= n; height++ {
+		if height > 1 {
+			header, data = types.GetRandomNextBlock(header, data, privKey, []byte{1, 2, 3}, 2, chainID)
+		}
+		require.NoError(s.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(s.SaveBlockResponses(ctx, height, &abcitypes.ResponseFinalizeBlock{AppHash: []byte{byte(height)}}))
+		require.NoError(s.UpdateState(ctx, types.State{
+			ChainID:         chainID,
+			LastBlockHeight: height,
+			Validators:      header.Validators,
+			NextValidators:  header.Validators,
+			LastValidators:  header.Validators,
+		}))
+		s.SetHeight(ctx, height)
+	}
+	return s, genesis
+}
+
+func TestArchiveExportImport(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	const n = 25
+	src, genesis := newArchiveTestStore(t, "TestArchiveExportImport", n)
+
+	var archive bytes.Buffer
+	require.NoError(Export(ctx, src, &archive, 1, n, 10))
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	dst := New(kv)
+
+	_, imported, err := GetImportedHeight(ctx, dst)
+	require.NoError(err)
+	assert.False(imported)
+
+	height, err := Import(ctx, dst, bytes.NewReader(archive.Bytes()), genesis)
+	require.NoError(err)
+	assert.Equal(uint64(n), height)
+	assert.Equal(uint64(n), dst.Height())
+
+	// app has to be restored to the imported height before the node starts
+	importedHeight, imported, err := GetImportedHeight(ctx, dst)
+	require.NoError(err)
+	assert.True(imported)
+	assert.Equal(uint64(n), importedHeight)
+
+	for h := uint64(1); h <= n; h++ {
+		expectedHeader, expectedData, err := src.GetBlockData(ctx, h)
+		require.NoError(err)
+		header, data, err := dst.GetBlockData(ctx, h)
+		require.NoError(err)
+		assert.Equal(expectedHeader.Hash(), header.Hash())
+		assert.Equal(expectedData.Hash(), data.Hash())
+
+		signature, err := dst.GetSignature(ctx, h)
+		require.NoError(err)
+		assert.Equal(expectedHeader.Signature, *signature)
+
+		responses, err := dst.GetBlockResponses(ctx, h)
+		require.NoError(err)
+		assert.Equal([]byte{byte(h)}, responses.AppHash)
+
+		state, err := dst.GetStateAtHeight(ctx, h)
+		require.NoError(err)
+		assert.Equal(h, state.LastBlockHeight)
+	}
+
+	state, err := dst.GetState(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(n), state.LastBlockHeight)
+}
+
+func TestArchiveImportContinuesChain(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctx := context.Background()
+
+	const n = 10
+	src, genesis := newArchiveTestStore(t, "TestArchiveImportContinuesChain", n)
+
+	var first, second bytes.Buffer
+	require.NoError(Export(ctx, src, &first, 1, 5, 0))
+	require.NoError(Export(ctx, src, &second, 6, n, 0))
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	dst := New(kv)
+
+	// archive that doesn't start at genesis can't be imported into an empty store
+	_, err = Import(ctx, dst, bytes.NewReader(second.Bytes()), genesis)
+	require.Error(err)
+
+	// archive that doesn't continue the chain from the last stored block is rejected
+	_, err = Import(ctx, dst, bytes.NewReader(first.Bytes()), genesis)
+	require.NoError(err)
+	_, err = Import(ctx, dst, bytes.NewReader(first.Bytes()), genesis)
+	require.Error(err)
+
+	height, err := Import(ctx, dst, bytes.NewReader(second.Bytes()), genesis)
+	require.NoError(err)
+	require.Equal(uint64(n), height)
+}
+
+func TestArchiveImportInvalid(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	src, genesis := newArchiveTestStore(t, "TestArchiveImportInvalid", 3)
+	var archive bytes.Buffer
+	require.NoError(t, Export(ctx, src, &archive, 1, 3, 0))
+
+	corrupted := bytes.Clone(archive.Bytes())
+	corrupted[len(archiveMagic)+4+5+10] ^= 0xff
+
+	wrongVersion := bytes.Clone(archive.Bytes())
+	wrongVersion[len(archiveMagic)+3] = 2
+
+	cases := []struct {
+		name     string
+		archive  []byte
+		expected error
+	}{
+		{"empty", nil, ErrInvalidArchive},
+		{"corrupted chunk", corrupted, ErrArchiveChecksumMismatch},
+		{"unsupported version", wrongVersion, ErrUnsupportedArchiveVersion},
+		{"truncated", archive.Bytes()[:archive.Len()-10], ErrInvalidArchive},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kv, err := NewDefaultInMemoryKVStore()
+			require.NoError(t, err)
+			s := New(kv)
+			_, err = Import(ctx, s, bytes.NewReader(c.archive), genesis)
+			require.ErrorIs(t, err, c.expected)
+		})
+	}
+}
+
+func TestArchiveImportVerifiesGenesis(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	src, genesis := newArchiveTestStore(t, "TestArchiveImportVerifiesGenesis", 3)
+	var archive bytes.Buffer
+	require.NoError(t, Export(ctx, src, &archive, 1, 3, 0))
+
+	otherKey := ed25519.GenPrivKey()
+	otherSequencer := *genesis
+	otherSequencer.Validators = []cmtypes.GenesisValidator{{
+		Address: otherKey.PubKey().Address(),
+		PubKey:  otherKey.PubKey(),
+		Power:   1,
+	}}
+	otherChain := *genesis
+	otherChain.ChainID = "other"
+	otherHeight := *genesis
+	otherHeight.InitialHeight = 2
+
+	cases := []struct {
+		name    string
+		genesis *cmtypes.GenesisDoc
+	}{
+		{"missing genesis", nil},
+		{"different sequencer", &otherSequencer},
+		{"different chain ID", &otherChain},
+		{"different initial height", &otherHeight},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kv, err := NewDefaultInMemoryKVStore()
+			require.NoError(t, err)
+			s := New(kv)
+			_, err = Import(ctx, s, bytes.NewReader(archive.Bytes()), c.genesis)
+			require.Error(t, err)
+			assert.Equal(t, uint64(0), s.Height())
+		})
+	}
+}
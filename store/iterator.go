@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Order defines the order of iteration over heights.
+type Order int
+
+const (
+	// Ascending iterates from the lowest to the highest height.
+	Ascending Order = iota
+	// Descending iterates from the highest to the lowest height.
+	Descending
+)
+
+// Iterator iterates over blocks saved in Store, in order of heights. Missing heights are skipped.
+type Iterator interface {
+	// Next advances iterator to the next block. It returns false when there are no more blocks or iteration failed.
+	Next() bool
+	// Header returns header of the current block.
+	Header() *types.SignedHeader
+	// Data returns data of the current block. It's nil for iterators returned by IterateHeaders.
+	Data() *types.Data
+	// Err returns error that stopped the iteration, if any.
+	Err() error
+}
+
+// IterateHeaders returns iterator over headers saved in given range of heights (inclusive).
+func (s *DefaultStore) IterateHeaders(ctx context.Context, from, to uint64, order Order) (Iterator, error) {
+	return s.newIterator(ctx, from, to, order, false)
+}
+
+// IterateBlocks returns iterator over blocks (headers and data) saved in given range of heights (inclusive).
+func (s *DefaultStore) IterateBlocks(ctx context.Context, from, to uint64, order Order) (Iterator, error) {
+	return s.newIterator(ctx, from, to, order, true)
+}
+
+func (s *DefaultStore) newIterator(ctx context.Context, from, to uint64, order Order, withData bool) (Iterator, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	if order != Ascending && order != Descending {
+		return nil, fmt.Errorf("invalid order: %d", order)
+	}
+	it := &storeIterator{
+		ctx:      ctx,
+		db:       s.db,
+		from:     from,
+		to:       to,
+		order:    order,
+		withData: withData,
+	}
+	if order == Ascending {
+		it.bucket = from / heightBucketSize
+	} else {
+		it.bucket = to / heightBucketSize
+	}
+	return it, nil
+}
+
+// storeIterator loads blocks bucket by bucket, using a prefix query per bucket.
+type storeIterator struct {
+	ctx      context.Context
+	db       ds.TxnDatastore
+	from, to uint64
+	order    Order
+	withData bool
+
+	bucket   uint64
+	finished bool
+
+	headers []*types.SignedHeader
+	data    []*types.Data
+	pos     int
+	err     error
+}
+
+func (it *storeIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.pos++
+	for it.pos >= len(it.headers) {
+		if it.finished {
+			return false
+		}
+		if err := it.loadBucket(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	return true
+}
+
+func (it *storeIterator) Header() *types.SignedHeader {
+	return it.headers[it.pos]
+}
+
+func (it *storeIterator) Data() *types.Data {
+	if !it.withData {
+		return nil
+	}
+	return it.data[it.pos]
+}
+
+func (it *storeIterator) Err() error {
+	return it.err
+}
+
+func (it *storeIterator) loadBucket() error {
+	it.headers, it.data, it.pos = nil, nil, 0
+
+	headerBlobs, err := it.queryBucket(headerPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to load block headers: %w", err)
+	}
+	var dataBlobs [][]byte
+	if it.withData {
+		dataBlobs, err = it.queryBucket(dataPrefix)
+		if err != nil {
+			return fmt.Errorf("failed to load block data: %w", err)
+		}
+		if len(dataBlobs) != len(headerBlobs) {
+			return fmt.Errorf("number of block headers (%d) doesn't match number of block data (%d)", len(headerBlobs), len(dataBlobs))
+		}
+	}
+
+	for i, headerBlob := range headerBlobs {
+		header := new(types.SignedHeader)
+		if err := header.UnmarshalBinary(headerBlob); err != nil {
+			return fmt.Errorf("failed to unmarshal block header: %w", err)
+		}
+		it.headers = append(it.headers, header)
+		if it.withData {
+			data := new(types.Data)
+			if err := data.UnmarshalBinary(dataBlobs[i]); err != nil {
+				return fmt.Errorf("failed to unmarshal block data: %w", err)
+			}
+			it.data = append(it.data, data)
+		}
+	}
+
+	// move to the next bucket
+	if it.order == Ascending {
+		it.finished = it.bucket == it.to/heightBucketSize
+		it.bucket++
+	} else {
+		it.finished = it.bucket == it.from/heightBucketSize
+		it.bucket--
+	}
+	return nil
+}
+
+func (it *storeIterator) queryBucket(prefix string) ([][]byte, error) {
+	// reverse iteration with prefix is not supported by badger datastore, so buckets are always loaded in
+	// ascending order and reversed in memory if needed
+	results, err := it.db.Query(it.ctx, dsq.Query{
+		Prefix:  getHeightBucketKey(prefix, it.bucket),
+		Filters: []dsq.Filter{heightRangeFilter{from: it.from, to: it.to}},
+		Orders:  []dsq.Order{dsq.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close() //nolint:errcheck
+
+	var blobs [][]byte
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		blobs = append(blobs, result.Value)
+	}
+	if it.order == Descending {
+		slices.Reverse(blobs)
+	}
+	return blobs, nil
+}
+
+// heightRangeFilter accepts entries with keys created by getHeightKey, with heights in given range (inclusive).
+type heightRangeFilter struct {
+	from, to uint64
+}
+
+func (f heightRangeFilter) Filter(e dsq.Entry) bool {
+	height, err := strconv.ParseUint(ds.RawKey(e.Key).Name(), 10, 64)
+	if err != nil {
+		return false
+	}
+	return height >= f.from && height <= f.to
+}
+
+// sliceIterator iterates over blocks kept in memory.
+type sliceIterator struct {
+	headers []*types.SignedHeader
+	data    []*types.Data
+	pos     int
+}
+
+// NewSliceIterator returns Iterator over given blocks. data may be nil, if iterator is used to return only headers.
+func NewSliceIterator(headers []*types.SignedHeader, data []*types.Data) Iterator {
+	return &sliceIterator{headers: headers, data: data, pos: -1}
+}
+
+func (it *sliceIterator) Next() bool {
+	it.pos++
+	return it.pos < len(it.headers)
+}
+
+func (it *sliceIterator) Header() *types.SignedHeader {
+	return it.headers[it.pos]
+}
+
+func (it *sliceIterator) Data() *types.Data {
+	if it.data == nil {
+		return nil
+	}
+	return it.data[it.pos]
+}
+
+func (it *sliceIterator) Err() error {
+	return nil
+}
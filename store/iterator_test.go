@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestIterators(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	chainID := "TestIterators"
+
+	badger, err := NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	backends := map[string]ds.TxnDatastore{
+		"badger": badger,
+		"map":    NewInMemoryMapKVStore(),
+	}
+
+	for name, kv := range backends {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			s := New(kv)
+
+			// heights span across multiple buckets; height 1000 is missing
+			var heights []uint64
+			for height := uint64(990); height <= 2010; height++ {
+				if height == 1000 {
+					continue
+				}
+				header, data := types.GetRandomBlock(height, 1, chainID)
+				require.NoError(s.SaveBlockData(ctx, header, data, &header.Signature))
+				heights = append(heights, height)
+			}
+
+			cases := []struct {
+				name     string
+				from, to uint64
+				order    Order
+				expected []uint64
+			}{
+				{"single bucket", 990, 995, Ascending, heights[:6]},
+				{"across buckets", 995, 1005, Ascending, heights[5:15]},
+				{"all", 1, 3000, Ascending, heights},
+				{"single height", 1999, 1999, Ascending, []uint64{1999}},
+				{"missing height", 1000, 1000, Ascending, nil},
+				{"empty range", 5, 50, Descending, nil},
+				{"descending", 995, 1005, Descending, reversed(heights[5:15])},
+				{"descending all", 0, 5000, Descending, reversed(heights)},
+			}
+			for _, c := range cases {
+				for _, withData := range []bool{false, true} {
+					var it Iterator
+					if withData {
+						it, err = s.IterateBlocks(ctx, c.from, c.to, c.order)
+					} else {
+						it, err = s.IterateHeaders(ctx, c.from, c.to, c.order)
+					}
+					require.NoError(err)
+
+					var actual []uint64
+					for it.Next() {
+						actual = append(actual, it.Header().Height())
+						if withData {
+							require.NotNil(it.Data())
+							assert.Equal(it.Header().Height(), it.Data().Height())
+						} else {
+							assert.Nil(it.Data())
+						}
+					}
+					require.NoError(it.Err())
+					assert.Equal(c.expected, actual, c.name)
+				}
+			}
+
+			_, err = s.IterateBlocks(ctx, 10, 1, Ascending)
+			assert.Error(err)
+		})
+	}
+}
+
+func reversed(heights []uint64) []uint64 {
+	r := make([]uint64, len(heights))
+	for i, h := range heights {
+		r[len(heights)-1-i] = h
+	}
+	return r
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"path/filepath"
 	"strings"
@@ -9,8 +10,19 @@ import (
 
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
 
 	badger4 "github.com/ipfs/go-ds-badger4"
+	pebbleds "github.com/ipfs/go-ds-pebble"
+)
+
+const (
+	// BadgerBackend is the name of the default, BadgerDB based key-value store backend.
+	BadgerBackend = "badger"
+	// PebbleBackend is the name of the Pebble based key-value store backend.
+	PebbleBackend = "pebble"
+	// MemoryBackend is the name of the in-memory key-value store backend, that doesn't persist any data.
+	MemoryBackend = "memory"
 )
 
 // NewDefaultInMemoryKVStore builds KVStore that works in-memory (without accessing disk).
@@ -30,6 +42,36 @@ func NewDefaultKVStore(rootDir, dbPath, dbName string) (ds.TxnDatastore, error)
 	return badger4.NewDatastore(path, nil)
 }
 
+// NewPebbleKVStore creates instance of Pebble based key-value store.
+func NewPebbleKVStore(rootDir, dbPath, dbName string) (ds.TxnDatastore, error) {
+	path := filepath.Join(rootify(rootDir, dbPath), dbName)
+	pebble, err := pebbleds.NewDatastore(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewTxnDatastore(pebble), nil
+}
+
+// NewInMemoryMapKVStore builds KVStore backed by a simple map (without accessing disk).
+func NewInMemoryMapKVStore() ds.TxnDatastore {
+	return NewTxnDatastore(dssync.MutexWrap(ds.NewMapDatastore()))
+}
+
+// NewKVStore creates instance of key-value store using given backend.
+// Empty backend name denotes the default backend (BadgerDB).
+func NewKVStore(backend, rootDir, dbPath, dbName string) (ds.TxnDatastore, error) {
+	switch backend {
+	case "", BadgerBackend:
+		return NewDefaultKVStore(rootDir, dbPath, dbName)
+	case PebbleBackend:
+		return NewPebbleKVStore(rootDir, dbPath, dbName)
+	case MemoryBackend:
+		return NewInMemoryMapKVStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown database backend: %q", backend)
+	}
+}
+
 // PrefixEntries retrieves all entries in the datastore whose keys have the supplied prefix
 func PrefixEntries(ctx context.Context, store ds.Datastore, prefix string) (dsq.Results, error) {
 	results, err := store.Query(ctx, dsq.Query{Prefix: prefix})
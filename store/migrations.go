@@ -0,0 +1,288 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// SchemaVersion is the version of the store layout created by this version of rollkit.
+// It must be equal to the version of the last registered migration.
+const SchemaVersion uint64 = 2
+
+// schemaVersionKey is the metadata key used to persist the schema version of the store.
+const schemaVersionKey = "schema-version"
+
+// ErrUnsupportedSchemaVersion is returned when store was created by a newer version of rollkit.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported store schema version")
+
+// Migration converts data in store from schema version Version-1 to Version.
+type Migration struct {
+	// Version is the schema version after the migration is applied.
+	Version uint64
+	// Description is a human-readable summary of changes made by the migration.
+	Description string
+	// Migrate applies the migration and returns the number of changed entries. If dryRun is true, datastore
+	// is not modified and the number of entries that would be changed is returned.
+	Migrate func(ctx context.Context, db ds.TxnDatastore, dryRun bool) (int, error)
+}
+
+// MigrationResult describes the outcome of a single migration.
+type MigrationResult struct {
+	Version     uint64
+	Description string
+	// Entries is the number of changed entries (or entries that would be changed in dry run).
+	Entries int
+}
+
+// migrations is the registry of all migrations, ordered by version.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "group height-indexed keys in buckets of heights with zero-padded heights",
+		Migrate:     migrateHeightKeys,
+	},
+	{
+		Version:     2,
+		Description: "index transactions of saved blocks by transaction hash",
+		Migrate:     migrateTxIndex,
+	},
+}
+
+// GetSchemaVersion returns the schema version of the store. Datastores without schema version are reported as version 0.
+func GetSchemaVersion(ctx context.Context, db ds.Read) (uint64, error) {
+	value, err := db.Get(ctx, ds.NewKey(getMetaKey(schemaVersionKey)))
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid schema version length: %d", len(value))
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+func setSchemaVersion(ctx context.Context, db ds.Write, version uint64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, version)
+	return db.Put(ctx, ds.NewKey(getMetaKey(schemaVersionKey)), value)
+}
+
+// Migrate applies, in order, all the registered migrations newer than the schema version of the store.
+// Schema version is updated after each successful migration.
+//
+// If dryRun is true, datastore is not modified, and results describe changes that would be made. As changes are not
+// applied, each migration in dry run sees the data as it's currently stored, without changes of preceding migrations.
+func Migrate(ctx context.Context, db ds.TxnDatastore, dryRun bool) ([]MigrationResult, error) {
+	version, err := GetSchemaVersion(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store schema version: %w", err)
+	}
+	if version > SchemaVersion {
+		return nil, fmt.Errorf("%w: %d (latest supported: %d)", ErrUnsupportedSchemaVersion, version, SchemaVersion)
+	}
+
+	var results []MigrationResult
+	for _, m := range migrations {
+		if m.Version <= version {
+			continue
+		}
+		entries, err := m.Migrate(ctx, db, dryRun)
+		if err != nil {
+			return results, fmt.Errorf("migration to schema version %d failed: %w", m.Version, err)
+		}
+		if !dryRun {
+			if err := setSchemaVersion(ctx, db, m.Version); err != nil {
+				return results, fmt.Errorf("failed to save store schema version: %w", err)
+			}
+		}
+		results = append(results, MigrationResult{Version: m.Version, Description: m.Description, Entries: entries})
+	}
+	return results, nil
+}
+
+// migrationBatchSize is the maximum number of entries changed in a single transaction.
+const migrationBatchSize = 1000
+
+// heightIndexedPrefixes are the prefixes of all entries keyed by height.
+var heightIndexedPrefixes = []string{headerPrefix, dataPrefix, signaturePrefix, extendedCommitPrefix, statePrefix, responsesPrefix}
+
+// migrateHeightKeys moves entries saved with legacy height keys (/<prefix>/<height>) to keys grouped in buckets of
+// heights (/<prefix>/<bucket>/<zero-padded height>), required for iteration over ranges of heights.
+func migrateHeightKeys(ctx context.Context, db ds.TxnDatastore, dryRun bool) (int, error) {
+	migrated := 0
+	for _, prefix := range heightIndexedPrefixes {
+		heights, err := legacyHeights(ctx, db, prefix)
+		if err != nil {
+			return migrated, fmt.Errorf("failed to list keys with prefix %q: %w", prefix, err)
+		}
+		if dryRun {
+			migrated += len(heights)
+			continue
+		}
+		for start := 0; start < len(heights); start += migrationBatchSize {
+			end := min(start+migrationBatchSize, len(heights))
+			if err := rekeyHeights(ctx, db, prefix, heights[start:end]); err != nil {
+				return migrated, fmt.Errorf("failed to migrate keys with prefix %q: %w", prefix, err)
+			}
+			migrated += end - start
+		}
+	}
+	return migrated, nil
+}
+
+// legacyHeights returns heights of all entries with given prefix, saved with legacy height keys.
+func legacyHeights(ctx context.Context, db ds.TxnDatastore, prefix string) ([]uint64, error) {
+	results, err := db.Query(ctx, dsq.Query{Prefix: GenerateKey([]string{prefix}), KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close() //nolint:errcheck
+
+	var heights []uint64
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		namespaces := ds.RawKey(result.Key).Namespaces()
+		if len(namespaces) != 2 {
+			continue
+		}
+		height, err := strconv.ParseUint(namespaces[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	return heights, nil
+}
+
+// getLegacyHeightKey returns key of height-indexed entry saved before schema version 1 (/<prefix>/<height>).
+func getLegacyHeightKey(prefix string, height uint64) string {
+	return GenerateKey([]string{prefix, strconv.FormatUint(height, 10)})
+}
+
+func rekeyHeights(ctx context.Context, db ds.TxnDatastore, prefix string, heights []uint64) error {
+	txn, err := db.NewTransaction(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	for _, height := range heights {
+		oldKey := ds.NewKey(getLegacyHeightKey(prefix, height))
+		value, err := txn.Get(ctx, oldKey)
+		if err != nil {
+			return err
+		}
+		if err := txn.Put(ctx, ds.NewKey(getHeightKey(prefix, height)), value); err != nil {
+			return err
+		}
+		if err := txn.Delete(ctx, oldKey); err != nil {
+			return err
+		}
+	}
+	return txn.Commit(ctx)
+}
+
+// migrateTxIndex saves locations of transactions included in blocks saved before transactions were indexed.
+// It returns the number of indexed transactions.
+//
+// Block data saved with legacy height keys is indexed as well. Such keys exist only in dry run, in which
+// migration of height keys (schema version 1) isn't applied, so that the number of transactions is reported
+// correctly for stores created before schema versioning.
+func migrateTxIndex(ctx context.Context, db ds.TxnDatastore, dryRun bool) (int, error) {
+	heights, err := savedHeights(ctx, db, dataPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list block data keys: %w", err)
+	}
+	legacy, err := legacyHeights(ctx, db, dataPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list legacy block data keys: %w", err)
+	}
+
+	indexed := 0
+	for _, keys := range []struct {
+		heights []uint64
+		dataKey func(uint64) string
+	}{
+		{heights, getDataKey},
+		{legacy, func(height uint64) string { return getLegacyHeightKey(dataPrefix, height) }},
+	} {
+		for start := 0; start < len(keys.heights); start += migrationBatchSize {
+			end := min(start+migrationBatchSize, len(keys.heights))
+			n, err := indexTxs(ctx, db, keys.heights[start:end], keys.dataKey, dryRun)
+			if err != nil {
+				return indexed, fmt.Errorf("failed to index transactions: %w", err)
+			}
+			indexed += n
+		}
+	}
+	return indexed, nil
+}
+
+// savedHeights returns heights of all entries with given prefix, saved with keys created by getHeightKey.
+func savedHeights(ctx context.Context, db ds.TxnDatastore, prefix string) ([]uint64, error) {
+	results, err := db.Query(ctx, dsq.Query{Prefix: GenerateKey([]string{prefix}), KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close() //nolint:errcheck
+
+	var heights []uint64
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		namespaces := ds.RawKey(result.Key).Namespaces()
+		if len(namespaces) != 3 {
+			continue
+		}
+		height, err := strconv.ParseUint(namespaces[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	return heights, nil
+}
+
+func indexTxs(ctx context.Context, db ds.TxnDatastore, heights []uint64, dataKey func(uint64) string, dryRun bool) (int, error) {
+	txn, err := db.NewTransaction(ctx, dryRun)
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Discard(ctx)
+
+	indexed := 0
+	for _, height := range heights {
+		dataBlob, err := txn.Get(ctx, ds.NewKey(dataKey(height)))
+		if err != nil {
+			return indexed, err
+		}
+		data := new(types.Data)
+		if err := data.UnmarshalBinary(dataBlob); err != nil {
+			return indexed, fmt.Errorf("failed to unmarshal block data at height %d: %w", height, err)
+		}
+		indexed += len(data.Txs)
+		if dryRun {
+			continue
+		}
+		if err := putTxLocations(ctx, txn, height, data.Txs); err != nil {
+			return indexed, err
+		}
+	}
+	if dryRun {
+		return indexed, nil
+	}
+	return indexed, txn.Commit(ctx)
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestMigrationsRegistry(t *testing.T) {
+	t.Parallel()
+	for i, m := range migrations {
+		assert.Equal(t, uint64(i+1), m.Version, "migrations must be ordered by version without gaps")
+		assert.NotEmpty(t, m.Description)
+		assert.NotNil(t, m.Migrate)
+	}
+	assert.Equal(t, SchemaVersion, migrations[len(migrations)-1].Version)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+
+	// save blocks with legacy keys, without schema version
+	const n = 5
+	var headers []*types.SignedHeader
+	txs := 0
+	header, data, privKey := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: 1, NTxs: 2}, "TestMigrate")
+	for height := uint64(1); height <= n; height++ {
+		if height > 1 {
+			header, data = types.GetRandomNextBlock(header, data, privKey, []byte{1, 2, 3}, 2, "TestMigrate")
+		}
+		headerBlob, err := header.MarshalBinary()
+		require.NoError(err)
+		dataBlob, err := data.MarshalBinary()
+		require.NoError(err)
+		require.NoError(kv.Put(ctx, ds.NewKey(GenerateKey([]string{headerPrefix, strconv.FormatUint(height, 10)})), headerBlob))
+		require.NoError(kv.Put(ctx, ds.NewKey(GenerateKey([]string{dataPrefix, strconv.FormatUint(height, 10)})), dataBlob))
+		headers = append(headers, header)
+		txs += len(data.Txs)
+	}
+	// entry that is not keyed by height is left as is
+	require.NoError(kv.Put(ctx, ds.NewKey(getStateKey()), []byte{1}))
+
+	version, err := GetSchemaVersion(ctx, kv)
+	require.NoError(err)
+	assert.Zero(version)
+
+	// dry run doesn't modify the datastore
+	results, err := Migrate(ctx, kv, true)
+	require.NoError(err)
+	require.Len(results, 2)
+	assert.Equal(uint64(1), results[0].Version)
+	assert.Equal(2*n, results[0].Entries)
+	assert.Equal(uint64(2), results[1].Version)
+	// dry run of tx index migration counts transactions of blocks saved with legacy keys
+	assert.Equal(txs, results[1].Entries)
+	version, err = GetSchemaVersion(ctx, kv)
+	require.NoError(err)
+	assert.Zero(version)
+	has, err := kv.Has(ctx, ds.NewKey(GenerateKey([]string{headerPrefix, "1"})))
+	require.NoError(err)
+	assert.True(has)
+
+	results, err = Migrate(ctx, kv, false)
+	require.NoError(err)
+	require.Len(results, 2)
+	assert.Equal(2*n, results[0].Entries)
+	assert.Equal(txs, results[1].Entries)
+	version, err = GetSchemaVersion(ctx, kv)
+	require.NoError(err)
+	assert.Equal(SchemaVersion, version)
+
+	s := New(kv)
+	for _, expected := range headers {
+		header, data, err := s.GetBlockData(ctx, expected.Height())
+		require.NoError(err)
+		assert.Equal(expected.Hash(), header.Hash())
+		assert.Equal(header.Height(), data.Height())
+
+		// transactions of blocks saved before indexing are indexed by migration
+		for i, tx := range data.Txs {
+			height, index, err := s.GetTxLocation(ctx, tx.Hash())
+			require.NoError(err)
+			assert.Equal(expected.Height(), height)
+			assert.Equal(uint32(i), index) //nolint:gosec
+		}
+	}
+	value, err := kv.Get(ctx, ds.NewKey(getStateKey()))
+	require.NoError(err)
+	assert.Equal([]byte{1}, value)
+
+	// store is up to date
+	results, err = Migrate(ctx, kv, false)
+	require.NoError(err)
+	assert.Empty(results)
+
+	// store created by newer version of rollkit is rejected
+	require.NoError(setSchemaVersion(ctx, kv, SchemaVersion+1))
+	_, err = Migrate(ctx, kv, false)
+	assert.ErrorIs(err, ErrUnsupportedSchemaVersion)
+}
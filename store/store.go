@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync/atomic"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -18,6 +19,7 @@ var (
 	headerPrefix         = "h"
 	dataPrefix           = "d"
 	indexPrefix          = "i"
+	txIndexPrefix        = "t"
 	signaturePrefix      = "c"
 	extendedCommitPrefix = "ec"
 	statePrefix          = "s"
@@ -34,6 +36,8 @@ type DefaultStore struct {
 var _ Store = &DefaultStore{}
 
 // New returns new, default store.
+//
+// Datastore must be at the current schema version; pending migrations are not applied by New (see Migrate).
 func New(ds ds.TxnDatastore) Store {
 	return &DefaultStore{
 		db: ds,
@@ -100,6 +104,10 @@ func (s *DefaultStore) SaveBlockData(ctx context.Context, header *types.SignedHe
 	if err != nil {
 		return fmt.Errorf("failed to create a new key using height of the block: %w", err)
 	}
+	err = putTxLocations(ctx, bb, height, data.Txs)
+	if err != nil {
+		return fmt.Errorf("failed to index transactions: %w", err)
+	}
 
 	if err = bb.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -154,6 +162,30 @@ func (s *DefaultStore) getHeightByHash(ctx context.Context, hash types.Hash) (ui
 	return height, nil
 }
 
+// GetTxLocation returns height of the block including transaction with given hash, and index of the transaction
+// in the block.
+func (s *DefaultStore) GetTxLocation(ctx context.Context, hash []byte) (uint64, uint32, error) {
+	location, err := s.db.Get(ctx, ds.NewKey(getTxIndexKey(hash)))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get location of transaction %X: %w", hash, err)
+	}
+	height, index, err := decodeTxLocation(location)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to decode location of transaction: %w", err)
+	}
+	return height, index, nil
+}
+
+// putTxLocations saves location (block height and index in block) of every transaction, keyed by transaction hash.
+func putTxLocations(ctx context.Context, w ds.Write, height uint64, txs types.Txs) error {
+	for i, tx := range txs {
+		if err := w.Put(ctx, ds.NewKey(getTxIndexKey(tx.Hash())), encodeTxLocation(height, uint32(i))); err != nil { //nolint:gosec
+			return err
+		}
+	}
+	return nil
+}
+
 // SaveBlockResponses saves block responses (events, tx responses, validator set updates, etc) in Store.
 func (s *DefaultStore) SaveBlockResponses(ctx context.Context, height uint64, responses *abci.ResponseFinalizeBlock) error {
 	data, err := responses.Marshal()
@@ -219,8 +251,10 @@ func (s *DefaultStore) GetExtendedCommit(ctx context.Context, height uint64) (*a
 	return extendedCommit, nil
 }
 
-// UpdateState updates state saved in Store. Only one State is stored.
+// UpdateState updates state saved in Store. Only one State is stored as the latest state.
 // If there is no State in Store, state will be saved.
+// Additionally, a copy of the state is kept under its LastBlockHeight, so it can be
+// retrieved later with GetStateAtHeight.
 func (s *DefaultStore) UpdateState(ctx context.Context, state types.State) error {
 	pbState, err := state.ToProto()
 	if err != nil {
@@ -230,7 +264,26 @@ func (s *DefaultStore) UpdateState(ctx context.Context, state types.State) error
 	if err != nil {
 		return err
 	}
-	return s.db.Put(ctx, ds.NewKey(getStateKey()), data)
+
+	bb, err := s.db.NewTransaction(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+	defer bb.Discard(ctx)
+
+	err = bb.Put(ctx, ds.NewKey(getStateKey()), data)
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	err = bb.Put(ctx, ds.NewKey(getStateAtHeightKey(state.LastBlockHeight)), data)
+	if err != nil {
+		return fmt.Errorf("failed to save state at height %d: %w", state.LastBlockHeight, err)
+	}
+
+	if err = bb.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
 }
 
 // GetState returns last state saved with UpdateState.
@@ -239,8 +292,21 @@ func (s *DefaultStore) GetState(ctx context.Context) (types.State, error) {
 	if err != nil {
 		return types.State{}, fmt.Errorf("failed to retrieve state: %w", err)
 	}
+	return decodeState(blob)
+}
+
+// GetStateAtHeight returns the state saved with UpdateState after the block at given height was applied.
+func (s *DefaultStore) GetStateAtHeight(ctx context.Context, height uint64) (types.State, error) {
+	blob, err := s.db.Get(ctx, ds.NewKey(getStateAtHeightKey(height)))
+	if err != nil {
+		return types.State{}, fmt.Errorf("failed to retrieve state at height %d: %w", height, err)
+	}
+	return decodeState(blob)
+}
+
+func decodeState(blob []byte) (types.State, error) {
 	var pbState pb.State
-	err = pbState.Unmarshal(blob)
+	err := pbState.Unmarshal(blob)
 	if err != nil {
 		return types.State{}, fmt.Errorf("failed to unmarshal state from JSON: %w", err)
 	}
@@ -271,27 +337,31 @@ func (s *DefaultStore) GetMetadata(ctx context.Context, key string) ([]byte, err
 }
 
 func getHeaderKey(height uint64) string {
-	return GenerateKey([]string{headerPrefix, strconv.FormatUint(height, 10)})
+	return getHeightKey(headerPrefix, height)
 }
 
 func getDataKey(height uint64) string {
-	return GenerateKey([]string{dataPrefix, strconv.FormatUint(height, 10)})
+	return getHeightKey(dataPrefix, height)
 }
 
 func getSignatureKey(height uint64) string {
-	return GenerateKey([]string{signaturePrefix, strconv.FormatUint(height, 10)})
+	return getHeightKey(signaturePrefix, height)
 }
 
 func getExtendedCommitKey(height uint64) string {
-	return GenerateKey([]string{extendedCommitPrefix, strconv.FormatUint(height, 10)})
+	return getHeightKey(extendedCommitPrefix, height)
 }
 
 func getStateKey() string {
 	return statePrefix
 }
 
+func getStateAtHeightKey(height uint64) string {
+	return getHeightKey(statePrefix, height)
+}
+
 func getResponsesKey(height uint64) string {
-	return GenerateKey([]string{responsesPrefix, strconv.FormatUint(height, 10)})
+	return getHeightKey(responsesPrefix, height)
 }
 
 func getMetaKey(key string) string {
@@ -302,6 +372,32 @@ func getIndexKey(hash types.Hash) string {
 	return GenerateKey([]string{indexPrefix, hash.String()})
 }
 
+func getTxIndexKey(hash []byte) string {
+	return GenerateKey([]string{txIndexPrefix, types.Hash(hash).String()})
+}
+
+// heightBucketSize is the number of consecutive heights grouped under common key prefix.
+// Grouping enables iteration over ranges of heights with prefix queries, without scanning all the entries.
+const heightBucketSize = 1000
+
+// heightKeyLength is the number of digits in the largest uint64 value. Heights in keys are zero-padded to
+// this length, so that lexicographical order of keys matches the order of heights.
+const heightKeyLength = 20
+
+// getHeightKey returns key for height-indexed entry, in form of /<prefix>/<bucket>/<zero-padded height>.
+func getHeightKey(prefix string, height uint64) string {
+	return GenerateKey([]string{getHeightBucketKey(prefix, height/heightBucketSize), formatHeight(height)})
+}
+
+func getHeightBucketKey(prefix string, bucket uint64) string {
+	return GenerateKey([]string{prefix, strconv.FormatUint(bucket, 10)})
+}
+
+func formatHeight(height uint64) string {
+	h := strconv.FormatUint(height, 10)
+	return strings.Repeat("0", heightKeyLength-len(h)) + h
+}
+
 const heightLength = 8
 
 func encodeHeight(height uint64) []byte {
@@ -316,3 +412,19 @@ func decodeHeight(heightBytes []byte) (uint64, error) {
 	}
 	return binary.BigEndian.Uint64(heightBytes), nil
 }
+
+const txLocationLength = heightLength + 4
+
+func encodeTxLocation(height uint64, index uint32) []byte {
+	location := make([]byte, txLocationLength)
+	binary.BigEndian.PutUint64(location, height)
+	binary.BigEndian.PutUint32(location[heightLength:], index)
+	return location
+}
+
+func decodeTxLocation(location []byte) (uint64, uint32, error) {
+	if len(location) != txLocationLength {
+		return 0, 0, fmt.Errorf("invalid transaction location length: %d (expected %d)", len(location), txLocationLength)
+	}
+	return binary.BigEndian.Uint64(location), binary.BigEndian.Uint32(location[heightLength:]), nil
+}
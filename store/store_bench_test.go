@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func BenchmarkSaveBlockData(b *testing.B) {
+	backends := []struct {
+		name string
+		open func(b *testing.B) ds.TxnDatastore
+	}{
+		{BadgerBackend, func(b *testing.B) ds.TxnDatastore {
+			kv, err := NewDefaultKVStore(b.TempDir(), "db", "bench")
+			if err != nil {
+				b.Fatal(err)
+			}
+			return kv
+		}},
+		{PebbleBackend, func(b *testing.B) ds.TxnDatastore {
+			kv, err := NewPebbleKVStore(b.TempDir(), "db", "bench")
+			if err != nil {
+				b.Fatal(err)
+			}
+			return kv
+		}},
+		{MemoryBackend, func(b *testing.B) ds.TxnDatastore {
+			return NewInMemoryMapKVStore()
+		}},
+	}
+
+	for _, txs := range []int{10, 1000} {
+		header, data := types.GetRandomBlock(1, txs, "BenchmarkSaveBlockData")
+		for _, backend := range backends {
+			b.Run(fmt.Sprintf("%s/txs=%d", backend.name, txs), func(b *testing.B) {
+				s := New(backend.open(b))
+				defer s.Close() //nolint:errcheck
+				ctx := context.Background()
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					header.BaseHeader.Height = uint64(i + 1) //nolint:gosec
+					if err := s.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
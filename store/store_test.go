@@ -86,9 +86,11 @@ func TestStoreLoad(t *testing.T) {
 
 	mKV, _ := NewDefaultInMemoryKVStore()
 	dKV, _ := NewDefaultKVStore(tmpDir, "db", "test")
+	pKV, _ := NewPebbleKVStore(tmpDir, "db", "pebble")
+	mapKV := NewInMemoryMapKVStore()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	for _, kv := range []ds.TxnDatastore{mKV, dKV} {
+	for _, kv := range []ds.TxnDatastore{mKV, dKV, pKV, mapKV} {
 		for _, c := range cases {
 			t.Run(c.name, func(t *testing.T) {
 				assert := assert.New(t)
@@ -276,3 +278,67 @@ func TestExtendedCommits(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, commit)
 }
+
+func TestStateAtHeight(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	validatorSet := types.GetRandomValidatorSet()
+	for height := uint64(1); height <= 3; height++ {
+		err = s.UpdateState(ctx, types.State{
+			LastBlockHeight: height,
+			AppHash:         types.Hash{byte(height)},
+			NextValidators:  validatorSet,
+			Validators:      validatorSet,
+			LastValidators:  validatorSet,
+		})
+		require.NoError(err)
+	}
+
+	latest, err := s.GetState(ctx)
+	require.NoError(err)
+	require.Equal(uint64(3), latest.LastBlockHeight)
+
+	for height := uint64(1); height <= 3; height++ {
+		state, err := s.GetStateAtHeight(ctx, height)
+		require.NoError(err)
+		require.Equal(height, state.LastBlockHeight)
+		require.Equal(types.Hash{byte(height)}, state.AppHash)
+	}
+
+	_, err = s.GetStateAtHeight(ctx, 4)
+	require.ErrorIs(err, ds.ErrNotFound)
+}
+
+func TestTxLocation(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	for height := uint64(1); height <= 3; height++ {
+		header, data := types.GetRandomBlock(height, 5, "TestTxLocation")
+		require.NoError(s.SaveBlockData(ctx, header, data, &header.Signature))
+
+		for i, tx := range data.Txs {
+			h, index, err := s.GetTxLocation(ctx, tx.Hash())
+			require.NoError(err)
+			assert.Equal(height, h)
+			assert.Equal(uint32(i), index) //nolint:gosec
+		}
+	}
+
+	_, _, err = s.GetTxLocation(ctx, types.Tx("missing").Hash())
+	assert.ErrorIs(err, ds.ErrNotFound)
+}
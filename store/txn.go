@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// ErrReadOnlyTxn is returned when writing in transaction created as read-only.
+var ErrReadOnlyTxn = errors.New("transaction is read-only")
+
+// batchingTxnDatastore implements ds.TxnDatastore on top of ds.Batching, for backends without native
+// transaction support.
+//
+// Writes made in a transaction are buffered and applied in a single batch on Commit. Reads in a
+// transaction see the buffered writes, except for Query, which is served by underlying datastore.
+type batchingTxnDatastore struct {
+	ds.Batching
+}
+
+var _ ds.TxnDatastore = &batchingTxnDatastore{}
+
+// NewTxnDatastore wraps ds.Batching to provide transactions implemented with batches.
+func NewTxnDatastore(batching ds.Batching) ds.TxnDatastore {
+	return &batchingTxnDatastore{Batching: batching}
+}
+
+// NewTransaction returns new transaction, buffering all the writes until Commit.
+func (d *batchingTxnDatastore) NewTransaction(ctx context.Context, readOnly bool) (ds.Txn, error) {
+	return &batchTxn{
+		store:    d.Batching,
+		readOnly: readOnly,
+		writes:   make(map[ds.Key][]byte),
+	}, nil
+}
+
+// batchTxn keeps pending writes; nil value denotes deleted key.
+type batchTxn struct {
+	store    ds.Batching
+	readOnly bool
+	writes   map[ds.Key][]byte
+}
+
+func (t *batchTxn) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	if value, ok := t.writes[key]; ok {
+		if value == nil {
+			return nil, ds.ErrNotFound
+		}
+		return value, nil
+	}
+	return t.store.Get(ctx, key)
+}
+
+func (t *batchTxn) Has(ctx context.Context, key ds.Key) (bool, error) {
+	if value, ok := t.writes[key]; ok {
+		return value != nil, nil
+	}
+	return t.store.Has(ctx, key)
+}
+
+func (t *batchTxn) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	if value, ok := t.writes[key]; ok {
+		if value == nil {
+			return -1, ds.ErrNotFound
+		}
+		return len(value), nil
+	}
+	return t.store.GetSize(ctx, key)
+}
+
+func (t *batchTxn) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	return t.store.Query(ctx, q)
+}
+
+func (t *batchTxn) Put(ctx context.Context, key ds.Key, value []byte) error {
+	if t.readOnly {
+		return ErrReadOnlyTxn
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	t.writes[key] = value
+	return nil
+}
+
+func (t *batchTxn) Delete(ctx context.Context, key ds.Key) error {
+	if t.readOnly {
+		return ErrReadOnlyTxn
+	}
+	t.writes[key] = nil
+	return nil
+}
+
+func (t *batchTxn) Commit(ctx context.Context) error {
+	if len(t.writes) == 0 {
+		return nil
+	}
+	batch, err := t.store.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for key, value := range t.writes {
+		if value == nil {
+			err = batch.Delete(ctx, key)
+		} else {
+			err = batch.Put(ctx, key, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return err
+	}
+	t.Discard(ctx)
+	return nil
+}
+
+func (t *batchTxn) Discard(ctx context.Context) {
+	t.writes = make(map[ds.Key][]byte)
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchingTxn(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kv := NewInMemoryMapKVStore()
+	key1, key2 := ds.NewKey("key1"), ds.NewKey("key2")
+	require.NoError(kv.Put(ctx, key1, []byte("value1")))
+
+	txn, err := kv.NewTransaction(ctx, false)
+	require.NoError(err)
+	require.NoError(txn.Put(ctx, key2, []byte("value2")))
+	require.NoError(txn.Delete(ctx, key1))
+
+	// writes are visible in transaction only
+	value, err := txn.Get(ctx, key2)
+	require.NoError(err)
+	require.Equal([]byte("value2"), value)
+	_, err = txn.Get(ctx, key1)
+	require.ErrorIs(err, ds.ErrNotFound)
+	_, err = kv.Get(ctx, key2)
+	require.ErrorIs(err, ds.ErrNotFound)
+
+	require.NoError(txn.Commit(ctx))
+	value, err = kv.Get(ctx, key2)
+	require.NoError(err)
+	require.Equal([]byte("value2"), value)
+	has, err := kv.Has(ctx, key1)
+	require.NoError(err)
+	require.False(has)
+
+	// discarded writes are never applied
+	txn, err = kv.NewTransaction(ctx, false)
+	require.NoError(err)
+	require.NoError(txn.Put(ctx, key1, []byte("value1")))
+	txn.Discard(ctx)
+	require.NoError(txn.Commit(ctx))
+	has, err = kv.Has(ctx, key1)
+	require.NoError(err)
+	require.False(has)
+
+	txn, err = kv.NewTransaction(ctx, true)
+	require.NoError(err)
+	require.ErrorIs(txn.Put(ctx, key1, []byte("value1")), ErrReadOnlyTxn)
+}
@@ -24,6 +24,15 @@ type Store interface {
 	// GetBlockByHash returns block with given block header hash, or error if it's not found in Store.
 	GetBlockByHash(ctx context.Context, hash types.Hash) (*types.SignedHeader, *types.Data, error)
 
+	// GetTxLocation returns height of the block including transaction with given hash, and index of the transaction
+	// in the block, or error if it's not found in Store.
+	GetTxLocation(ctx context.Context, hash []byte) (uint64, uint32, error)
+
+	// IterateHeaders returns iterator over block headers with heights in range [from, to], in given order.
+	IterateHeaders(ctx context.Context, from, to uint64, order Order) (Iterator, error)
+	// IterateBlocks returns iterator over blocks with heights in range [from, to], in given order.
+	IterateBlocks(ctx context.Context, from, to uint64, order Order) (Iterator, error)
+
 	// SaveBlockResponses saves block responses (events, tx responses, validator set updates, etc) in Store.
 	SaveBlockResponses(ctx context.Context, height uint64, responses *abci.ResponseFinalizeBlock) error
 
@@ -41,11 +50,14 @@ type Store interface {
 	// GetExtendedCommit returns extended commit (commit with vote extensions) for a block at given height.
 	GetExtendedCommit(ctx context.Context, height uint64) (*abci.ExtendedCommitInfo, error)
 
-	// UpdateState updates state saved in Store. Only one State is stored.
+	// UpdateState updates state saved in Store. Only one State is stored as the latest state,
+	// but every update is also kept by its LastBlockHeight.
 	// If there is no State in Store, state will be saved.
 	UpdateState(ctx context.Context, state types.State) error
 	// GetState returns last state saved with UpdateState.
 	GetState(ctx context.Context) (types.State, error)
+	// GetStateAtHeight returns the state saved with UpdateState after the block at given height was applied.
+	GetStateAtHeight(ctx context.Context, height uint64) (types.State, error)
 
 	// SetMetadata saves arbitrary value in the store.
 	//
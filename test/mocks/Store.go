@@ -11,6 +11,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	store "github.com/rollkit/rollkit/store"
+
 	types "github.com/rollkit/rollkit/types"
 )
 
@@ -293,6 +295,69 @@ func (_m *Store) GetState(ctx context.Context) (types.State, error) {
 	return r0, r1
 }
 
+// GetStateAtHeight provides a mock function with given fields: ctx, height
+func (_m *Store) GetStateAtHeight(ctx context.Context, height uint64) (types.State, error) {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStateAtHeight")
+	}
+
+	var r0 types.State
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (types.State, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) types.State); ok {
+		r0 = rf(ctx, height)
+	} else {
+		r0 = ret.Get(0).(types.State)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxLocation provides a mock function with given fields: ctx, hash
+func (_m *Store) GetTxLocation(ctx context.Context, hash []byte) (uint64, uint32, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxLocation")
+	}
+
+	var r0 uint64
+	var r1 uint32
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (uint64, uint32, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) uint64); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) uint32); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []byte) error); ok {
+		r2 = rf(ctx, hash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Height provides a mock function with given fields:
 func (_m *Store) Height() uint64 {
 	ret := _m.Called()
@@ -311,6 +376,66 @@ func (_m *Store) Height() uint64 {
 	return r0
 }
 
+// IterateBlocks provides a mock function with given fields: ctx, from, to, order
+func (_m *Store) IterateBlocks(ctx context.Context, from uint64, to uint64, order store.Order) (store.Iterator, error) {
+	ret := _m.Called(ctx, from, to, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IterateBlocks")
+	}
+
+	var r0 store.Iterator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, store.Order) (store.Iterator, error)); ok {
+		return rf(ctx, from, to, order)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, store.Order) store.Iterator); ok {
+		r0 = rf(ctx, from, to, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Iterator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, store.Order) error); ok {
+		r1 = rf(ctx, from, to, order)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IterateHeaders provides a mock function with given fields: ctx, from, to, order
+func (_m *Store) IterateHeaders(ctx context.Context, from uint64, to uint64, order store.Order) (store.Iterator, error) {
+	ret := _m.Called(ctx, from, to, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IterateHeaders")
+	}
+
+	var r0 store.Iterator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, store.Order) (store.Iterator, error)); ok {
+		return rf(ctx, from, to, order)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, store.Order) store.Iterator); ok {
+		r0 = rf(ctx, from, to, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Iterator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, store.Order) error); ok {
+		r1 = rf(ctx, from, to, order)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveBlockData provides a mock function with given fields: ctx, _a1, data, signature
 func (_m *Store) SaveBlockData(ctx context.Context, _a1 *types.SignedHeader, data *types.Data, signature *types.Signature) error {
 	ret := _m.Called(ctx, _a1, data, signature)
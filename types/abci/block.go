@@ -94,7 +94,7 @@ func ToABCIBlock(header *types.SignedHeader, data *types.Data) (*cmtypes.Block,
 	abciBlock := cmtypes.Block{
 		Header: abciHeader,
 		Evidence: cmtypes.EvidenceData{
-			Evidence: nil,
+			Evidence: data.Evidence.Evidence,
 		},
 		LastCommit: abciCommit,
 	}
@@ -103,6 +103,9 @@ func ToABCIBlock(header *types.SignedHeader, data *types.Data) (*cmtypes.Block,
 		abciBlock.Data.Txs[i] = cmtypes.Tx(data.Txs[i])
 	}
 	abciBlock.Header.DataHash = cmbytes.HexBytes(header.DataHash)
+	if len(data.Evidence.Evidence) > 0 {
+		abciBlock.Header.EvidenceHash = abciBlock.Evidence.Hash()
+	}
 
 	return &abciBlock, nil
 }
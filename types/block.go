@@ -34,8 +34,7 @@ type Data struct {
 	*Metadata
 	Txs Txs
 	// IntermediateStateRoots IntermediateStateRoots
-	// Note: Temporarily remove Evidence #896
-	// Evidence               EvidenceData
+	Evidence EvidenceData
 }
 
 // EvidenceData defines how evidence is stored in block.
@@ -85,7 +84,7 @@ func Validate(header *SignedHeader, data *Data) error {
 		}
 	}
 	// exclude Metadata while computing the data hash for comparison
-	d := Data{Txs: data.Txs}
+	d := Data{Txs: data.Txs, Evidence: data.Evidence}
 	dataHash := d.Hash()
 	if !bytes.Equal(dataHash[:], header.DataHash[:]) {
 		return errors.New("dataHash from the header does not match with hash of the block's data")
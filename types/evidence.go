@@ -0,0 +1,203 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+var (
+	// ErrUnsupportedEvidence is returned for evidence types not supported by Rollkit.
+	ErrUnsupportedEvidence = errors.New("unsupported evidence type")
+	// ErrNotDoubleSign is returned when headers of DoubleSignEvidence don't prove double signing.
+	ErrNotDoubleSign = errors.New("headers are not conflicting")
+)
+
+func init() {
+	cmjson.RegisterType(&DoubleSignEvidence{}, "rollkit/DoubleSignEvidence")
+}
+
+// DoubleSignEvidence proves that the sequencer signed two different headers at the same height.
+//
+// CometBFT DuplicateVoteEvidence can't be used, because votes signed by the sequencer have no part set header,
+// so they are not valid CometBFT votes. Both headers are included instead, so evidence can be verified like any
+// other signed header.
+type DoubleSignEvidence struct {
+	// HeaderA and HeaderB are ordered by hash, so the same headers always result in the same evidence.
+	HeaderA *SignedHeader
+	HeaderB *SignedHeader
+}
+
+var _ cmtypes.Evidence = &DoubleSignEvidence{}
+
+// NewDoubleSignEvidence creates evidence of signing two given headers.
+func NewDoubleSignEvidence(header1, header2 *SignedHeader) (*DoubleSignEvidence, error) {
+	if header1 == nil || header2 == nil {
+		return nil, errors.New("missing header")
+	}
+	ev := &DoubleSignEvidence{HeaderA: header1, HeaderB: header2}
+	if bytes.Compare(header1.Hash(), header2.Hash()) > 0 {
+		ev.HeaderA, ev.HeaderB = header2, header1
+	}
+	return ev, ev.ValidateBasic()
+}
+
+// ABCI returns the application relevant representation of the evidence.
+func (e *DoubleSignEvidence) ABCI() []abci.Misbehavior {
+	val := e.HeaderA.Validators.GetProposer()
+	return []abci.Misbehavior{{
+		Type: abci.MisbehaviorType_DUPLICATE_VOTE,
+		Validator: abci.Validator{
+			Address: val.Address,
+			Power:   val.VotingPower,
+		},
+		Height:           e.Height(),
+		Time:             e.Time(),
+		TotalVotingPower: e.HeaderA.Validators.TotalVotingPower(),
+	}}
+}
+
+// Bytes returns the proto-encoded evidence as a byte array.
+func (e *DoubleSignEvidence) Bytes() []byte {
+	pbe, err := e.ToProto()
+	if err != nil {
+		panic(err)
+	}
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Hash returns the hash of the evidence.
+func (e *DoubleSignEvidence) Hash() []byte {
+	return tmhash.Sum(e.Bytes())
+}
+
+// Height returns the height at which the sequencer signed conflicting headers.
+func (e *DoubleSignEvidence) Height() int64 {
+	return int64(e.HeaderA.Height()) //nolint:gosec
+}
+
+// Time returns the time of the first (by hash) header.
+func (e *DoubleSignEvidence) Time() time.Time {
+	return e.HeaderA.Time()
+}
+
+// String returns a string representation of the evidence.
+func (e *DoubleSignEvidence) String() string {
+	return fmt.Sprintf("DoubleSignEvidence{Height: %d, Proposer: %X, HeaderA: %v, HeaderB: %v}",
+		e.Height(), e.HeaderA.ProposerAddress, e.HeaderA.Hash(), e.HeaderB.Hash())
+}
+
+// ValidateBasic checks that both headers are validly signed by the same proposer at the same height,
+// and are different.
+//
+// It doesn't check if the proposer was the sequencer at given height.
+func (e *DoubleSignEvidence) ValidateBasic() error {
+	if e == nil || e.HeaderA == nil || e.HeaderB == nil {
+		return errors.New("empty double sign evidence")
+	}
+	if err := e.HeaderA.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid HeaderA: %w", err)
+	}
+	if err := e.HeaderB.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid HeaderB: %w", err)
+	}
+	if e.HeaderA.ChainID() != e.HeaderB.ChainID() || e.HeaderA.Height() != e.HeaderB.Height() {
+		return fmt.Errorf("%w: chain ID or height mismatch", ErrNotDoubleSign)
+	}
+	if !bytes.Equal(e.HeaderA.ProposerAddress, e.HeaderB.ProposerAddress) ||
+		!bytes.Equal(e.HeaderA.Validators.Hash(), e.HeaderB.Validators.Hash()) {
+		return fmt.Errorf("%w: signed by different proposers", ErrNotDoubleSign)
+	}
+	if cmp := bytes.Compare(e.HeaderA.Hash(), e.HeaderB.Hash()); cmp == 0 {
+		return fmt.Errorf("%w: headers are the same", ErrNotDoubleSign)
+	} else if cmp > 0 {
+		return errors.New("headers in invalid order")
+	}
+	return nil
+}
+
+// ToProto converts DoubleSignEvidence into protobuf representation and returns it.
+func (e *DoubleSignEvidence) ToProto() (*pb.DoubleSignEvidence, error) {
+	headerA, err := e.HeaderA.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	headerB, err := e.HeaderB.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DoubleSignEvidence{HeaderA: headerA, HeaderB: headerB}, nil
+}
+
+// FromProto fills DoubleSignEvidence with data from its protobuf representation.
+func (e *DoubleSignEvidence) FromProto(other *pb.DoubleSignEvidence) error {
+	if other == nil || other.HeaderA == nil || other.HeaderB == nil {
+		return errors.New("empty double sign evidence")
+	}
+	e.HeaderA, e.HeaderB = new(SignedHeader), new(SignedHeader)
+	if err := e.HeaderA.FromProto(other.HeaderA); err != nil {
+		return err
+	}
+	return e.HeaderB.FromProto(other.HeaderB)
+}
+
+// EvidenceToProto converts evidence into protobuf representation.
+func EvidenceToProto(evidence cmtypes.Evidence) (*pb.Evidence, error) {
+	switch ev := evidence.(type) {
+	case *DoubleSignEvidence:
+		pbe, err := ev.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Evidence{Sum: &pb.Evidence_DoubleSignEvidence{DoubleSignEvidence: pbe}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedEvidence, evidence)
+	}
+}
+
+// EvidenceFromProto converts protobuf representation into evidence, and validates it.
+func EvidenceFromProto(evidence *pb.Evidence) (cmtypes.Evidence, error) {
+	if evidence == nil {
+		return nil, errors.New("nil evidence")
+	}
+	switch sum := evidence.Sum.(type) {
+	case *pb.Evidence_DoubleSignEvidence:
+		ev := new(DoubleSignEvidence)
+		if err := ev.FromProto(sum.DoubleSignEvidence); err != nil {
+			return nil, err
+		}
+		return ev, ev.ValidateBasic()
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedEvidence, evidence.Sum)
+	}
+}
+
+// MarshalEvidence encodes evidence into binary form.
+func MarshalEvidence(evidence cmtypes.Evidence) ([]byte, error) {
+	pbe, err := EvidenceToProto(evidence)
+	if err != nil {
+		return nil, err
+	}
+	return pbe.Marshal()
+}
+
+// UnmarshalEvidence decodes and validates evidence encoded with MarshalEvidence.
+func UnmarshalEvidence(data []byte) (cmtypes.Evidence, error) {
+	var pbe pb.Evidence
+	if err := pbe.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return EvidenceFromProto(&pbe)
+}
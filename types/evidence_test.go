@@ -0,0 +1,107 @@
+package types
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmjson "github.com/cometbft/cometbft/libs/json"
+	cmtypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleSignEvidence(t *testing.T) {
+	chainID := "TestDoubleSignEvidence"
+	header1, privKey, err := GetRandomSignedHeader(chainID)
+	require.NoError(t, err)
+	header2, err := GetConflictingSignedHeader(header1, privKey)
+	require.NoError(t, err)
+
+	ev, err := NewDoubleSignEvidence(header1, header2)
+	require.NoError(t, err)
+	// order of headers doesn't matter
+	ev2, err := NewDoubleSignEvidence(header2, header1)
+	require.NoError(t, err)
+	assert.Equal(t, ev.Hash(), ev2.Hash())
+	assert.Equal(t, int64(header1.Height()), ev.Height()) //nolint:gosec
+
+	misbehavior := ev.ABCI()
+	require.Len(t, misbehavior, 1)
+	assert.Equal(t, abci.MisbehaviorType_DUPLICATE_VOTE, misbehavior[0].Type)
+	assert.Equal(t, []byte(header1.ProposerAddress), misbehavior[0].Validator.Address)
+
+	t.Run("binary encoding", func(t *testing.T) {
+		bz, err := MarshalEvidence(ev)
+		require.NoError(t, err)
+		decoded, err := UnmarshalEvidence(bz)
+		require.NoError(t, err)
+		assert.Equal(t, ev.Hash(), decoded.Hash())
+	})
+
+	t.Run("JSON encoding", func(t *testing.T) {
+		bz, err := cmjson.Marshal(cmtypes.Evidence(ev))
+		require.NoError(t, err)
+		assert.Contains(t, string(bz), "rollkit/DoubleSignEvidence")
+		var decoded cmtypes.Evidence
+		require.NoError(t, cmjson.Unmarshal(bz, &decoded))
+		assert.Equal(t, ev.Hash(), decoded.Hash())
+	})
+
+	t.Run("data with evidence", func(t *testing.T) {
+		_, data := GetRandomBlock(1, 2, chainID)
+		data.Evidence = EvidenceData{Evidence: []cmtypes.Evidence{ev}}
+		bz, err := data.MarshalBinary()
+		require.NoError(t, err)
+		decoded := new(Data)
+		require.NoError(t, decoded.UnmarshalBinary(bz))
+		require.Len(t, decoded.Evidence.Evidence, 1)
+		assert.Equal(t, ev.Hash(), decoded.Evidence.Evidence[0].Hash())
+	})
+}
+
+func TestDoubleSignEvidenceValidateBasic(t *testing.T) {
+	chainID := "TestDoubleSignEvidenceValidateBasic"
+	header, privKey, err := GetRandomSignedHeader(chainID)
+	require.NoError(t, err)
+	conflicting, err := GetConflictingSignedHeader(header, privKey)
+	require.NoError(t, err)
+
+	next, err := GetRandomNextSignedHeader(header, privKey, chainID)
+	require.NoError(t, err)
+	otherSequencer, err := GetConflictingSignedHeader(header, ed25519.GenPrivKey())
+	require.NoError(t, err)
+	otherSequencer.Validators = GetRandomValidatorSet()
+
+	cases := []struct {
+		name    string
+		headers [2]*SignedHeader
+		wantErr bool
+		err     error
+	}{
+		{"valid", [2]*SignedHeader{header, conflicting}, false, nil},
+		{"same header", [2]*SignedHeader{header, header}, true, ErrNotDoubleSign},
+		{"different heights", [2]*SignedHeader{header, next}, true, ErrNotDoubleSign},
+		{"different proposers", [2]*SignedHeader{header, otherSequencer}, true, nil},
+		{"missing header", [2]*SignedHeader{header, nil}, true, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := NewDoubleSignEvidence(c.headers[0], c.headers[1])
+			if !c.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if c.err != nil {
+				assert.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+
+	// evidence with headers in invalid order is rejected
+	ev, err := NewDoubleSignEvidence(header, conflicting)
+	require.NoError(t, err)
+	ev.HeaderA, ev.HeaderB = ev.HeaderB, ev.HeaderA
+	assert.Error(t, ev.ValidateBasic())
+}
@@ -364,6 +364,8 @@ func (m *Metadata) GetLastDataHash() []byte {
 type Data struct {
 	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Txs      [][]byte  `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`
+	// repeated bytes intermediate_state_roots = 2;
+	Evidence []*Evidence `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty"`
 }
 
 func (m *Data) Reset()         { *m = Data{} }
@@ -413,6 +415,139 @@ func (m *Data) GetTxs() [][]byte {
 	return nil
 }
 
+func (m *Data) GetEvidence() []*Evidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+// Evidence of misbehavior, included in blocks and passed to the application as ABCI Misbehavior.
+type Evidence struct {
+	// Types that are valid to be assigned to Sum:
+	//	*Evidence_DoubleSignEvidence
+	Sum isEvidence_Sum `protobuf_oneof:"sum"`
+}
+
+func (m *Evidence) Reset()         { *m = Evidence{} }
+func (m *Evidence) String() string { return proto.CompactTextString(m) }
+func (*Evidence) ProtoMessage()    {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed489fb7f4d78b3f, []int{5}
+}
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(m, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
+
+type isEvidence_Sum interface {
+	isEvidence_Sum()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Evidence_DoubleSignEvidence struct {
+	DoubleSignEvidence *DoubleSignEvidence `protobuf:"bytes,1,opt,name=double_sign_evidence,json=doubleSignEvidence,proto3,oneof" json:"double_sign_evidence,omitempty"`
+}
+
+func (*Evidence_DoubleSignEvidence) isEvidence_Sum() {}
+
+func (m *Evidence) GetSum() isEvidence_Sum {
+	if m != nil {
+		return m.Sum
+	}
+	return nil
+}
+
+func (m *Evidence) GetDoubleSignEvidence() *DoubleSignEvidence {
+	if x, ok := m.GetSum().(*Evidence_DoubleSignEvidence); ok {
+		return x.DoubleSignEvidence
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Evidence) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Evidence_DoubleSignEvidence)(nil),
+	}
+}
+
+// DoubleSignEvidence proves that the sequencer signed two different headers at the same height.
+type DoubleSignEvidence struct {
+	HeaderA *SignedHeader `protobuf:"bytes,1,opt,name=header_a,json=headerA,proto3" json:"header_a,omitempty"`
+	HeaderB *SignedHeader `protobuf:"bytes,2,opt,name=header_b,json=headerB,proto3" json:"header_b,omitempty"`
+}
+
+func (m *DoubleSignEvidence) Reset()         { *m = DoubleSignEvidence{} }
+func (m *DoubleSignEvidence) String() string { return proto.CompactTextString(m) }
+func (*DoubleSignEvidence) ProtoMessage()    {}
+func (*DoubleSignEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed489fb7f4d78b3f, []int{6}
+}
+func (m *DoubleSignEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DoubleSignEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DoubleSignEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DoubleSignEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoubleSignEvidence.Merge(m, src)
+}
+func (m *DoubleSignEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *DoubleSignEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoubleSignEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DoubleSignEvidence proto.InternalMessageInfo
+
+func (m *DoubleSignEvidence) GetHeaderA() *SignedHeader {
+	if m != nil {
+		return m.HeaderA
+	}
+	return nil
+}
+
+func (m *DoubleSignEvidence) GetHeaderB() *SignedHeader {
+	if m != nil {
+		return m.HeaderB
+	}
+	return nil
+}
+
 type TxWithISRs struct {
 	PreIsr  []byte `protobuf:"bytes,1,opt,name=pre_isr,json=preIsr,proto3" json:"pre_isr,omitempty"`
 	Tx      []byte `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
@@ -423,7 +558,7 @@ func (m *TxWithISRs) Reset()         { *m = TxWithISRs{} }
 func (m *TxWithISRs) String() string { return proto.CompactTextString(m) }
 func (*TxWithISRs) ProtoMessage()    {}
 func (*TxWithISRs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ed489fb7f4d78b3f, []int{5}
+	return fileDescriptor_ed489fb7f4d78b3f, []int{7}
 }
 func (m *TxWithISRs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -479,50 +614,57 @@ func init() {
 	proto.RegisterType((*SignedHeader)(nil), "rollkit.SignedHeader")
 	proto.RegisterType((*Metadata)(nil), "rollkit.Metadata")
 	proto.RegisterType((*Data)(nil), "rollkit.Data")
+	proto.RegisterType((*Evidence)(nil), "rollkit.Evidence")
+	proto.RegisterType((*DoubleSignEvidence)(nil), "rollkit.DoubleSignEvidence")
 	proto.RegisterType((*TxWithISRs)(nil), "rollkit.TxWithISRs")
 }
 
 func init() { proto.RegisterFile("rollkit/rollkit.proto", fileDescriptor_ed489fb7f4d78b3f) }
 
 var fileDescriptor_ed489fb7f4d78b3f = []byte{
-	// 580 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcb, 0x6e, 0x13, 0x3f,
-	0x14, 0xc6, 0x3b, 0x49, 0x9a, 0x49, 0x4e, 0xe7, 0xdf, 0xa6, 0xd6, 0x1f, 0x18, 0x2e, 0x1a, 0x45,
-	0x11, 0x88, 0x50, 0x44, 0x22, 0xca, 0x1e, 0x89, 0x9b, 0x68, 0x16, 0x48, 0xc8, 0x45, 0x45, 0x62,
-	0x33, 0x72, 0x32, 0x56, 0xc6, 0xea, 0xcc, 0xd8, 0xb2, 0x9d, 0x12, 0xde, 0x82, 0x0d, 0x6f, 0xc1,
-	0x83, 0xb0, 0xec, 0x92, 0x25, 0x6a, 0x5e, 0x04, 0xf9, 0x32, 0x13, 0xca, 0x8e, 0x55, 0xce, 0xf9,
-	0xfc, 0xb3, 0xe7, 0x8b, 0xbf, 0x63, 0xb8, 0x21, 0x79, 0x51, 0x9c, 0x33, 0x3d, 0xf5, 0xbf, 0x13,
-	0x21, 0xb9, 0xe6, 0x28, 0xf4, 0xed, 0x9d, 0xa1, 0xa6, 0x55, 0x46, 0x65, 0xc9, 0x2a, 0x3d, 0xd5,
-	0x5f, 0x04, 0x55, 0xd3, 0x0b, 0x52, 0xb0, 0x8c, 0x68, 0x2e, 0x1d, 0x3a, 0x7a, 0x0a, 0xe1, 0x19,
-	0x95, 0x8a, 0xf1, 0x0a, 0xfd, 0x0f, 0xbb, 0xf3, 0x82, 0x2f, 0xce, 0xe3, 0x60, 0x18, 0x8c, 0x3b,
-	0xd8, 0x35, 0x68, 0x00, 0x6d, 0x22, 0x44, 0xdc, 0xb2, 0x9a, 0x29, 0x47, 0xdf, 0xdb, 0xd0, 0x3d,
-	0xa1, 0x24, 0xa3, 0x12, 0x1d, 0x41, 0x78, 0xe1, 0x76, 0xdb, 0x4d, 0x7b, 0xc7, 0x83, 0x49, 0xed,
-	0xc4, 0x9f, 0x8a, 0x6b, 0x00, 0xdd, 0x84, 0x6e, 0x4e, 0xd9, 0x32, 0xd7, 0xfe, 0x2c, 0xdf, 0x21,
-	0x04, 0x1d, 0xcd, 0x4a, 0x1a, 0xb7, 0xad, 0x6a, 0x6b, 0x34, 0x86, 0x41, 0x41, 0x94, 0x4e, 0x73,
-	0xfb, 0x99, 0x34, 0x27, 0x2a, 0x8f, 0x3b, 0xc3, 0x60, 0x1c, 0xe1, 0x7d, 0xa3, 0xbb, 0xaf, 0x9f,
-	0x10, 0x95, 0x37, 0xe4, 0x82, 0x97, 0x25, 0xd3, 0x8e, 0xdc, 0xdd, 0x92, 0xaf, 0xac, 0x6c, 0xc9,
-	0xbb, 0xd0, 0xcf, 0x88, 0x26, 0x0e, 0xe9, 0x5a, 0xa4, 0x67, 0x04, 0xbb, 0xf8, 0x00, 0xf6, 0x17,
-	0xbc, 0x52, 0xb4, 0x52, 0x2b, 0xe5, 0x88, 0xd0, 0x12, 0xff, 0x35, 0xaa, 0xc5, 0x6e, 0x43, 0x8f,
-	0x08, 0xe1, 0x80, 0x9e, 0x05, 0x42, 0x22, 0x84, 0x5d, 0x3a, 0x82, 0x43, 0x6b, 0x44, 0x52, 0xb5,
-	0x2a, 0xb4, 0x3f, 0xa4, 0x6f, 0x99, 0x03, 0xb3, 0x80, 0x9d, 0x6e, 0xd9, 0x47, 0x30, 0x10, 0x92,
-	0x0b, 0xae, 0xa8, 0x4c, 0x49, 0x96, 0x49, 0xaa, 0x54, 0x0c, 0x0e, 0xad, 0xf5, 0x17, 0x4e, 0x36,
-	0xc6, 0x9a, 0xc8, 0xdc, 0x99, 0x7b, 0xce, 0x58, 0xa3, 0xd6, 0xc6, 0x16, 0x39, 0x61, 0x55, 0xca,
-	0xb2, 0x38, 0x1a, 0x06, 0xe3, 0x3e, 0x0e, 0x6d, 0x3f, 0xcb, 0x46, 0xdf, 0x02, 0x88, 0x4e, 0xd9,
-	0xb2, 0xa2, 0x99, 0x0f, 0xed, 0xa1, 0x09, 0xc2, 0x54, 0x3e, 0xb3, 0x83, 0x26, 0x33, 0x07, 0x60,
-	0xbf, 0x8c, 0xee, 0x41, 0x5f, 0xb1, 0x65, 0x45, 0xf4, 0x4a, 0x52, 0x1b, 0x5a, 0x84, 0xb7, 0x02,
-	0x7a, 0x0e, 0xd0, 0x78, 0x50, 0x36, 0xbd, 0xbd, 0xe3, 0x64, 0xb2, 0x1d, 0xb8, 0x89, 0x1d, 0xb8,
-	0xc9, 0x59, 0xcd, 0x9c, 0x52, 0x8d, 0xff, 0xd8, 0x31, 0xfa, 0x0c, 0xbd, 0x77, 0x54, 0x13, 0x13,
-	0xc1, 0x35, 0xfb, 0xc1, 0x35, 0xfb, 0xff, 0x34, 0x36, 0xf7, 0xc1, 0x86, 0x9e, 0x6e, 0x73, 0x76,
-	0x43, 0x13, 0x19, 0xf5, 0xb5, 0xcf, 0x7a, 0xf4, 0x16, 0x3a, 0xa6, 0x46, 0x4f, 0xa0, 0x57, 0x7a,
-	0x03, 0xfe, 0x26, 0x0e, 0x9b, 0x9b, 0xa8, 0x9d, 0xe1, 0x06, 0x31, 0x0f, 0x41, 0xaf, 0x55, 0xdc,
-	0x1a, 0xb6, 0xc7, 0x11, 0x36, 0xe5, 0xe8, 0x3d, 0xc0, 0x87, 0xf5, 0x47, 0xa6, 0xf3, 0xd9, 0x29,
-	0x56, 0xe8, 0x16, 0x84, 0x42, 0xd2, 0x94, 0x29, 0x77, 0xaf, 0x11, 0xee, 0x0a, 0x49, 0x67, 0x4a,
-	0xa2, 0x7d, 0x68, 0xe9, 0xb5, 0xbf, 0xbf, 0x96, 0x5e, 0x9b, 0x3f, 0x2b, 0xb8, 0xd2, 0x96, 0x6c,
-	0xbb, 0x21, 0x32, 0xfd, 0x4c, 0xc9, 0x97, 0x6f, 0x7e, 0x5c, 0x25, 0xc1, 0xe5, 0x55, 0x12, 0xfc,
-	0xba, 0x4a, 0x82, 0xaf, 0x9b, 0x64, 0xe7, 0x72, 0x93, 0xec, 0xfc, 0xdc, 0x24, 0x3b, 0x9f, 0x1e,
-	0x2f, 0x99, 0xce, 0x57, 0xf3, 0xc9, 0x82, 0x97, 0xd3, 0xbf, 0x1e, 0xbd, 0x7f, 0xd9, 0x62, 0x5e,
-	0x0b, 0xf3, 0xae, 0x7d, 0xdb, 0xcf, 0x7e, 0x07, 0x00, 0x00, 0xff, 0xff, 0x91, 0x8b, 0xa5, 0x29,
-	0x1f, 0x04, 0x00, 0x00,
+	// 669 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xcf, 0x6e, 0x13, 0x3b,
+	0x14, 0xc6, 0x33, 0x49, 0x9a, 0x49, 0x4e, 0x73, 0xdb, 0xd4, 0x6a, 0xef, 0x9d, 0x7b, 0x7b, 0x15,
+	0x45, 0x23, 0x10, 0xa1, 0x88, 0x04, 0xca, 0x1e, 0xa9, 0xa5, 0x95, 0x9a, 0x05, 0x02, 0xb9, 0xa8,
+	0x48, 0x6c, 0x46, 0x4e, 0xc6, 0xca, 0x58, 0x9d, 0x3f, 0x96, 0xed, 0x84, 0xf0, 0x16, 0x6c, 0x78,
+	0x0b, 0x1e, 0x84, 0x65, 0x97, 0x2c, 0x51, 0xfb, 0x22, 0xc8, 0x7f, 0x66, 0xd2, 0xd2, 0x0d, 0xac,
+	0x62, 0x7f, 0xfe, 0x9d, 0x33, 0x5f, 0xe6, 0x3b, 0x63, 0xd8, 0x13, 0x45, 0x9a, 0x5e, 0x32, 0x35,
+	0x76, 0xbf, 0x23, 0x2e, 0x0a, 0x55, 0x20, 0xdf, 0x6d, 0xff, 0x1b, 0x28, 0x9a, 0xc7, 0x54, 0x64,
+	0x2c, 0x57, 0x63, 0xf5, 0x89, 0x53, 0x39, 0x5e, 0x92, 0x94, 0xc5, 0x44, 0x15, 0xc2, 0xa2, 0xe1,
+	0x73, 0xf0, 0x2f, 0xa8, 0x90, 0xac, 0xc8, 0xd1, 0x2e, 0x6c, 0x4c, 0xd3, 0x62, 0x76, 0x19, 0x78,
+	0x03, 0x6f, 0xd8, 0xc4, 0x76, 0x83, 0x7a, 0xd0, 0x20, 0x9c, 0x07, 0x75, 0xa3, 0xe9, 0x65, 0xf8,
+	0xb5, 0x01, 0xad, 0x33, 0x4a, 0x62, 0x2a, 0xd0, 0x01, 0xf8, 0x4b, 0x5b, 0x6d, 0x8a, 0x36, 0x0f,
+	0x7b, 0xa3, 0xd2, 0x89, 0xeb, 0x8a, 0x4b, 0x00, 0xfd, 0x0d, 0xad, 0x84, 0xb2, 0x79, 0xa2, 0x5c,
+	0x2f, 0xb7, 0x43, 0x08, 0x9a, 0x8a, 0x65, 0x34, 0x68, 0x18, 0xd5, 0xac, 0xd1, 0x10, 0x7a, 0x29,
+	0x91, 0x2a, 0x4a, 0xcc, 0x63, 0xa2, 0x84, 0xc8, 0x24, 0x68, 0x0e, 0xbc, 0x61, 0x17, 0x6f, 0x69,
+	0xdd, 0x3e, 0xfd, 0x8c, 0xc8, 0xa4, 0x22, 0x67, 0x45, 0x96, 0x31, 0x65, 0xc9, 0x8d, 0x35, 0xf9,
+	0xca, 0xc8, 0x86, 0xdc, 0x87, 0x4e, 0x4c, 0x14, 0xb1, 0x48, 0xcb, 0x20, 0x6d, 0x2d, 0x98, 0xc3,
+	0x87, 0xb0, 0x35, 0x2b, 0x72, 0x49, 0x73, 0xb9, 0x90, 0x96, 0xf0, 0x0d, 0xf1, 0x57, 0xa5, 0x1a,
+	0xec, 0x5f, 0x68, 0x13, 0xce, 0x2d, 0xd0, 0x36, 0x80, 0x4f, 0x38, 0x37, 0x47, 0x07, 0xb0, 0x63,
+	0x8c, 0x08, 0x2a, 0x17, 0xa9, 0x72, 0x4d, 0x3a, 0x86, 0xd9, 0xd6, 0x07, 0xd8, 0xea, 0x86, 0x7d,
+	0x0c, 0x3d, 0x2e, 0x0a, 0x5e, 0x48, 0x2a, 0x22, 0x12, 0xc7, 0x82, 0x4a, 0x19, 0x80, 0x45, 0x4b,
+	0xfd, 0xc8, 0xca, 0xda, 0x58, 0x15, 0x99, 0xed, 0xb9, 0x69, 0x8d, 0x55, 0x6a, 0x69, 0x6c, 0x96,
+	0x10, 0x96, 0x47, 0x2c, 0x0e, 0xba, 0x03, 0x6f, 0xd8, 0xc1, 0xbe, 0xd9, 0x4f, 0xe2, 0xf0, 0x8b,
+	0x07, 0xdd, 0x73, 0x36, 0xcf, 0x69, 0xec, 0x42, 0x7b, 0xa4, 0x83, 0xd0, 0x2b, 0x97, 0xd9, 0x76,
+	0x95, 0x99, 0x05, 0xb0, 0x3b, 0x46, 0xff, 0x43, 0x47, 0xb2, 0x79, 0x4e, 0xd4, 0x42, 0x50, 0x13,
+	0x5a, 0x17, 0xaf, 0x05, 0xf4, 0x12, 0xa0, 0xf2, 0x20, 0x4d, 0x7a, 0x9b, 0x87, 0xfd, 0xd1, 0x7a,
+	0xe0, 0x46, 0x66, 0xe0, 0x46, 0x17, 0x25, 0x73, 0x4e, 0x15, 0xbe, 0x55, 0x11, 0x7e, 0x84, 0xf6,
+	0x6b, 0xaa, 0x88, 0x8e, 0xe0, 0x8e, 0x7d, 0xef, 0x8e, 0xfd, 0x3f, 0x1a, 0x9b, 0x07, 0x60, 0x42,
+	0x8f, 0xd6, 0x39, 0xdb, 0xa1, 0xe9, 0x6a, 0xf5, 0xc4, 0x65, 0x1d, 0x2e, 0xa1, 0xa9, 0xd7, 0xe8,
+	0x29, 0xb4, 0x33, 0x67, 0xc0, 0xbd, 0x89, 0x9d, 0xea, 0x4d, 0x94, 0xce, 0x70, 0x85, 0xe8, 0x0f,
+	0x41, 0xad, 0x64, 0x50, 0x1f, 0x34, 0x86, 0x5d, 0xac, 0x97, 0xba, 0x01, 0x5d, 0xb2, 0x98, 0xe6,
+	0x33, 0x6d, 0xa3, 0x71, 0xa7, 0xc1, 0xa9, 0x3b, 0xc0, 0x15, 0x12, 0x4e, 0xa1, 0x5d, 0xaa, 0xe8,
+	0x0d, 0xec, 0xc6, 0xc5, 0x62, 0x9a, 0xd2, 0x48, 0xbf, 0xd0, 0xa8, 0x6a, 0x63, 0x7d, 0xec, 0x57,
+	0x6d, 0x4e, 0x0c, 0xa4, 0xe3, 0x2b, 0x4b, 0xcf, 0x6a, 0x18, 0xc5, 0xf7, 0xd4, 0xe3, 0x0d, 0x68,
+	0xc8, 0x45, 0x16, 0xae, 0x00, 0xdd, 0x2f, 0x41, 0xcf, 0xa0, 0xed, 0xbe, 0xa4, 0xf2, 0x9f, 0xee,
+	0x55, 0x4f, 0xb8, 0x3d, 0x1a, 0xd8, 0xb7, 0xd8, 0xd1, 0xad, 0x8a, 0x69, 0x50, 0xff, 0x8d, 0x8a,
+	0xe3, 0xf0, 0x2d, 0xc0, 0xbb, 0xd5, 0x7b, 0xa6, 0x92, 0xc9, 0x39, 0x96, 0xe8, 0x1f, 0xf0, 0xb9,
+	0xa0, 0x11, 0x93, 0x76, 0xc8, 0xba, 0xb8, 0xc5, 0x05, 0x9d, 0x48, 0x81, 0xb6, 0xa0, 0xae, 0x56,
+	0x6e, 0x98, 0xea, 0x6a, 0xa5, 0x93, 0xe7, 0x85, 0x54, 0x86, 0x6c, 0xd8, 0x2f, 0x4a, 0xef, 0x27,
+	0x52, 0x1c, 0x9f, 0x7e, 0xbb, 0xee, 0x7b, 0x57, 0xd7, 0x7d, 0xef, 0xc7, 0x75, 0xdf, 0xfb, 0x7c,
+	0xd3, 0xaf, 0x5d, 0xdd, 0xf4, 0x6b, 0xdf, 0x6f, 0xfa, 0xb5, 0x0f, 0x4f, 0xe6, 0x4c, 0x25, 0x8b,
+	0xe9, 0x68, 0x56, 0x64, 0xe3, 0x5f, 0x6e, 0x40, 0x77, 0xcd, 0xf1, 0x69, 0x29, 0x4c, 0x5b, 0xe6,
+	0xa2, 0x7b, 0xf1, 0x73, 0x00, 0xb3, 0x39, 0x12, 0xf0, 0x2c, 0x05, 0x00, 0x00,
 }
 
 func (m *Version) Marshal() (dAtA []byte, err error) {
@@ -787,6 +929,20 @@ func (m *Data) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Evidence) > 0 {
+		for iNdEx := len(m.Evidence) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Evidence[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRollkit(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.Txs) > 0 {
 		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Txs[iNdEx])
@@ -811,6 +967,106 @@ func (m *Data) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Evidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Evidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Evidence_DoubleSignEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence_DoubleSignEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DoubleSignEvidence != nil {
+		{
+			size, err := m.DoubleSignEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRollkit(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *DoubleSignEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DoubleSignEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DoubleSignEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HeaderB != nil {
+		{
+			size, err := m.HeaderB.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRollkit(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.HeaderA != nil {
+		{
+			size, err := m.HeaderA.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRollkit(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TxWithISRs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -996,6 +1252,53 @@ func (m *Data) Size() (n int) {
 			n += 1 + l + sovRollkit(uint64(l))
 		}
 	}
+	if len(m.Evidence) > 0 {
+		for _, e := range m.Evidence {
+			l = e.Size()
+			n += 1 + l + sovRollkit(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Evidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sum != nil {
+		n += m.Sum.Size()
+	}
+	return n
+}
+
+func (m *Evidence_DoubleSignEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DoubleSignEvidence != nil {
+		l = m.DoubleSignEvidence.Size()
+		n += 1 + l + sovRollkit(uint64(l))
+	}
+	return n
+}
+func (m *DoubleSignEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HeaderA != nil {
+		l = m.HeaderA.Size()
+		n += 1 + l + sovRollkit(uint64(l))
+	}
+	if m.HeaderB != nil {
+		l = m.HeaderB.Size()
+		n += 1 + l + sovRollkit(uint64(l))
+	}
 	return n
 }
 
@@ -1949,6 +2252,247 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
 			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Evidence = append(m.Evidence, &Evidence{})
+			if err := m.Evidence[len(m.Evidence)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRollkit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Evidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRollkit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DoubleSignEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DoubleSignEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Evidence_DoubleSignEvidence{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRollkit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DoubleSignEvidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRollkit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DoubleSignEvidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DoubleSignEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderA", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HeaderA == nil {
+				m.HeaderA = &SignedHeader{}
+			}
+			if err := m.HeaderA.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderB", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HeaderB == nil {
+				m.HeaderB = &SignedHeader{}
+			}
+			if err := m.HeaderB.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRollkit(dAtA[iNdEx:])
@@ -181,8 +181,7 @@ func (d *Data) ToProto() *pb.Data {
 		Metadata: mProto,
 		Txs:      txsToByteSlices(d.Txs),
 		// IntermediateStateRoots: d.IntermediateStateRoots.RawRootsList,
-		// Note: Temporarily remove Evidence #896
-		// Evidence:               evidenceToProto(d.Evidence),
+		Evidence: evidenceToProto(d.Evidence),
 	}
 }
 
@@ -196,8 +195,11 @@ func (d *Data) FromProto(other *pb.Data) error {
 	}
 	d.Txs = byteSlicesToTxs(other.Txs)
 	// d.IntermediateStateRoots.RawRootsList = other.IntermediateStateRoots
-	// Note: Temporarily remove Evidence #896
-	// d.Evidence = evidenceFromProto(other.Evidence)
+	evidence, err := evidenceFromProto(other.Evidence)
+	if err != nil {
+		return err
+	}
+	d.Evidence = evidence
 
 	return nil
 }
@@ -294,24 +296,34 @@ func byteSlicesToTxs(bytes [][]byte) Txs {
 	return txs
 }
 
-// Note: Temporarily remove Evidence #896
-
-// func evidenceToProto(evidence EvidenceData) []*abci.Evidence {
-// 	var ret []*abci.Evidence
-// 	for _, e := range evidence.Evidence {
-// 		for i := range e.ABCI() {
-// 			ae := e.ABCI()[i]
-// 			ret = append(ret, &ae)
-// 		}
-// 	}
-// 	return ret
-// }
-
-// func evidenceFromProto(evidence []*abci.Evidence) EvidenceData {
-// 	var ret EvidenceData
-// 	// TODO(tzdybal): right now Evidence is just an interface without implementations
-// 	return ret
-// }
+// evidenceToProto converts evidence included in block into protobuf representation. Evidence is validated
+// before inclusion in block, so conversion errors are not expected; evidence that can't be converted is skipped.
+func evidenceToProto(evidence EvidenceData) []*pb.Evidence {
+	if len(evidence.Evidence) == 0 {
+		return nil
+	}
+	ret := make([]*pb.Evidence, 0, len(evidence.Evidence))
+	for _, e := range evidence.Evidence {
+		pbe, err := EvidenceToProto(e)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, pbe)
+	}
+	return ret
+}
+
+func evidenceFromProto(evidence []*pb.Evidence) (EvidenceData, error) {
+	var ret EvidenceData
+	for _, pbe := range evidence {
+		e, err := EvidenceFromProto(pbe)
+		if err != nil {
+			return EvidenceData{}, err
+		}
+		ret.Evidence = append(ret.Evidence, e)
+	}
+	return ret, nil
+}
 
 // ConsensusParamsFromProto converts protobuf consensus parameters to consensus parameters
 func ConsensusParamsFromProto(pbParams cmproto.ConsensusParams) types.ConsensusParams {
@@ -245,6 +245,22 @@ func GetRandomNextSignedHeader(signedHeader *SignedHeader, privKey cmcrypto.Priv
 	return newSignedHeader, nil
 }
 
+// GetConflictingSignedHeader returns a header with the same height and proposer as the provided signed header,
+// but with different data hash, signed with given private key.
+func GetConflictingSignedHeader(signedHeader *SignedHeader, privKey cmcrypto.PrivKey) (*SignedHeader, error) {
+	conflicting := &SignedHeader{
+		Header:     signedHeader.Header,
+		Validators: signedHeader.Validators,
+	}
+	conflicting.DataHash = GetRandomBytes(32)
+	signature, err := GetSignature(conflicting.Header, privKey)
+	if err != nil {
+		return nil, err
+	}
+	conflicting.Signature = *signature
+	return conflicting, nil
+}
+
 // GetNodeKey creates libp2p private key from Tendermints NodeKey.
 func GetNodeKey(nodeKey *p2p.NodeKey) (crypto.PrivKey, error) {
 	if nodeKey == nil || nodeKey.PrivKey == nil {